@@ -5,7 +5,9 @@ import (
 	_ "embed"
 	"encoding/hex"
 	"fmt"
+	"slices"
 	"strconv"
+	"strings"
 
 	"github.com/plainkit/html"
 	"github.com/plainkit/icons/lucide"
@@ -13,12 +15,22 @@ import (
 )
 
 type Props struct {
-	ID       string
-	Class    string
-	Attrs    []html.Global
-	Autoplay bool
-	Interval int
-	Loop     bool
+	ID          string
+	Class       string
+	Attrs       []html.Global
+	Autoplay    bool // Pauses on hover, on focus, in background tabs and for reduced motion
+	Interval    int
+	Loop        bool
+	Label       string       // Accessible name, such as "Featured products"
+	PerView     int          // Slides shown at once (default 1)
+	Breakpoints []Breakpoint // Slides shown at once from wider viewports up
+}
+
+// Breakpoint shows PerView slides at once from a viewport width of MinWidth CSS pixels
+// up, like a Tailwind breakpoint.
+type Breakpoint struct {
+	MinWidth int
+	PerView  int
 }
 
 type ContentProps struct {
@@ -52,6 +64,32 @@ type IndicatorsProps struct {
 	Count int
 }
 
+type ThumbnailsProps struct {
+	ID    string
+	Class string
+	Attrs []html.Global
+	For   string // ID of the carousel, when the strip is rendered outside of it
+}
+
+type ThumbnailProps struct {
+	ID    string
+	Class string
+	Attrs []html.Global
+	Index int    // Slide shown by the thumbnail, from 0
+	Label string // Default "Go to slide N"
+}
+
+type ImageProps struct {
+	ID     string
+	Class  string
+	Attrs  []html.Global
+	Src    string
+	Srcset string
+	Sizes  string
+	Alt    string
+	Eager  bool // Loads right away, for the slides visible on page load
+}
+
 func divArgsFromProps(baseClass string, extra ...string) func(p Props) []html.DivArg {
 	return func(p Props) []html.DivArg {
 		args := []html.DivArg{html.AClass(html.ClassMerge(append([]string{baseClass}, append(extra, p.Class)...)...))}
@@ -78,16 +116,30 @@ func (p Props) ApplyDiv(attrs *html.DivAttrs, children *[]html.Component) {
 		interval = 5000
 	}
 
+	perView := max(p.PerView, 1)
+
 	args := divArgsFromProps(styles.Surface("relative w-full overflow-hidden rounded-3xl"))(p)
 	args = append([]html.DivArg{
 		html.AId(id),
+		html.ACustom("role", "region"),
+		html.AAria("roledescription", "carousel"),
+		html.AStyle("--pui-carousel-per-view: " + strconv.Itoa(perView) + ";"),
 		html.AData("pui-carousel", ""),
 		html.AData("pui-carousel-current", "0"),
 		html.AData("pui-carousel-autoplay", strconv.FormatBool(p.Autoplay)),
 		html.AData("pui-carousel-interval", fmt.Sprintf("%d", interval)),
 		html.AData("pui-carousel-loop", strconv.FormatBool(p.Loop)),
+		html.AData("pui-carousel-per-view", strconv.Itoa(perView)),
 	}, args...)
 
+	if p.Label != "" {
+		args = append(args, html.AAria("label", p.Label))
+	}
+
+	if len(p.Breakpoints) > 0 {
+		args = append(args, html.AData("pui-carousel-breakpoints", breakpoints(p.Breakpoints)))
+	}
+
 	for _, a := range args {
 		a.ApplyDiv(attrs, children)
 	}
@@ -111,8 +163,12 @@ func (p ContentProps) ApplyDiv(attrs *html.DivAttrs, children *[]html.Component)
 }
 
 func (p ItemProps) ApplyDiv(attrs *html.DivAttrs, children *[]html.Component) {
-	args := []html.DivArg{html.AClass(html.ClassMerge("relative h-full w-full shrink-0", p.Class))}
-	args = append(args, html.AData("pui-carousel-item", ""))
+	args := []html.DivArg{html.AClass(html.ClassMerge("relative h-full shrink-0", itemWidthClass, p.Class))}
+	args = append(args,
+		html.AData("pui-carousel-item", ""),
+		html.ACustom("role", "group"),
+		html.AAria("roledescription", "slide"),
+	)
 
 	if p.ID != "" {
 		args = append(args, html.AId(p.ID))
@@ -127,6 +183,10 @@ func (p ItemProps) ApplyDiv(attrs *html.DivAttrs, children *[]html.Component) {
 	}
 }
 
+// itemWidthClass shares the track among the slides in view, less the gaps between them.
+// carousel.js sets --pui-carousel-gap to the track's gap; 1.5rem matches gap-6.
+const itemWidthClass = "w-[calc((100%_-_(var(--pui-carousel-per-view,1)_-_1)_*_var(--pui-carousel-gap,1.5rem))_/_var(--pui-carousel-per-view,1))]"
+
 func (p PreviousProps) ApplyButton(attrs *html.ButtonAttrs, children *[]html.Component) {
 	args := []html.ButtonArg{
 		html.AClass(html.ClassMerge(styles.InteractiveGhost("absolute left-4 top-1/2 -translate-y-1/2 size-10 rounded-full bg-background/80 shadow-lg backdrop-blur", "hover:bg-background"), p.Class)),
@@ -215,6 +275,94 @@ func (p IndicatorsProps) ApplyDiv(attrs *html.DivAttrs, children *[]html.Compone
 	}
 }
 
+func (p ThumbnailsProps) ApplyDiv(attrs *html.DivAttrs, children *[]html.Component) {
+	args := []html.DivArg{
+		html.AClass(html.ClassMerge("relative flex gap-2 overflow-x-auto p-1", p.Class)),
+		html.AData("pui-carousel-thumbnails", ""),
+	}
+
+	if p.For != "" {
+		args = append(args, html.AData("pui-carousel-for", p.For), html.AAria("controls", p.For))
+	}
+
+	if p.ID != "" {
+		args = append(args, html.AId(p.ID))
+	}
+
+	for _, a := range p.Attrs {
+		args = append(args, a)
+	}
+
+	for _, a := range args {
+		a.ApplyDiv(attrs, children)
+	}
+}
+
+func (p ThumbnailProps) ApplyButton(attrs *html.ButtonAttrs, children *[]html.Component) {
+	label := p.Label
+	if label == "" {
+		label = fmt.Sprintf("Go to slide %d", p.Index+1)
+	}
+
+	args := []html.ButtonArg{
+		html.AClass(html.ClassMerge(styles.InteractiveGhost(
+			"h-16 w-24 shrink-0 overflow-hidden rounded-lg p-0 opacity-60",
+			"hover:opacity-100 data-[pui-carousel-active=true]:opacity-100 aria-[current=true]:ring-2 aria-[current=true]:ring-primary",
+		), p.Class)),
+		html.AData("pui-carousel-thumbnail", strconv.Itoa(p.Index)),
+		html.AData("pui-carousel-active", strconv.FormatBool(p.Index == 0)),
+		html.AAria("current", strconv.FormatBool(p.Index == 0)),
+		html.AAria("label", label),
+		html.AType("button"),
+	}
+
+	if p.ID != "" {
+		args = append(args, html.AId(p.ID))
+	}
+
+	for _, a := range p.Attrs {
+		args = append(args, a)
+	}
+
+	for _, a := range args {
+		a.ApplyButton(attrs, children)
+	}
+}
+
+func (p ImageProps) ApplyImg(attrs *html.ImgAttrs, children *[]html.Component) {
+	args := []html.ImgArg{
+		html.AClass(html.ClassMerge("h-full w-full object-cover", p.Class)),
+		html.AAlt(p.Alt),
+	}
+
+	args = append(args, html.ASrc(p.Src))
+	if p.Srcset != "" {
+		args = append(args, html.ASrcset(p.Srcset))
+	}
+
+	// The browser loads lazy images once in view; carousel.js also loads the slides
+	// next to it, so they are ready when shown
+	if !p.Eager {
+		args = append(args, html.ALoading("lazy"))
+	}
+
+	if p.Sizes != "" {
+		args = append(args, html.ASizes(p.Sizes))
+	}
+
+	if p.ID != "" {
+		args = append(args, html.AId(p.ID))
+	}
+
+	for _, a := range p.Attrs {
+		args = append(args, a)
+	}
+
+	for _, a := range args {
+		a.ApplyImg(attrs, children)
+	}
+}
+
 // Carousel renders a carousel container for sliding content
 func Carousel(args ...html.DivArg) html.Node {
 	var (
@@ -230,7 +378,16 @@ func Carousel(args ...html.DivArg) html.Node {
 		}
 	}
 
-	return html.Div(append([]html.DivArg{props}, rest...)...).WithAssets("", carouselJS, "ui-carousel")
+	// Announces the slides in view after navigation. carousel.js turns it off while
+	// autoplay rotates, so screen readers are not interrupted every few seconds.
+	live := html.Div(
+		html.AClass("sr-only"),
+		html.AData("pui-carousel-live", ""),
+		html.AAria("live", "polite"),
+		html.AAria("atomic", "true"),
+	)
+
+	return html.Div(append(append([]html.DivArg{props}, rest...), live)...).WithAssets("", carouselJS, "ui-carousel")
 }
 
 // Content creates the carousel track that contains the items
@@ -323,6 +480,75 @@ func Indicators(args ...html.DivArg) html.Node {
 	return html.Div(append([]html.DivArg{props}, rest...)...)
 }
 
+// Thumbnails renders a strip of Thumbnail buttons, inside the carousel or linked to it
+// with For
+func Thumbnails(args ...html.DivArg) html.Node {
+	var (
+		props ThumbnailsProps
+		rest  []html.DivArg
+	)
+
+	for _, a := range args {
+		if v, ok := a.(ThumbnailsProps); ok {
+			props = v
+		} else {
+			rest = append(rest, a)
+		}
+	}
+
+	return html.Div(append([]html.DivArg{props}, rest...)...).WithAssets("", carouselJS, "ui-carousel")
+}
+
+// Thumbnail creates a button showing a preview of the slide at Index
+func Thumbnail(args ...html.ButtonArg) html.Node {
+	var (
+		props ThumbnailProps
+		rest  []html.ButtonArg
+	)
+
+	for _, a := range args {
+		if v, ok := a.(ThumbnailProps); ok {
+			props = v
+		} else {
+			rest = append(rest, a)
+		}
+	}
+
+	return html.Button(append([]html.ButtonArg{props}, rest...)...)
+}
+
+// Image creates a slide image that loads when its slide comes near the view, unless
+// Eager is set. Without JavaScript the browser's native lazy loading applies.
+func Image(args ...html.ImgArg) html.Node {
+	var (
+		props ImageProps
+		rest  []html.ImgArg
+	)
+
+	for _, a := range args {
+		if v, ok := a.(ImageProps); ok {
+			props = v
+		} else {
+			rest = append(rest, a)
+		}
+	}
+
+	return html.Img(append([]html.ImgArg{props}, rest...)...)
+}
+
+// breakpoints encodes breakpoints as "min:count" pairs sorted by width, for carousel.js.
+func breakpoints(bps []Breakpoint) string {
+	sorted := slices.Clone(bps)
+	slices.SortFunc(sorted, func(a, b Breakpoint) int { return a.MinWidth - b.MinWidth })
+
+	pairs := make([]string, 0, len(sorted))
+	for _, bp := range sorted {
+		pairs = append(pairs, strconv.Itoa(bp.MinWidth)+":"+strconv.Itoa(max(bp.PerView, 1)))
+	}
+
+	return strings.Join(pairs, ",")
+}
+
 func randomID(prefix string) string {
 	buf := make([]byte, 4)
 	if _, err := rand.Read(buf); err != nil {
@@ -3,105 +3,208 @@
 
   const autoplays = new Map();
   let dragState = null;
-  const prefersReducedMotion =
-    typeof window !== "undefined" &&
-    window.matchMedia("(prefers-reduced-motion: reduce)").matches;
+
+  // Reasons autoplay is paused, by carousel: "hover", "focus" or "drag"
+  const pauses = new WeakMap();
+
+  // Carousels scrolled into view
+  const visible = new WeakSet();
+
+  const reducedMotion = window.matchMedia("(prefers-reduced-motion: reduce)");
+
+  function carouselOf(el) {
+    if (!(el instanceof Element)) return null;
+
+    const strip = el.closest("[data-pui-carousel-thumbnails][data-pui-carousel-for]");
+    if (strip && !strip.closest("[data-pui-carousel]")) {
+      return document.getElementById(strip.getAttribute("data-pui-carousel-for"));
+    }
+
+    return el.closest("[data-pui-carousel]");
+  }
+
+  // Elements of the carousel matching selector, leaving out nested carousels
+  function parts(carousel, selector) {
+    return Array.from(carousel.querySelectorAll(selector)).filter(
+      (el) => el.closest("[data-pui-carousel]") === carousel,
+    );
+  }
+
+  function thumbnails(carousel) {
+    const linked = carousel.id
+      ? Array.from(
+          document.querySelectorAll(
+            '[data-pui-carousel-for="' + CSS.escape(carousel.id) + '"] [data-pui-carousel-thumbnail]',
+          ),
+        )
+      : [];
+    return parts(carousel, "[data-pui-carousel-thumbnail]").concat(linked);
+  }
+
+  function current(carousel) {
+    return parseInt(carousel.getAttribute("data-pui-carousel-current"), 10) || 0;
+  }
+
+  function isLoop(carousel) {
+    return carousel.getAttribute("data-pui-carousel-loop") === "true";
+  }
+
+  // Slides in view for the viewport width: the widest breakpoint reached, else the base
+  function perView(carousel) {
+    let n = parseInt(carousel.getAttribute("data-pui-carousel-per-view"), 10) || 1;
+
+    (carousel.getAttribute("data-pui-carousel-breakpoints") || "").split(",").forEach((bp) => {
+      const [min, count] = bp.split(":").map(Number);
+      if (count && window.innerWidth >= min) n = count;
+    });
+
+    return Math.max(1, n);
+  }
+
+  // Last index the track can show from: the slides after it fill the view
+  function lastIndex(carousel) {
+    return Math.max(0, parts(carousel, "[data-pui-carousel-item]").length - perView(carousel));
+  }
+
+  // Offset of the slide at index from the first one, in pixels
+  function offsetOf(items, index) {
+    return items[index] ? items[index].offsetLeft - items[0].offsetLeft : 0;
+  }
 
   // Click handling for navigation
   document.addEventListener("click", (e) => {
     const prevBtn = e.target.closest("[data-pui-carousel-prev]");
     if (prevBtn) {
-      const carousel = prevBtn.closest("[data-pui-carousel]");
+      const carousel = carouselOf(prevBtn);
       if (carousel) navigate(carousel, -1);
       return;
     }
 
     const nextBtn = e.target.closest("[data-pui-carousel-next]");
     if (nextBtn) {
-      const carousel = nextBtn.closest("[data-pui-carousel]");
+      const carousel = carouselOf(nextBtn);
       if (carousel) navigate(carousel, 1);
       return;
     }
 
-    const indicator = e.target.closest("[data-pui-carousel-indicator]");
-    if (indicator) {
-      const carousel = indicator.closest("[data-pui-carousel]");
-      const index = parseInt(indicator.dataset.tuiCarouselIndicator);
+    const control = e.target.closest("[data-pui-carousel-indicator], [data-pui-carousel-thumbnail]");
+    if (control) {
+      const carousel = carouselOf(control);
+      const index = parseInt(
+        control.getAttribute("data-pui-carousel-indicator") ?? control.getAttribute("data-pui-carousel-thumbnail"),
+        10,
+      );
       if (carousel && !isNaN(index)) {
-        updateCarousel(carousel, index);
+        updateCarousel(carousel, index, true);
       }
     }
   });
 
+  // A drag that moved the track is not a click on the slide under the pointer
+  document.addEventListener(
+    "click",
+    (e) => {
+      if (!e.target.closest?.("[data-pui-carousel-dragged]")) return;
+      e.target.closest("[data-pui-carousel-dragged]").removeAttribute("data-pui-carousel-dragged");
+      e.preventDefault();
+      e.stopPropagation();
+    },
+    true,
+  );
+
   // Drag/swipe handling
   function startDrag(e) {
+    if (e.button > 0) return;
+
     const track = e.target.closest("[data-pui-carousel-track]");
     if (!track) return;
 
     const carousel = track.closest("[data-pui-carousel]");
     if (!carousel) return;
 
-    e.preventDefault();
-    const clientX = e.touches ? e.touches[0].clientX : e.clientX;
+    track.removeAttribute("data-pui-carousel-dragged");
+
+    // Mice would otherwise drag the image or select text; fingers keep scrolling the page
+    // until the swipe turns out to be horizontal
+    if (!e.touches) e.preventDefault();
+
+    const point = e.touches ? e.touches[0] : e;
+    const items = parts(carousel, "[data-pui-carousel-item]");
 
     dragState = {
       carousel,
       track,
-      startX: clientX,
-      currentX: clientX,
+      items,
+      startX: point.clientX,
+      startY: point.clientY,
+      currentX: point.clientX,
+      base: -offsetOf(items, current(carousel)),
       startTime: Date.now(),
+      horizontal: !e.touches,
     };
 
     track.style.cursor = "grabbing";
     track.style.transition = "none";
-    stopAutoplay(carousel);
+    pause(carousel, "drag", true);
   }
 
   function doDrag(e) {
     if (!dragState) return;
 
-    const clientX = e.touches ? e.touches[0].clientX : e.clientX;
-    dragState.currentX = clientX;
+    const point = e.touches ? e.touches[0] : e;
+    const diff = point.clientX - dragState.startX;
 
-    const diff = clientX - dragState.startX;
-    const currentIndex = parseInt(
-      dragState.carousel.dataset.tuiCarouselCurrent || "0",
-    );
-    const offset =
-      -currentIndex * 100 + (diff / dragState.track.offsetWidth) * 100;
+    if (!dragState.horizontal) {
+      const dy = point.clientY - dragState.startY;
+      if (Math.abs(diff) < 5 && Math.abs(dy) < 5) return;
 
-    dragState.track.style.transform = "translateX(" + offset + "%)";
+      // A vertical swipe scrolls the page instead
+      if (Math.abs(dy) > Math.abs(diff)) {
+        cancelDrag();
+        return;
+      }
+
+      dragState.horizontal = true;
+    }
+
+    if (e.cancelable) e.preventDefault();
+    dragState.currentX = point.clientX;
+    dragState.track.style.transform = "translateX(" + (dragState.base + diff) + "px)";
+  }
+
+  function cancelDrag() {
+    const { carousel, track } = dragState;
+    dragState = null;
+    track.style.cursor = "";
+    track.style.transition = "";
+    pause(carousel, "drag", false);
   }
 
   function endDrag(e) {
     if (!dragState) return;
 
-    const { carousel, track, startX, startTime } = dragState;
-    const clientX = e.changedTouches
-      ? e.changedTouches[0].clientX
-      : e.clientX || dragState.currentX;
+    const { carousel, track, items, startX, startTime } = dragState;
+    const clientX = e.changedTouches ? e.changedTouches[0].clientX : e.clientX || dragState.currentX;
 
     track.style.cursor = "";
     track.style.transition = "";
 
     const diff = startX - clientX;
     const velocity = Math.abs(diff) / (Date.now() - startTime);
+    const width = items[0] ? items[0].offsetWidth : track.offsetWidth;
+
+    if (Math.abs(diff) > 5) track.setAttribute("data-pui-carousel-dragged", "");
 
     if (Math.abs(diff) > 50 || velocity > 0.5) {
-      navigate(carousel, diff > 0 ? 1 : -1);
+      // Long drags move by as many slides as were dragged past
+      const steps = Math.max(1, Math.round(Math.abs(diff) / width));
+      navigate(carousel, diff > 0 ? steps : -steps);
     } else {
-      const currentIndex = parseInt(carousel.dataset.tuiCarouselCurrent || "0");
-      updateCarousel(carousel, currentIndex);
+      updateCarousel(carousel, current(carousel));
     }
 
     dragState = null;
-
-    if (
-      carousel.dataset.tuiCarouselAutoplay === "true" &&
-      !carousel.matches(":hover")
-    ) {
-      startAutoplay(carousel);
-    }
+    pause(carousel, "drag", false);
   }
 
   document.addEventListener("mousedown", startDrag);
@@ -111,94 +214,173 @@
     if (e.target === document.documentElement) endDrag(e);
   });
 
-  document.addEventListener("touchstart", startDrag, { passive: false });
+  document.addEventListener("touchstart", startDrag, { passive: true });
   document.addEventListener("touchmove", doDrag, { passive: false });
   document.addEventListener("touchend", endDrag, { passive: false });
 
   // Navigation logic
   function navigate(carousel, direction) {
-    const current = parseInt(carousel.dataset.tuiCarouselCurrent || "0");
-    const items = carousel.querySelectorAll("[data-pui-carousel-item]");
-    const count = items.length;
-
+    const count = parts(carousel, "[data-pui-carousel-item]").length;
     if (count === 0) return;
 
-    let next = current + direction;
+    const last = lastIndex(carousel);
+    let next = current(carousel) + direction;
 
-    if (carousel.dataset.tuiCarouselLoop === "true") {
-      next = ((next % count) + count) % count;
+    if (isLoop(carousel)) {
+      next = ((next % (last + 1)) + last + 1) % (last + 1);
     } else {
-      next = Math.max(0, Math.min(next, count - 1));
+      next = Math.max(0, Math.min(next, last));
     }
 
-    updateCarousel(carousel, next);
+    updateCarousel(carousel, next, true);
   }
 
-  function updateCarousel(carousel, index) {
-    const track = carousel.querySelector("[data-pui-carousel-track]");
-    const indicators = carousel.querySelectorAll(
-      "[data-pui-carousel-indicator]",
-    );
-    const prevBtn = carousel.querySelector("[data-pui-carousel-prev]");
-    const nextBtn = carousel.querySelector("[data-pui-carousel-next]");
-    const items = carousel.querySelectorAll("[data-pui-carousel-item]");
+  // Load the lazy images of the slides from first to last now, rather than once they
+  // scroll into view
+  function loadImages(items, first, last) {
+    items.slice(Math.max(0, first), last + 1).forEach((item) => {
+      item.querySelectorAll('img[loading="lazy"]').forEach((img) => {
+        img.loading = "eager";
+      });
+    });
+  }
+
+  // Scroll the thumbnail strip, not the page, so the thumbnail is in view
+  function reveal(thumb) {
+    const strip = thumb.closest("[data-pui-carousel-thumbnails]");
+    if (!strip || strip.scrollWidth <= strip.clientWidth) return;
+
+    strip.scrollTo({
+      left: thumb.offsetLeft - (strip.clientWidth - thumb.offsetWidth) / 2,
+      behavior: reducedMotion.matches ? "auto" : "smooth",
+    });
+  }
+
+  function announce(carousel, index, view, count) {
+    const live = parts(carousel, "[data-pui-carousel-live]")[0];
+    if (!live) return;
+
+    const last = Math.min(index + view, count);
+    live.textContent =
+      last - index > 1
+        ? "Slides " + (index + 1) + " to " + last + " of " + count
+        : "Slide " + (index + 1) + " of " + count;
+  }
+
+  function updateCarousel(carousel, index, notify) {
+    const track = parts(carousel, "[data-pui-carousel-track]")[0];
+    const prevBtn = parts(carousel, "[data-pui-carousel-prev]")[0];
+    const nextBtn = parts(carousel, "[data-pui-carousel-next]")[0];
+    const items = parts(carousel, "[data-pui-carousel-item]");
     const count = items.length;
+    const view = perView(carousel);
+    const last = lastIndex(carousel);
 
-    carousel.dataset.tuiCarouselCurrent = index;
+    index = Math.max(0, Math.min(index, last));
+    carousel.setAttribute("data-pui-carousel-current", index);
+    carousel.style.setProperty("--pui-carousel-per-view", view);
+    // Slide widths subtract the track's gap, which callers may change with a class
+    if (track) {
+      const gap = getComputedStyle(track).columnGap;
+      carousel.style.setProperty("--pui-carousel-gap", gap && gap !== "normal" ? gap : "0px");
+    }
 
     if (track) {
-      track.style.transform = "translateX(-" + index * 100 + "%)";
+      track.style.transitionDuration = reducedMotion.matches ? "0s" : "";
+      track.style.transform = "translateX(" + -offsetOf(items, index) + "px)";
     }
 
-    indicators.forEach((ind, i) => {
-      const isActive = i === index;
-      ind.dataset.tuiCarouselActive = isActive ? "true" : "false";
+    const inView = (i) => i >= index && i < index + view;
+
+    parts(carousel, "[data-pui-carousel-indicator]").forEach((ind) => {
+      const isActive = inView(parseInt(ind.getAttribute("data-pui-carousel-indicator"), 10));
+      ind.setAttribute("data-pui-carousel-active", isActive ? "true" : "false");
       ind.classList.toggle("bg-primary", isActive);
       ind.classList.toggle("bg-foreground/30", !isActive);
       ind.setAttribute("aria-current", isActive ? "true" : "false");
     });
 
+    thumbnails(carousel).forEach((thumb) => {
+      const i = parseInt(thumb.getAttribute("data-pui-carousel-thumbnail"), 10);
+      thumb.setAttribute("data-pui-carousel-active", inView(i) ? "true" : "false");
+      thumb.setAttribute("aria-current", i === index ? "true" : "false");
+      if (i === index) reveal(thumb);
+    });
+
+    // Slides out of view can be neither read nor tabbed into
     items.forEach((item, i) => {
-      const isActive = i === index;
-      item.setAttribute("aria-hidden", isActive ? "false" : "true");
-      item.tabIndex = isActive ? 0 : -1;
+      item.inert = !inView(i);
+      if (!item.hasAttribute("aria-label") || item.hasAttribute("data-pui-carousel-labelled")) {
+        item.setAttribute("aria-label", i + 1 + " of " + count);
+        item.setAttribute("data-pui-carousel-labelled", "");
+      }
     });
 
-    const isLoop = carousel.dataset.tuiCarouselLoop === "true";
+    // The slides next to the view load too, so they are ready when dragged in
+    loadImages(items, index - 1, index + view);
+
+    const loop = isLoop(carousel);
 
     if (prevBtn) {
-      prevBtn.disabled = !isLoop && index === 0;
+      prevBtn.disabled = !loop && index === 0;
       prevBtn.classList.toggle("opacity-50", prevBtn.disabled);
     }
 
     if (nextBtn) {
-      nextBtn.disabled = !isLoop && index === count - 1;
+      nextBtn.disabled = !loop && index >= last;
       nextBtn.classList.toggle("opacity-50", nextBtn.disabled);
     }
+
+    if (notify) announce(carousel, index, view, count);
   }
 
   // Autoplay functionality
-  function startAutoplay(carousel) {
-    if (
-      carousel.dataset.tuiCarouselAutoplay !== "true" ||
-      prefersReducedMotion
-    ) {
-      return;
+  function rotates(carousel) {
+    return (
+      carousel.getAttribute("data-pui-carousel-autoplay") === "true" &&
+      !reducedMotion.matches &&
+      !document.hidden &&
+      visible.has(carousel) &&
+      !pauses.get(carousel)?.size
+    );
+  }
+
+  // Start or stop autoplay to match the carousel's state. The live region stays quiet
+  // while slides rotate by themselves.
+  function syncAutoplay(carousel) {
+    const live = parts(carousel, "[data-pui-carousel-live]")[0];
+
+    if (rotates(carousel)) {
+      if (live) live.setAttribute("aria-live", "off");
+      if (!autoplays.has(carousel)) startAutoplay(carousel);
+    } else {
+      if (live) live.setAttribute("aria-live", "polite");
+      stopAutoplay(carousel);
+    }
+  }
+
+  function pause(carousel, reason, paused) {
+    let reasons = pauses.get(carousel);
+    if (!reasons) {
+      reasons = new Set();
+      pauses.set(carousel, reasons);
     }
 
+    if (paused) reasons.add(reason);
+    else reasons.delete(reason);
+    syncAutoplay(carousel);
+  }
+
+  function startAutoplay(carousel) {
     stopAutoplay(carousel);
 
-    const interval = parseInt(carousel.dataset.tuiCarouselInterval || "5000");
+    const interval = parseInt(carousel.getAttribute("data-pui-carousel-interval"), 10) || 5000;
     const id = setInterval(() => {
       if (!document.contains(carousel)) {
         stopAutoplay(carousel);
         return;
       }
 
-      if (carousel.matches(":hover") || dragState?.carousel === carousel) {
-        return;
-      }
-
       navigate(carousel, 1);
     }, interval);
 
@@ -213,6 +395,47 @@
     }
   }
 
+  // Pause while the pointer is over the carousel or focus is inside it
+  document.addEventListener("mouseover", (e) => {
+    const carousel = e.target.closest?.("[data-pui-carousel]");
+    if (carousel && !carousel.contains(e.relatedTarget)) pause(carousel, "hover", true);
+  });
+
+  document.addEventListener("mouseout", (e) => {
+    const carousel = e.target.closest?.("[data-pui-carousel]");
+    if (carousel && !carousel.contains(e.relatedTarget)) pause(carousel, "hover", false);
+  });
+
+  document.addEventListener("focusin", (e) => {
+    const carousel = carouselOf(e.target);
+    if (carousel) pause(carousel, "focus", true);
+  });
+
+  document.addEventListener("focusout", (e) => {
+    const carousel = carouselOf(e.target);
+    if (carousel && carouselOf(e.relatedTarget) !== carousel) pause(carousel, "focus", false);
+  });
+
+  function each(fn) {
+    document.querySelectorAll("[data-pui-carousel][data-pui-carousel-initialized]").forEach(fn);
+  }
+
+  document.addEventListener("visibilitychange", () => each(syncAutoplay));
+
+  reducedMotion.addEventListener("change", () =>
+    each((carousel) => {
+      updateCarousel(carousel, current(carousel));
+      syncAutoplay(carousel);
+    }),
+  );
+
+  // Breakpoints change the slides in view, and slide widths change the offsets
+  let resizeFrame = 0;
+  window.addEventListener("resize", () => {
+    cancelAnimationFrame(resizeFrame);
+    resizeFrame = requestAnimationFrame(() => each((carousel) => updateCarousel(carousel, current(carousel))));
+  });
+
   // Intersection Observer for visibility management
   const observedCarousels = new WeakSet();
   const carouselObserver = new IntersectionObserver((entries) => {
@@ -222,18 +445,12 @@
       // Initialize display on first observation
       if (!carousel.hasAttribute("data-pui-carousel-initialized")) {
         carousel.setAttribute("data-pui-carousel-initialized", "true");
-        const index = parseInt(carousel.dataset.tuiCarouselCurrent || "0");
-        updateCarousel(carousel, index);
+        updateCarousel(carousel, current(carousel));
       }
 
-      // Handle autoplay if enabled
-      if (carousel.dataset.tuiCarouselAutoplay === "true") {
-        if (entry.isIntersecting) {
-          startAutoplay(carousel);
-        } else {
-          stopAutoplay(carousel);
-        }
-      }
+      if (entry.isIntersecting) visible.add(carousel);
+      else visible.delete(carousel);
+      syncAutoplay(carousel);
     });
   });
 
@@ -259,4 +476,21 @@
     childList: true,
     subtree: true,
   });
+
+  // Expose public API
+  window.tui = window.tui || {};
+  window.tui.carousel = {
+    goTo: (id, index) => {
+      const carousel = document.getElementById(id);
+      if (carousel) updateCarousel(carousel, index, true);
+    },
+    next: (id) => {
+      const carousel = document.getElementById(id);
+      if (carousel) navigate(carousel, 1);
+    },
+    previous: (id) => {
+      const carousel = document.getElementById(id);
+      if (carousel) navigate(carousel, -1);
+    },
+  };
 })();
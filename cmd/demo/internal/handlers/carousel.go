@@ -1,6 +1,8 @@
 package handlers
 
 import (
+	"fmt"
+
 	"github.com/plainkit/html"
 	"github.com/plainkit/ui/card"
 	"github.com/plainkit/ui/carousel"
@@ -90,7 +92,62 @@ func RenderCarouselContent() html.Node {
 						),
 					),
 				),
+
+				// Gallery with slides per view, lazy images and thumbnails
+				html.Div(
+					html.AClass("space-y-2"),
+					html.H3(html.AClass("text-lg font-semibold"), html.Text("Product Gallery")),
+					html.P(html.AClass("text-sm text-muted-foreground"), html.Text("One photo on phones, two from 640px and three from 1024px. Photos load as they come near the view.")),
+					productGallery(),
+				),
+			),
+		),
+	)
+}
+
+func productGallery() html.Node {
+	const count = 8
+
+	items := []html.DivArg{}
+	thumbs := []html.DivArg{carousel.ThumbnailsProps{For: "gallery-carousel"}}
+
+	for i := 0; i < count; i++ {
+		src := fmt.Sprintf("https://picsum.photos/seed/plainkit-%d/800/600", i+1)
+		alt := fmt.Sprintf("Product photo %d", i+1)
+
+		items = append(items, carousel.Item(
+			html.Div(
+				html.AClass("h-56 overflow-hidden rounded-lg bg-muted"),
+				// Up to three photos are in view on page load
+				carousel.Image(carousel.ImageProps{Src: src, Alt: alt, Eager: i < 3}),
+			),
+		))
+
+		thumbs = append(thumbs, carousel.Thumbnail(
+			carousel.ThumbnailProps{Index: i},
+			html.Img(
+				html.ASrc(fmt.Sprintf("https://picsum.photos/seed/plainkit-%d/96/64", i+1)),
+				html.AAlt(""),
+				html.ALoading("lazy"),
+				html.AClass("h-full w-full object-cover"),
+			),
+		))
+	}
+
+	return html.Div(
+		html.AClass("space-y-3"),
+		html.Div(
+			html.AClass("relative"),
+			carousel.Carousel(carousel.Props{
+				ID:          "gallery-carousel",
+				Label:       "Product photos",
+				Breakpoints: []carousel.Breakpoint{{MinWidth: 640, PerView: 2}, {MinWidth: 1024, PerView: 3}},
+			},
+				carousel.Content(items...),
+				carousel.Previous(),
+				carousel.Next(),
 			),
 		),
+		carousel.Thumbnails(thumbs...),
 	)
 }
@@ -0,0 +1,49 @@
+package handlers
+
+import (
+	"github.com/plainkit/html"
+	"github.com/plainkit/icons/lucide"
+	"github.com/plainkit/ui/button"
+	"github.com/plainkit/ui/command"
+)
+
+func RenderCommandContent() html.Node {
+	const paletteID = "demo-command"
+
+	return html.Div(
+		html.AClass("space-y-10"),
+		html.Section(
+			html.AClass("space-y-4"),
+			html.Div(
+				html.AClass("space-y-1"),
+				html.H2(html.AClass("text-2xl font-semibold"), html.Text("Command Palette")),
+				html.P(html.AClass("text-sm text-muted-foreground"), html.Text("Searchable, keyboard-driven list of commands. Press ⌘K or Ctrl+K to open.")),
+			),
+			html.Div(
+				html.AClass("flex flex-wrap gap-3"),
+				command.Trigger(
+					command.TriggerProps{For: paletteID},
+					button.Props{Variant: button.VariantOutline},
+					lucide.Search(html.AClass("size-4")),
+					html.Text("Search commands..."),
+				),
+			),
+			command.Command(
+				command.Props{ID: paletteID, Hotkey: "mod+k"},
+				command.Group(
+					command.GroupProps{Heading: "Navigation"},
+					command.Item(command.ItemProps{Value: "dialogs", Href: "/dialogs", Keywords: []string{"modal"}}, html.Text("Go to Dialogs")),
+					command.Item(command.ItemProps{Value: "inputs", Href: "/inputs", Keywords: []string{"form", "field"}}, html.Text("Go to Inputs")),
+					command.Item(command.ItemProps{Value: "tables", Href: "/tables"}, html.Text("Go to Tables")),
+				),
+				command.Separator(command.SeparatorProps{}),
+				command.Group(
+					command.GroupProps{Heading: "Actions"},
+					command.Item(command.ItemProps{Value: "new-file", Shortcut: "⌘⇧N", Hotkey: "mod+shift+n"}, lucide.Plus(html.AClass("size-4")), html.Text("New file")),
+					command.Item(command.ItemProps{Value: "copy-link", Keywords: []string{"share", "url"}}, lucide.Link(html.AClass("size-4")), html.Text("Copy link")),
+					command.Item(command.ItemProps{Value: "archive", Disabled: true}, html.Text("Archive (disabled)")),
+				),
+			),
+		),
+	)
+}
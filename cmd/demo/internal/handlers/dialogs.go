@@ -1,13 +1,177 @@
 package handlers
 
 import (
+	"net/http"
+	"net/mail"
+	"strconv"
+	"strings"
+	"sync"
+
 	"github.com/plainkit/html"
 	"github.com/plainkit/ui/button"
 	"github.com/plainkit/ui/dialog"
+	"github.com/plainkit/ui/dropdown"
+	"github.com/plainkit/ui/form"
+	"github.com/plainkit/ui/input"
+	"github.com/plainkit/ui/label"
+	"github.com/plainkit/ui/popover"
+	"github.com/plainkit/ui/tooltip"
+	"github.com/plainkit/ui/urlstate"
+)
+
+// contactDialogID is the dialog whose body Contact loads and replaces.
+const contactDialogID = "demo-contact-dialog"
+
+type contact struct {
+	Name  string
+	Email string
+}
+
+var (
+	contactsMu sync.Mutex
+	contacts   = []contact{
+		{Name: "Ada Lovelace", Email: "ada@example.com"},
+		{Name: "Grace Hopper", Email: "grace@example.com"},
+		{Name: "Alan Turing", Email: "alan@example.com"},
+	}
 )
 
-func RenderDialogsContent() html.Node {
-	const dialogID = "demo-dialog"
+// Contact serves the edit form loaded into the contact dialog and saves it. Invalid input
+// re-renders the form in the dialog with its errors; a successful save closes it.
+func Contact(w http.ResponseWriter, r *http.Request) {
+	index, err := strconv.Atoi(r.URL.Query().Get("id"))
+
+	contactsMu.Lock()
+	defer contactsMu.Unlock()
+
+	if err != nil || index < 0 || index >= len(contacts) {
+		http.NotFound(w, r)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Header().Set("Cache-Control", "no-store")
+
+		_, _ = w.Write([]byte(html.Render(contactForm(index, contacts[index], nil))))
+	case http.MethodPost:
+		if err := r.ParseForm(); err != nil {
+			http.Error(w, "could not read the form", http.StatusBadRequest)
+			return
+		}
+
+		c := contact{
+			Name:  strings.TrimSpace(r.PostForm.Get("name")),
+			Email: strings.TrimSpace(r.PostForm.Get("email")),
+		}
+
+		errs := map[string]string{}
+		if c.Name == "" {
+			errs["name"] = "Enter a name."
+		}
+
+		if _, err := mail.ParseAddress(c.Email); err != nil {
+			errs["email"] = "Enter a valid email address."
+		}
+
+		if len(errs) > 0 {
+			dialog.RespondReplace(w, contactDialogID, contactForm(index, c, errs))
+			return
+		}
+
+		contacts[index] = c
+
+		dialog.RespondClose(w, contactDialogID)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.Header().Set("Allow", "GET, POST")
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+	}
+}
+
+func contactForm(index int, c contact, errs map[string]string) html.Node {
+	field := func(id, name, text, value string, kind input.Type) html.Node {
+		item := []html.DivArg{
+			form.ItemProps{},
+			form.Label(form.LabelProps{For: id}, html.Text(text)),
+			input.Input(input.Props{ID: id, Name: name, Type: kind, Value: value, HasError: errs[name] != ""}),
+		}
+
+		if errs[name] != "" {
+			item = append(item, form.Message(form.MessageProps{Variant: form.MessageVariantError}, html.Text(errs[name])))
+		}
+
+		return form.Item(item...)
+	}
+
+	return html.Form(
+		html.AClass("grid gap-6"),
+		html.AMethod("post"),
+		html.AAction("/api/contacts?id="+strconv.Itoa(index)),
+		html.ANovalidate(), // Let the server report the errors
+		dialog.Header(
+			dialog.HeaderProps{},
+			dialog.Title(dialog.TitleProps{}, html.Text("Edit "+orPlaceholder(c.Name, "contact"))),
+			dialog.Description(dialog.DescriptionProps{}, html.Text("Loaded from the server when the dialog opens.")),
+		),
+		html.Div(
+			html.AClass("grid gap-4"),
+			field("contact-name", "name", "Name", c.Name, input.TypeText),
+			field("contact-email", "email", "Email", c.Email, input.TypeEmail),
+		),
+		dialog.Footer(
+			dialog.FooterProps{},
+			dialog.Close(dialog.CloseProps{For: contactDialogID},
+				button.Button(button.Props{Variant: button.VariantOutline}, html.Text("Cancel"))),
+			button.Button(button.Props{Type: button.TypeSubmit}, html.Text("Save")),
+		),
+	)
+}
+
+// demoProject is the name typed to confirm deleting it in the confirmation demo.
+const demoProject = "plainkit-ui"
+
+// DeleteProject handles the confirmation demo. Plain forms send DELETE as a POST carrying
+// dialog.MethodField.
+func DeleteProject(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete && (r.Method != http.MethodPost || r.FormValue(dialog.MethodField) != http.MethodDelete) {
+		w.Header().Set("Allow", http.MethodDelete)
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	if r.FormValue("project") != demoProject || !dialog.Confirmed(r, demoProject) {
+		http.Error(w, "confirmation phrase does not match", http.StatusUnprocessableEntity)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+
+	_, _ = w.Write([]byte(html.Render(html.P(html.Text("Project " + demoProject + " deleted.")))))
+}
+
+func orPlaceholder(value, placeholder string) string {
+	if value == "" {
+		return placeholder
+	}
+
+	return value
+}
+
+// linkedDialog keeps the open settings dialog in ?dialog=.
+var linkedDialog = urlstate.Sync{Param: "dialog", Push: true}
+
+func RenderDialogsContent(r *http.Request) html.Node {
+	const (
+		dialogID  = "demo-dialog"
+		profileID = "demo-profile-dialog"
+		discardID = "demo-discard-dialog"
+		nativeID  = "demo-native-dialog"
+		linkedID  = "settings"
+	)
 
 	return html.Div(
 		html.AClass("space-y-10"),
@@ -44,5 +208,263 @@ func RenderDialogsContent() html.Node {
 				),
 			),
 		),
+		html.Section(
+			html.AClass("space-y-4"),
+			html.Div(
+				html.AClass("space-y-1"),
+				html.H2(html.AClass("text-2xl font-semibold"), html.Text("Nested dialogs")),
+				html.P(html.AClass("text-sm text-muted-foreground"), html.Text("Focus stays inside the topmost dialog, the page behind is inert, Escape closes one level at a time and focus returns to the control that opened each dialog.")),
+			),
+			html.Div(
+				html.AClass("flex flex-wrap gap-3"),
+				dialog.Trigger(
+					dialog.TriggerProps{For: profileID},
+					button.Props{Variant: button.VariantOutline},
+					html.Text("Edit profile"),
+				),
+			),
+			dialog.Content(
+				dialog.ContentProps{ID: profileID},
+				dialog.Header(
+					dialog.HeaderProps{},
+					dialog.Title(dialog.TitleProps{}, html.Text("Edit profile")),
+					dialog.Description(dialog.DescriptionProps{}, html.Text("Changes are saved when you press Save.")),
+				),
+				html.Div(
+					html.AClass("grid gap-4"),
+					html.Div(
+						html.AClass("space-y-2"),
+						label.Label(label.Props{For: "profile-name"}, html.Text("Name")),
+						input.Input(input.Props{ID: "profile-name", Name: "name", Value: "Ada Lovelace"}),
+					),
+					html.Div(
+						html.AClass("space-y-2"),
+						label.Label(label.Props{For: "profile-email"}, html.Text("Email")),
+						input.Input(input.Props{ID: "profile-email", Name: "email", Type: input.TypeEmail, Value: "ada@example.com"}),
+					),
+				),
+				dialog.Footer(
+					dialog.FooterProps{},
+					dialog.Trigger(
+						dialog.TriggerProps{For: discardID},
+						button.Props{Variant: button.VariantOutline},
+						html.Text("Cancel"),
+					),
+					dialog.Close(dialog.CloseProps{For: profileID},
+						button.Button(button.Props{}, html.Text("Save"))),
+				),
+			),
+			dialog.Content(
+				dialog.ContentProps{ID: discardID, HideCloseButton: true, Class: "max-w-sm"},
+				dialog.Header(
+					dialog.HeaderProps{},
+					dialog.Title(dialog.TitleProps{}, html.Text("Discard changes?")),
+					dialog.Description(dialog.DescriptionProps{}, html.Text("Your edits to the profile will be lost.")),
+				),
+				dialog.Footer(
+					dialog.FooterProps{},
+					dialog.Close(dialog.CloseProps{For: discardID},
+						button.Button(button.Props{Variant: button.VariantOutline}, html.Text("Keep editing"))),
+					// Closing the profile dialog closes this one, stacked above it, too
+					dialog.Close(dialog.CloseProps{For: profileID},
+						button.Button(button.Props{Variant: button.VariantDestructive}, html.Text("Discard"))),
+				),
+			),
+		),
+		html.Section(
+			html.AClass("space-y-4"),
+			html.Div(
+				html.AClass("space-y-1"),
+				html.H2(html.AClass("text-2xl font-semibold"), html.Text("Native dialog")),
+				html.P(html.AClass("text-sm text-muted-foreground"), html.Text("Rendered as a <dialog> in the top layer. The buttons close it through a method=\"dialog\" form, and without JavaScript the trigger still opens it through :target.")),
+			),
+			html.Div(
+				html.AClass("flex flex-wrap gap-3"),
+				dialog.Trigger(
+					dialog.TriggerProps{For: nativeID, Native: true},
+					button.Props{Variant: button.VariantOutline},
+					html.Text("Open native dialog"),
+				),
+			),
+			dialog.Content(
+				dialog.ContentProps{ID: nativeID, Native: true, Class: "max-w-md"},
+				dialog.Header(
+					dialog.HeaderProps{},
+					dialog.Title(dialog.TitleProps{}, html.Text("Share document")),
+					dialog.Description(dialog.DescriptionProps{}, html.Text("Anyone with the link can view this document.")),
+				),
+				input.Input(input.Props{ID: "native-share-link", Value: "https://plainkit.dev/d/7f3a", Readonly: true}),
+				dialog.Footer(
+					dialog.FooterProps{},
+					dialog.Close(dialog.CloseProps{Native: true},
+						button.Button(button.Props{Type: button.TypeSubmit}, html.Text("Done"))),
+				),
+			),
+		),
+		html.Section(
+			html.AClass("space-y-4"),
+			html.Div(
+				html.AClass("space-y-1"),
+				html.H2(html.AClass("text-2xl font-semibold"), html.Text("Loaded from the server")),
+				html.P(html.AClass("text-sm text-muted-foreground"), html.Text("Each row loads its edit form into one shared dialog when opened. Saving with an empty name or an invalid email shows the server's errors in place; a valid save closes the dialog.")),
+			),
+			contactList(),
+			dialog.Content(
+				dialog.ContentProps{ID: contactDialogID, Class: "max-w-md"},
+				dialog.Body(dialog.BodyProps{}),
+			),
+		),
+		html.Section(
+			html.AClass("space-y-4"),
+			html.Div(
+				html.AClass("space-y-1"),
+				html.H2(html.AClass("text-2xl font-semibold"), html.Text("Confirmations")),
+				html.P(html.AClass("text-sm text-muted-foreground"), html.Text("One call renders the trigger and an alert dialog that submits the action. Focus starts on Cancel, clicks outside are ignored, and the project name must be typed before deleting.")),
+			),
+			html.Div(
+				html.AClass("flex flex-wrap gap-3"),
+				dialog.Confirm(
+					dialog.ConfirmProps{
+						ID:           "demo-delete-project",
+						Title:        "Delete project?",
+						Message:      "This permanently deletes the project, its issues and its deployments.",
+						ConfirmLabel: "Delete project",
+						Action:       "/api/projects/delete",
+						Method:       http.MethodDelete,
+						Fields:       map[string]string{"project": demoProject},
+						Phrase:       demoProject,
+					},
+					button.Props{Variant: button.VariantDestructive},
+					html.Text("Delete project"),
+				),
+			),
+		),
+		html.Section(
+			html.AClass("space-y-4"),
+			html.Div(
+				html.AClass("space-y-1"),
+				html.H2(html.AClass("text-2xl font-semibold"), html.Text("Linked dialog")),
+				html.P(html.AClass("text-sm text-muted-foreground"), html.Text("Opening this dialog adds ?dialog=settings to the URL. Back closes it, and loading the link renders it open on the server.")),
+			),
+			html.Div(
+				html.AClass("flex flex-wrap gap-3"),
+				dialog.Trigger(
+					dialog.TriggerProps{For: linkedID},
+					button.Props{Variant: button.VariantOutline},
+					html.Text("Settings"),
+				),
+				button.Button(button.Props{Variant: button.VariantLink, Href: "?dialog=" + linkedID}, html.Text("Link to the open dialog")),
+			),
+			dialog.Content(
+				dialog.ContentProps{ID: linkedID, Sync: linkedDialog, Open: linkedDialog.Is(r, linkedID), Class: "max-w-md"},
+				dialog.Header(
+					dialog.HeaderProps{},
+					dialog.Title(dialog.TitleProps{}, html.Text("Settings")),
+					dialog.Description(dialog.DescriptionProps{}, html.Text("Copy the address bar now to share a link to this dialog.")),
+				),
+				dialog.Footer(
+					dialog.FooterProps{},
+					dialog.Close(dialog.CloseProps{For: linkedID}, button.Button(button.Props{}, html.Text("Done"))),
+				),
+			),
+		),
+		stackedOverlays(),
+	)
+}
+
+// stackedOverlays opens a dialog from a dropdown, with a popover and a tooltip inside it.
+// Each ESC or outside click closes the topmost overlay only.
+func stackedOverlays() html.Node {
+	const (
+		menuID    = "demo-stack-menu"
+		renameID  = "demo-stack-rename"
+		rulesID   = "demo-stack-rules"
+		tooltipID = "demo-stack-tooltip"
+	)
+
+	return html.Section(
+		html.AClass("space-y-4"),
+		html.Div(
+			html.AClass("space-y-1"),
+			html.H2(html.AClass("text-2xl font-semibold"), html.Text("Stacked overlays")),
+			html.P(html.AClass("text-sm text-muted-foreground"), html.Text("Rename opens a dialog over the menu, and the dialog opens a popover and a tooltip of its own. ESC and clicks outside close the topmost overlay only, and each one stacks above the last.")),
+		),
+		dropdown.Dropdown(
+			dropdown.Props{},
+			dropdown.Trigger(
+				dropdown.TriggerProps{For: menuID},
+				button.Props{Variant: button.VariantOutline},
+				html.Text("Project"),
+			),
+			dropdown.Content(
+				dropdown.ContentProps{ID: menuID},
+				dropdown.Item(
+					dropdown.ItemProps{Attrs: []html.Global{
+						html.AData("pui-dialog-trigger", renameID),
+						html.AData("dialog-instance", renameID),
+					}},
+					html.Span(html.Text("Rename…")),
+				),
+				dropdown.Item(dropdown.ItemProps{}, html.Span(html.Text("Duplicate"))),
+			),
+		),
+		dialog.Content(
+			dialog.ContentProps{ID: renameID, Class: "max-w-md"},
+			dialog.Header(
+				dialog.HeaderProps{},
+				dialog.Title(dialog.TitleProps{}, html.Text("Rename project")),
+				dialog.Description(dialog.DescriptionProps{}, html.Text("The new name shows up everywhere the project is listed.")),
+			),
+			html.Div(
+				html.AClass("grid gap-2"),
+				html.Div(
+					html.AClass("flex items-center justify-between"),
+					label.Label(label.Props{For: "stack-name"}, html.Text("Name")),
+					popover.Trigger(
+						popover.TriggerProps{For: rulesID, TriggerType: popover.TriggerTypeClick},
+						button.Button(button.Props{Variant: button.VariantLink, Size: button.SizeSm}, html.Text("Naming rules")),
+					),
+					popover.Content(
+						popover.ContentProps{ID: rulesID, Class: "w-64 p-4 text-muted-foreground", ShowArrow: true},
+						html.Text("Use 3 to 40 lowercase letters, digits or dashes."),
+					),
+				),
+				input.Input(input.Props{ID: "stack-name", Value: demoProject}),
+			),
+			dialog.Footer(
+				dialog.FooterProps{},
+				tooltip.Trigger(
+					tooltip.TriggerProps{For: tooltipID},
+					dialog.Close(dialog.CloseProps{For: renameID}, button.Button(button.Props{}, html.Text("Save"))),
+				),
+				tooltip.Content(
+					tooltip.ContentProps{ID: tooltipID, Position: tooltip.PositionTop, ShowArrow: true},
+					html.Text("Saves and closes the dialog"),
+				),
+			),
+		),
 	)
 }
+
+func contactList() html.Node {
+	contactsMu.Lock()
+	defer contactsMu.Unlock()
+
+	rows := []html.UlArg{html.AClass("divide-y rounded-lg border")}
+	for i, c := range contacts {
+		rows = append(rows, html.Li(
+			html.AClass("flex items-center justify-between gap-4 px-4 py-3"),
+			html.Div(
+				html.P(html.AClass("text-sm font-medium"), html.Text(c.Name)),
+				html.P(html.AClass("text-sm text-muted-foreground"), html.Text(c.Email)),
+			),
+			dialog.Trigger(
+				dialog.TriggerProps{For: contactDialogID, URL: "/api/contacts?id=" + strconv.Itoa(i)},
+				button.Props{Variant: button.VariantOutline, Size: button.SizeSm},
+				html.Text("Edit"),
+			),
+		))
+	}
+
+	return html.Ul(rows...)
+}
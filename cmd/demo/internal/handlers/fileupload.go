@@ -0,0 +1,71 @@
+package handlers
+
+import (
+	"github.com/plainkit/html"
+	"github.com/plainkit/ui/fileupload"
+	"github.com/plainkit/ui/label"
+)
+
+// DocumentUpload holds the rules shared by the resumable upload demo and its handler.
+var DocumentUpload = fileupload.Props{
+	ID:        "documents",
+	Name:      "documents",
+	Accept:    []string{".pdf", "image/*"},
+	MaxSize:   50 << 20,
+	MaxFiles:  5,
+	Multiple:  true,
+	UploadURL: "/api/uploads",
+	ChunkSize: 1 << 20,
+}
+
+func RenderFileUploadContent() html.Node {
+	return html.Div(
+		html.AClass("space-y-10"),
+		html.Section(
+			html.AClass("space-y-4"),
+			html.Div(
+				html.AClass("space-y-1"),
+				html.H2(html.AClass("text-2xl font-semibold"), html.Text("Resumable uploads")),
+				html.P(html.AClass("text-sm text-muted-foreground"), html.Text("Files upload in 1 MB chunks while you fill in the form. Interrupted uploads resume where they stopped, even after a reload.")),
+			),
+			html.Div(
+				html.AClass("max-w-xl space-y-2"),
+				label.Label(label.Props{For: "documents"}, html.Text("Supporting documents")),
+				fileupload.FileUpload(DocumentUpload),
+			),
+		),
+		html.Section(
+			html.AClass("space-y-4"),
+			html.Div(
+				html.AClass("space-y-1"),
+				html.H2(html.AClass("text-2xl font-semibold"), html.Text("Form uploads")),
+				html.P(html.AClass("text-sm text-muted-foreground"), html.Text("Without an upload URL the picked files are posted with the form.")),
+			),
+			html.Div(
+				html.AClass("grid gap-6 md:grid-cols-2"),
+				html.Div(
+					html.AClass("space-y-2"),
+					label.Label(label.Props{For: "avatar"}, html.Text("Avatar")),
+					fileupload.FileUpload(fileupload.Props{
+						ID:      "avatar",
+						Name:    "avatar",
+						Accept:  []string{"image/png", "image/jpeg"},
+						MaxSize: 2 << 20,
+						Title:   "Drop an image or click to browse",
+					}),
+				),
+				html.Div(
+					html.AClass("space-y-2"),
+					label.Label(label.Props{For: "contract", Error: "A signed contract is required"}, html.Text("Signed contract")),
+					fileupload.FileUpload(fileupload.Props{
+						ID:       "contract",
+						Name:     "contract",
+						Accept:   []string{".pdf"},
+						Required: true,
+						HasError: true,
+					}),
+				),
+			),
+		),
+	)
+}
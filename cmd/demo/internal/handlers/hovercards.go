@@ -0,0 +1,125 @@
+package handlers
+
+import (
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/plainkit/html"
+	"github.com/plainkit/ui/avatar"
+	"github.com/plainkit/ui/badge"
+	"github.com/plainkit/ui/hovercard"
+	"github.com/plainkit/ui/popover"
+)
+
+type person struct {
+	Handle   string
+	Name     string
+	Initials string
+	Role     string
+	Bio      string
+	Joined   string
+}
+
+var demoPeople = []person{
+	{Handle: "ada", Name: "Ada Lovelace", Initials: "AL", Role: "Maintainer", Bio: "Writes the scheduler and most of the docs.", Joined: "March 2021"},
+	{Handle: "grace", Name: "Grace Hopper", Initials: "GH", Role: "Reviewer", Bio: "Reviews compiler changes and keeps the release notes honest.", Joined: "June 2022"},
+	{Handle: "linus", Name: "Linus Park", Initials: "LP", Role: "Contributor", Bio: "Sends small, well-tested patches on weekends.", Joined: "January 2024"},
+}
+
+// PersonPreview renders the profile card of ?handle=, slowly, to show the placeholder.
+func PersonPreview(r *http.Request) (html.Node, error) {
+	time.Sleep(400 * time.Millisecond)
+
+	handle := r.URL.Query().Get("handle")
+	for _, p := range demoPeople {
+		if p.Handle == handle {
+			return html.Div(
+				html.AClass("flex gap-4"),
+				avatar.Avatar(avatar.Fallback(html.Text(p.Initials))),
+				html.Div(
+					html.AClass("space-y-1"),
+					html.Div(
+						html.AClass("flex items-center gap-2"),
+						html.P(html.AClass("text-sm font-semibold"), html.Text(p.Name)),
+						badge.Badge(badge.Props{Variant: badge.VariantSecondary}, html.Text(p.Role)),
+					),
+					html.P(html.AClass("text-sm"), html.Text(p.Bio)),
+					html.P(html.AClass("text-xs text-muted-foreground"), html.Text("Joined "+p.Joined)),
+				),
+			), nil
+		}
+	}
+
+	return html.Node{}, hovercard.ErrNotFound
+}
+
+func RenderHovercardsContent() html.Node {
+	// Cards are divs, which cannot sit inside a paragraph; they follow it instead
+	mentions := []html.PArg{html.AClass("max-w-prose text-sm leading-7"), html.Text("This release was planned by ")}
+	cards := []html.SectionArg{}
+	for i, p := range demoPeople {
+		id := "hovercard-" + p.Handle
+		mentions = append(mentions,
+			hovercard.Trigger(
+				hovercard.TriggerProps{For: id},
+				html.A(
+					html.AHref("#"+p.Handle),
+					html.AClass("rounded-md bg-primary/10 px-1.5 py-0.5 font-medium text-primary hover:underline"),
+					html.Text("@"+p.Handle),
+				),
+			),
+		)
+		cards = append(cards, hovercard.Content(hovercard.ContentProps{ID: id, URL: "/api/people?handle=" + url.QueryEscape(p.Handle), ShowArrow: true}))
+
+		switch {
+		case i < len(demoPeople)-2:
+			mentions = append(mentions, html.Text(", "))
+		case i == len(demoPeople)-2:
+			mentions = append(mentions, html.Text(" and "))
+		default:
+			mentions = append(mentions, html.Text(". Move the pointer diagonally from a name into its card: it stays open, and the other names on the way do not open theirs."))
+		}
+	}
+
+	mentionsSection := append([]html.SectionArg{
+		html.AClass("space-y-4"),
+		html.Div(
+			html.AClass("space-y-1"),
+			html.H2(html.AClass("text-2xl font-semibold"), html.Text("Hover cards")),
+			html.P(html.AClass("text-sm text-muted-foreground"), html.Text("Rich previews that open after the pointer rests on a link, load their body from the server the first time, and open on the first tap on touch screens.")),
+		),
+		html.P(mentions...),
+	}, cards...)
+
+	return html.Div(
+		html.AClass("space-y-10"),
+		html.Section(mentionsSection...),
+		html.Section(
+			html.AClass("space-y-4"),
+			html.Div(
+				html.AClass("space-y-1"),
+				html.H2(html.AClass("text-2xl font-semibold"), html.Text("Link preview")),
+				html.P(html.AClass("text-sm text-muted-foreground"), html.Text("Cards can hold static content too, and take the same placement options as popovers.")),
+			),
+			html.P(
+				html.AClass("text-sm"),
+				html.Text("Built on "),
+				hovercard.Trigger(
+					hovercard.TriggerProps{For: "hovercard-link"},
+					html.A(html.AHref("https://github.com/plainkit/html"), html.AClass("font-medium underline underline-offset-4"), html.Text("plainkit/html")),
+				),
+				html.Text("."),
+			),
+			hovercard.Content(
+				hovercard.ContentProps{ID: "hovercard-link", Placement: popover.PlacementTopStart, OpenDelay: 300},
+				html.Div(
+					html.AClass("space-y-2"),
+					html.P(html.AClass("text-sm font-semibold"), html.Text("plainkit/html")),
+					html.P(html.AClass("text-sm text-muted-foreground"), html.Text("Type-safe HTML for Go, with per-element attribute types and asset collection.")),
+					html.P(html.AClass("text-xs text-muted-foreground"), html.Text("github.com")),
+				),
+			),
+		),
+	)
+}
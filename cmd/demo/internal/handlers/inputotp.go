@@ -1,11 +1,57 @@
 package handlers
 
 import (
+	"errors"
+	"net/http"
+	"time"
+
 	"github.com/plainkit/html"
+	"github.com/plainkit/ui/button"
 	"github.com/plainkit/ui/form"
 	"github.com/plainkit/ui/inputotp"
+	"github.com/plainkit/ui/otp"
+	"github.com/plainkit/ui/qrcode"
 )
 
+// demoKey is fixed so the enrollment survives restarts of the demo server; real keys
+// come from otp.NewKey and are stored per user.
+var demoKey = otp.Key{
+	Secret:  []byte("plainkit-ui-demo-key"),
+	Issuer:  "Plainkit UI",
+	Account: "demo@plainkit.dev",
+}
+
+var twoFactorProps = inputotp.Props{ID: "otp-two-factor", Name: "code", Mode: inputotp.ModeNumeric, Layout: "3-3", Required: true}
+
+// VerifyTwoFactor checks a code posted from the enrollment demo against demoKey.
+func VerifyTwoFactor(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	message := "Code accepted. Two-factor authentication is enabled."
+
+	code, err := inputotp.Parse(r, twoFactorProps)
+	if err == nil {
+		_, err = otp.VerifyTOTP(demoKey, code, time.Now(), otp.DefaultSkew)
+	}
+
+	switch {
+	case errors.Is(err, otp.ErrInvalidCode):
+		message = "That code is not valid. Check the time on your device and try again."
+	case err != nil:
+		message = "Enter the 6-digit code from your authenticator app."
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+
+	_, _ = w.Write([]byte(html.Render(html.P(html.Text(message)))))
+}
+
 func RenderInputOTPContent() html.Node {
 	buildSlots := func(start int, hasError bool) []html.DivArg {
 		items := make([]html.DivArg, 0, 3)
@@ -55,5 +101,57 @@ func RenderInputOTPContent() html.Node {
 				),
 			),
 		),
+		html.Section(
+			html.AClass("space-y-4"),
+			html.Div(
+				html.AClass("space-y-1"),
+				html.H2(html.AClass("text-2xl font-semibold"), html.Text("Modes and layouts")),
+				html.P(html.AClass("text-sm text-muted-foreground"), html.Text("Restrict the accepted characters, mask the code, and submit automatically once every slot is filled.")),
+			),
+			html.Div(
+				html.AClass("grid gap-6 md:grid-cols-2"),
+				html.Div(
+					html.AClass("space-y-3 rounded-lg border bg-card p-6 shadow-xs"),
+					html.Label(html.AClass("text-sm font-medium"), html.Text("Invite code")),
+					inputotp.Code(inputotp.Props{ID: "otp-invite", Name: "invite_code", Mode: inputotp.ModeUppercaseAlpha, Layout: "4-4"}),
+					form.Description(form.DescriptionProps{}, html.Text("Letters only, converted to upper case. Paste \"abcd-efgh\" to fill every slot.")),
+				),
+				html.Div(
+					html.AClass("space-y-3 rounded-lg border bg-card p-6 shadow-xs"),
+					html.Label(html.AClass("text-sm font-medium"), html.Text("Device pairing code")),
+					inputotp.Code(inputotp.Props{ID: "otp-hex", Name: "pairing_code", Mode: inputotp.ModePattern, Pattern: "[0-9A-Fa-f]", Layout: "3 3"}),
+					form.Description(form.DescriptionProps{}, html.Text("A custom pattern accepts hexadecimal digits only.")),
+				),
+				html.Form(
+					html.AClass("space-y-3 rounded-lg border bg-card p-6 shadow-xs"),
+					html.AMethod("get"),
+					html.Label(html.AClass("text-sm font-medium"), html.Text("Security PIN")),
+					inputotp.Code(inputotp.Props{ID: "otp-pin", Name: "pin", Mode: inputotp.ModeNumeric, Secret: true, AutoSubmit: true, Layout: "6", Placeholder: "•"}),
+					form.Description(form.DescriptionProps{}, html.Text("Masked while typing; the form submits as soon as the sixth digit is entered.")),
+				),
+			),
+		),
+		html.Section(
+			html.AClass("space-y-4"),
+			html.Div(
+				html.AClass("space-y-1"),
+				html.H2(html.AClass("text-2xl font-semibold"), html.Text("Two-factor enrollment")),
+				html.P(html.AClass("text-sm text-muted-foreground"), html.Text("Scan the QR code with an authenticator app, then confirm with the code it shows. The otp and qrcode packages cover the whole flow without dependencies.")),
+			),
+			html.Form(
+				html.AClass("grid gap-6 rounded-lg border bg-card p-6 shadow-xs md:grid-cols-[auto_1fr]"),
+				html.AMethod("post"),
+				html.AAction("/api/two-factor/verify"),
+				qrcode.QRCode(qrcode.Props{Value: demoKey.URI(), Level: qrcode.LevelMedium, Label: "QR code to add " + demoKey.Issuer + " to an authenticator app"}),
+				html.Div(
+					html.AClass("space-y-3"),
+					html.P(html.AClass("text-sm text-muted-foreground"), html.Text("Can't scan it? Enter this key manually:")),
+					html.Code(html.AClass("block rounded bg-muted px-2 py-1 font-mono text-sm"), html.Text(otp.FormatSecret(demoKey.Secret))),
+					html.Label(html.AClass("block text-sm font-medium"), html.AFor(twoFactorProps.ID), html.Text("Verification code")),
+					inputotp.Code(twoFactorProps),
+					button.Button(button.Props{Type: button.TypeSubmit}, html.Text("Verify")),
+				),
+			),
+		),
 	)
 }
@@ -1,11 +1,54 @@
 package handlers
 
 import (
+	"net/http"
+
 	"github.com/plainkit/html"
+	"github.com/plainkit/ui/button"
 	"github.com/plainkit/ui/input"
 	"github.com/plainkit/ui/label"
 )
 
+// signupPolicy is shown as a checklist on the signup demo and enforced again by
+// CreateAccount, so both sides apply the same rules.
+var signupPolicy = input.Policy{
+	MinLength:     12,
+	Lower:         true,
+	Upper:         true,
+	Digit:         true,
+	Symbol:        true,
+	UsernameField: "signup-username",
+	MinStrength:   input.StrengthFair,
+}
+
+// CreateAccount validates the signup demo password against signupPolicy.
+func CreateAccount(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "could not read the form", http.StatusBadRequest)
+		return
+	}
+
+	policy := signupPolicy
+	policy.Username = r.PostForm.Get("username")
+
+	message := "Account created."
+	if err := policy.Validate(r.PostForm.Get("password")); err != nil {
+		message = err.Error()
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+
+	_, _ = w.Write([]byte(html.Render(html.P(html.Text(message)))))
+}
+
 func RenderInputsContent() html.Node {
 	return html.Div(
 		html.AClass("space-y-10"),
@@ -61,5 +104,95 @@ func RenderInputsContent() html.Node {
 				),
 			),
 		),
+		html.Section(
+			html.AClass("space-y-4"),
+			html.Div(
+				html.AClass("space-y-1"),
+				html.H2(html.AClass("text-2xl font-semibold"), html.Text("Masked inputs")),
+				html.P(html.AClass("text-sm text-muted-foreground"), html.Text("Format values while typing; the server normalizes them with the same mask.")),
+			),
+			html.Div(
+				html.AClass("grid gap-6 md:grid-cols-2"),
+				html.Div(
+					html.AClass("space-y-2"),
+					label.Label(label.Props{For: "phone"}, html.Text("Phone")),
+					input.Input(input.Props{ID: "phone", Name: "phone", Type: input.TypeTel, Placeholder: "(555) 123-4567", Mask: input.MaskPhoneUS}),
+				),
+				html.Div(
+					html.AClass("space-y-2"),
+					label.Label(label.Props{For: "iban"}, html.Text("IBAN")),
+					input.Input(input.Props{ID: "iban", Name: "iban", Placeholder: "DE89 3704 0044 0532 0130 00", Mask: input.MaskIBAN}),
+				),
+				html.Div(
+					html.AClass("space-y-2"),
+					label.Label(label.Props{For: "card"}, html.Text("Card number")),
+					input.Input(input.Props{ID: "card", Name: "card", Placeholder: "4111 1111 1111 1111", Mask: input.MaskCreditCard}),
+				),
+				html.Div(
+					html.AClass("space-y-2"),
+					label.Label(label.Props{For: "amount"}, html.Text("Amount (de-DE, EUR)")),
+					input.Input(input.Props{
+						ID:          "amount",
+						Name:        "amount",
+						Placeholder: "0,00 €",
+						Mask:        input.Mask{Number: &input.NumberFormat{Locale: "de-DE", Currency: "EUR"}},
+					}),
+				),
+			),
+		),
+		html.Section(
+			html.AClass("space-y-4"),
+			html.Div(
+				html.AClass("space-y-1"),
+				html.H2(html.AClass("text-2xl font-semibold"), html.Text("Length limits")),
+				html.P(html.AClass("text-sm text-muted-foreground"), html.Text("Show a live counter that warns near the limit; soft limits allow overflow and flag it.")),
+			),
+			html.Div(
+				html.AClass("grid gap-6 md:grid-cols-2"),
+				html.Div(
+					html.AClass("space-y-2"),
+					label.Label(label.Props{For: "ticket-subject"}, html.Text("Ticket subject")),
+					input.Input(input.Props{ID: "ticket-subject", Name: "ticket_subject", Placeholder: "Summarize the issue", MinLength: 10, MaxLength: 80, ShowCount: true}),
+				),
+				html.Div(
+					html.AClass("space-y-2"),
+					label.Label(label.Props{For: "sms-sender"}, html.Text("SMS sender name")),
+					input.Input(input.Props{ID: "sms-sender", Name: "sms_sender", Value: "Plainkit Notifications", MaxLength: 11, SoftLimit: true}),
+				),
+			),
+		),
+		html.Section(
+			html.AClass("space-y-4"),
+			html.Div(
+				html.AClass("space-y-1"),
+				html.H2(html.AClass("text-2xl font-semibold"), html.Text("Password strength")),
+				html.P(html.AClass("text-sm text-muted-foreground"), html.Text("A strength meter and a live checklist of the password policy; the server validates the same policy on submit.")),
+			),
+			html.Form(
+				html.AClass("max-w-md space-y-4"),
+				html.AMethod("post"),
+				html.AAction("/api/signup"),
+				html.Div(
+					html.AClass("space-y-2"),
+					label.Label(label.Props{For: "signup-username"}, html.Text("Username")),
+					input.Input(input.Props{ID: "signup-username", Name: "username", Placeholder: "ada", Required: true}),
+				),
+				html.Div(
+					html.AClass("space-y-2"),
+					label.Label(label.Props{For: "signup-password"}, html.Text("Password")),
+					input.Input(input.Props{
+						ID:                 "signup-password",
+						Name:               "password",
+						Type:               input.TypePassword,
+						Required:           true,
+						ShowPasswordToggle: true,
+						ShowStrength:       true,
+						Policy:             signupPolicy,
+						Attrs:              []html.Global{html.ACustom("autocomplete", "new-password")},
+					}),
+				),
+				button.Button(button.Props{Type: button.TypeSubmit}, html.Text("Create account")),
+			),
+		),
 	)
 }
@@ -0,0 +1,115 @@
+package handlers
+
+import (
+	"github.com/plainkit/html"
+	"github.com/plainkit/ui/input"
+	"github.com/plainkit/ui/label"
+	"github.com/plainkit/ui/numberinput"
+)
+
+func RenderNumberInputsContent() html.Node {
+	return html.Div(
+		html.AClass("space-y-10"),
+		html.Section(
+			html.AClass("space-y-4"),
+			html.Div(
+				html.AClass("space-y-1"),
+				html.H2(html.AClass("text-2xl font-semibold"), html.Text("Number inputs")),
+				html.P(html.AClass("text-sm text-muted-foreground"), html.Text("Step with the buttons, arrow keys, Page Up/Down or the mouse wheel while focused.")),
+			),
+			html.Div(
+				html.AClass("grid gap-6 md:grid-cols-2"),
+				html.Div(
+					html.AClass("space-y-2"),
+					label.Label(label.Props{For: "quantity"}, html.Text("Quantity")),
+					numberinput.NumberInput(numberinput.Props{
+						ID:    "quantity",
+						Name:  "quantity",
+						Value: numberinput.Float(1),
+						Min:   numberinput.Float(1),
+						Max:   numberinput.Float(99),
+					}),
+				),
+				html.Div(
+					html.AClass("space-y-2"),
+					label.Label(label.Props{For: "weight"}, html.Text("Weight (kg)")),
+					numberinput.NumberInput(numberinput.Props{
+						ID:          "weight",
+						Name:        "weight",
+						Placeholder: "0.0",
+						Min:         numberinput.Float(0),
+						Step:        0.5,
+					}),
+				),
+				html.Div(
+					html.AClass("space-y-2"),
+					label.Label(label.Props{For: "temperature"}, html.Text("Temperature offset")),
+					numberinput.NumberInput(numberinput.Props{
+						ID:    "temperature",
+						Name:  "temperature",
+						Value: numberinput.Float(-2),
+						Min:   numberinput.Float(-10),
+						Max:   numberinput.Float(10),
+					}),
+				),
+				html.Div(
+					html.AClass("space-y-2"),
+					label.Label(label.Props{For: "seats", Error: "At least 5 seats are required"}, html.Text("Seats")),
+					numberinput.NumberInput(numberinput.Props{
+						ID:       "seats",
+						Name:     "seats",
+						Value:    numberinput.Float(2),
+						Min:      numberinput.Float(0),
+						HasError: true,
+					}),
+				),
+			),
+		),
+		html.Section(
+			html.AClass("space-y-4"),
+			html.Div(
+				html.AClass("space-y-1"),
+				html.H2(html.AClass("text-2xl font-semibold"), html.Text("Prices & locales")),
+				html.P(html.AClass("text-sm text-muted-foreground"), html.Text("The field shows the locale's separators and currency while the form submits a plain decimal.")),
+			),
+			html.Div(
+				html.AClass("grid gap-6 md:grid-cols-2"),
+				html.Div(
+					html.AClass("space-y-2"),
+					label.Label(label.Props{For: "unit-price"}, html.Text("Unit price")),
+					numberinput.NumberInput(numberinput.Props{
+						ID:        "unit-price",
+						Name:      "unit_price",
+						Value:     numberinput.Float(1249.5),
+						Min:       numberinput.Float(0),
+						Step:      0.25,
+						LargeStep: 100,
+						Format:    input.NumberFormat{Locale: "en-US", Currency: "USD"},
+					}),
+				),
+				html.Div(
+					html.AClass("space-y-2"),
+					label.Label(label.Props{For: "preis"}, html.Text("Preis")),
+					numberinput.NumberInput(numberinput.Props{
+						ID:     "preis",
+						Name:   "preis",
+						Value:  numberinput.Float(19999),
+						Min:    numberinput.Float(0),
+						Step:   10,
+						Format: input.NumberFormat{Locale: "de-DE", Currency: "EUR"},
+					}),
+				),
+				html.Div(
+					html.AClass("space-y-2"),
+					label.Label(label.Props{For: "discount"}, html.Text("Discount (disabled)")),
+					numberinput.NumberInput(numberinput.Props{
+						ID:       "discount",
+						Name:     "discount",
+						Value:    numberinput.Float(15),
+						Disabled: true,
+					}),
+				),
+			),
+		),
+	)
+}
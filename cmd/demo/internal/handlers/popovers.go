@@ -4,12 +4,15 @@ import (
 	"github.com/plainkit/html"
 	"github.com/plainkit/ui/button"
 	"github.com/plainkit/ui/popover"
+	"github.com/plainkit/ui/tooltip"
 )
 
 func RenderPopoversContent() html.Node {
 	const (
-		infoPopoverID  = "profile-popover"
-		hoverPopoverID = "shortcut-popover"
+		infoPopoverID   = "profile-popover"
+		hoverPopoverID  = "shortcut-popover"
+		nativePopoverID = "native-share-popover"
+		nativeTooltipID = "native-tooltip"
 	)
 
 	return html.Div(
@@ -80,5 +83,34 @@ func RenderPopoversContent() html.Node {
 				),
 			),
 		),
+		html.Section(
+			html.AClass("space-y-4"),
+			html.Div(
+				html.AClass("space-y-1"),
+				html.H2(html.AClass("text-2xl font-semibold"), html.Text("Native popovers")),
+				html.P(html.AClass("text-sm text-muted-foreground"), html.Text("Native panels open in the browser's top layer and follow their trigger with CSS anchor positioning, falling back to floating-ui where that is unsupported. Scroll the page while one is open, or open one near the bottom of the window to see it flip.")),
+			),
+			html.Div(
+				html.AClass("flex flex-wrap items-center gap-3"),
+				popover.Trigger(
+					popover.TriggerProps{For: nativePopoverID},
+					button.Button(button.Props{Variant: button.VariantOutline}, html.Text("Share")),
+				),
+				popover.Content(
+					popover.ContentProps{ID: nativePopoverID, Class: "w-64 space-y-2 p-4", Placement: popover.PlacementBottomStart, ShowArrow: true, Native: true},
+					html.P(html.AClass("text-sm font-medium"), html.Text("Share this document")),
+					html.P(html.AClass("text-xs text-muted-foreground"), html.Text("Anyone with the link can view it.")),
+					button.Button(button.Props{Size: button.SizeSm}, html.Text("Copy link")),
+				),
+				tooltip.Trigger(
+					tooltip.TriggerProps{For: nativeTooltipID},
+					button.Button(button.Props{Variant: button.VariantGhost}, html.Text("Hover me")),
+				),
+				tooltip.Content(
+					tooltip.ContentProps{ID: nativeTooltipID, Position: tooltip.PositionRight, ShowArrow: true, Native: true},
+					html.Text("Rendered in the top layer"),
+				),
+			),
+		),
 	)
 }
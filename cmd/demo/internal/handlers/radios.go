@@ -2,9 +2,17 @@ package handlers
 
 import (
 	"github.com/plainkit/html"
+	"github.com/plainkit/ui/option"
 	"github.com/plainkit/ui/radio"
 )
 
+// demoPlans is a typed option list shared by the radio and select box demos.
+var demoPlans = option.New(option.Int,
+	option.Option[int]{Value: 1, Label: "Starter", Description: "Up to 3 projects"},
+	option.Option[int]{Value: 2, Label: "Team", Description: "Unlimited projects and 10 seats"},
+	option.Option[int]{Value: 3, Label: "Enterprise", Description: "SSO and audit logs", Disabled: true},
+)
+
 func RenderRadiosContent() html.Node {
 	return html.Div(
 		html.AClass("space-y-10"),
@@ -49,6 +57,11 @@ func RenderRadiosContent() html.Node {
 					),
 				),
 			),
+			html.Fieldset(
+				html.AClass("space-y-4 rounded-lg border p-6"),
+				html.Legend(html.AClass("text-sm font-semibold uppercase tracking-wide text-muted-foreground"), html.Text("Typed options")),
+				radio.Group(radio.GroupProps{Name: "plan"}, demoPlans, 2),
+			),
 		),
 	)
 }
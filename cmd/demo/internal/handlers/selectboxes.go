@@ -1,10 +1,44 @@
 package handlers
 
 import (
+	"net/http"
+	"strconv"
+	"strings"
+
 	"github.com/plainkit/html"
+	"github.com/plainkit/ui/option"
 	"github.com/plainkit/ui/selectbox"
+	"github.com/plainkit/ui/virtual"
 )
 
+var demoCustomers = []string{
+	"Acme Corporation", "Globex", "Initech", "Umbrella Corp", "Hooli",
+	"Stark Industries", "Wayne Enterprises", "Wonka Industries", "Cyberdyne Systems", "Soylent",
+}
+
+// SearchCustomers backs the remote select box demo.
+func SearchCustomers(_ *http.Request, query string) ([]option.Option[string], error) {
+	var options []option.Option[string]
+
+	for i, name := range demoCustomers {
+		if query == "" || strings.Contains(strings.ToLower(name), strings.ToLower(query)) {
+			options = append(options, option.Option[string]{Value: "cus_" + strconv.Itoa(i+1), Label: name})
+		}
+	}
+
+	return options, nil
+}
+
+func demoPorts() option.List[string] {
+	options := make([]option.Option[string], 0, 5000)
+	for i := range 5000 {
+		port := strconv.Itoa(1024 + i)
+		options = append(options, option.Option[string]{Value: port, Label: "Port " + port})
+	}
+
+	return option.New(option.String, options...)
+}
+
 func RenderSelectBoxesContent() html.Node {
 	return html.Div(
 		html.AClass("space-y-10"),
@@ -65,6 +99,85 @@ func RenderSelectBoxesContent() html.Node {
 						),
 					),
 				),
+				html.Div(
+					html.AClass("space-y-4"),
+					html.H3(html.AClass("text-lg font-semibold"), html.Text("Remote Options")),
+					selectbox.SelectBox(
+						selectbox.Props{Multiple: true},
+						selectbox.Trigger(
+							selectbox.TriggerProps{
+								Name:      "customer",
+								Multiple:  true,
+								ShowPills: true,
+								Selected:  []option.Option[string]{{Value: "cus_1", Label: "Acme Corporation"}},
+							},
+							"remote-select-content",
+							selectbox.Value(selectbox.ValueProps{Placeholder: "Select customers...", Multiple: true}),
+						),
+						selectbox.Content(selectbox.ContentProps{
+							ID:                "remote-select-content",
+							SearchPlaceholder: "Search customers...",
+							RemoteURL:         "/api/customers",
+						}),
+					),
+				),
+				html.Div(
+					html.AClass("space-y-4"),
+					html.H3(html.AClass("text-lg font-semibold"), html.Text("Typed Options")),
+					selectbox.FromOptions(
+						selectbox.OptionsProps{
+							Trigger:     selectbox.TriggerProps{Name: "plan"},
+							Content:     selectbox.ContentProps{NoSearch: true},
+							Placeholder: "Select plan...",
+						},
+						demoPlans,
+						1,
+					),
+				),
+				html.Div(
+					html.AClass("space-y-4"),
+					html.H3(html.AClass("text-lg font-semibold"), html.Text("Creatable")),
+					selectbox.SelectBox(
+						selectbox.Props{Multiple: true},
+						selectbox.Trigger(
+							selectbox.TriggerProps{Name: "labels", Multiple: true, ShowPills: true},
+							"creatable-select-content",
+							selectbox.Value(selectbox.ValueProps{Placeholder: "Add labels...", Multiple: true}),
+						),
+						selectbox.Content(
+							selectbox.ContentProps{
+								ID:                "creatable-select-content",
+								SearchPlaceholder: "Find or create a label...",
+								Creatable:         true,
+								CreateText:        "Create label \"{q}\"",
+							},
+							selectbox.Items(option.New(option.String,
+								option.Option[string]{Value: "bug"},
+								option.Option[string]{Value: "feature"},
+								option.Option[string]{Value: "question"},
+							)),
+						),
+					),
+				),
+				html.Div(
+					html.AClass("space-y-4"),
+					html.H3(html.AClass("text-lg font-semibold"), html.Text("Virtualized List")),
+					selectbox.SelectBox(
+						selectbox.Trigger(
+							selectbox.TriggerProps{Name: "port"},
+							"virtual-select-content",
+							selectbox.Value(selectbox.ValueProps{Placeholder: "Select a port..."}),
+						),
+						selectbox.Content(
+							selectbox.ContentProps{
+								ID:                "virtual-select-content",
+								SearchPlaceholder: "Search 5,000 ports...",
+								Virtual:           virtual.Props{ItemHeight: 38},
+							},
+							selectbox.Items(demoPorts()),
+						),
+					),
+				),
 			),
 		),
 	)
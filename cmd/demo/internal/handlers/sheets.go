@@ -0,0 +1,131 @@
+package handlers
+
+import (
+	"strings"
+
+	"github.com/plainkit/html"
+	"github.com/plainkit/ui/button"
+	"github.com/plainkit/ui/checkbox"
+	"github.com/plainkit/ui/label"
+	"github.com/plainkit/ui/sheet"
+)
+
+func RenderSheetsContent() html.Node {
+	sides := []struct {
+		side  sheet.Side
+		label string
+	}{
+		{sheet.SideTop, "Top"},
+		{sheet.SideRight, "Right"},
+		{sheet.SideBottom, "Bottom"},
+		{sheet.SideLeft, "Left"},
+	}
+
+	triggers := []html.DivArg{html.AClass("flex flex-wrap gap-3")}
+	panels := []html.DivArg{}
+
+	for _, s := range sides {
+		id := "demo-sheet-" + string(s.side)
+		triggers = append(triggers, sheet.Trigger(
+			sheet.TriggerProps{For: id},
+			button.Props{Variant: button.VariantOutline},
+			html.Text(s.label),
+		))
+		panels = append(panels, sheet.Content(
+			sheet.ContentProps{ID: id, Side: s.side, Size: sheet.SizeSm},
+			sheet.Header(
+				sheet.HeaderProps{},
+				sheet.Title(sheet.TitleProps{}, html.Text(s.label+" sheet")),
+				sheet.Description(sheet.DescriptionProps{}, html.Text("Slides in from the "+string(s.side)+" edge. On touch, drag it back towards that edge to dismiss it.")),
+			),
+			sheet.Footer(
+				sheet.FooterProps{},
+				sheet.Close(sheet.CloseProps{For: id}, button.Button(button.Props{}, html.Text("Done"))),
+			),
+		))
+	}
+
+	filters := []string{"Open", "In review", "Merged", "Closed"}
+	filterItems := []html.DivArg{html.AClass("grid gap-3")}
+
+	for _, f := range filters {
+		id := "sheet-filter-" + strings.ReplaceAll(strings.ToLower(f), " ", "-")
+		filterItems = append(filterItems, html.Div(
+			html.AClass("flex items-center gap-2"),
+			checkbox.Checkbox(checkbox.Props{ID: id, Name: "status", Value: f, Checked: f == "Open"}),
+			label.Label(label.Props{For: id}, html.Text(f)),
+		))
+	}
+
+	return html.Div(
+		html.AClass("space-y-10"),
+		html.Section(
+			html.AClass("space-y-4"),
+			html.Div(
+				html.AClass("space-y-1"),
+				html.H2(html.AClass("text-2xl font-semibold"), html.Text("Sheets")),
+				html.P(html.AClass("text-sm text-muted-foreground"), html.Text("Panels sliding in from any edge. They share focus trapping, scroll locking and Escape handling with dialogs.")),
+			),
+			html.Div(triggers...),
+			html.Div(panels...),
+		),
+		html.Section(
+			html.AClass("space-y-4"),
+			html.Div(
+				html.AClass("space-y-1"),
+				html.H2(html.AClass("text-2xl font-semibold"), html.Text("Filters")),
+				html.P(html.AClass("text-sm text-muted-foreground"), html.Text("A medium side sheet holding a form, with its footer pinned to the bottom.")),
+			),
+			sheet.Trigger(
+				sheet.TriggerProps{For: "demo-sheet-filters"},
+				button.Props{},
+				html.Text("Filter issues"),
+			),
+			sheet.Content(
+				sheet.ContentProps{ID: "demo-sheet-filters"},
+				sheet.Header(
+					sheet.HeaderProps{},
+					sheet.Title(sheet.TitleProps{}, html.Text("Filters")),
+					sheet.Description(sheet.DescriptionProps{}, html.Text("Show issues with any of these statuses.")),
+				),
+				html.Div(filterItems...),
+				sheet.Footer(
+					sheet.FooterProps{},
+					sheet.Close(sheet.CloseProps{For: "demo-sheet-filters"},
+						button.Button(button.Props{Variant: button.VariantOutline}, html.Text("Cancel"))),
+					sheet.Close(sheet.CloseProps{For: "demo-sheet-filters"},
+						button.Button(button.Props{}, html.Text("Apply"))),
+				),
+			),
+		),
+		html.Section(
+			html.AClass("space-y-4"),
+			html.Div(
+				html.AClass("space-y-1"),
+				html.H2(html.AClass("text-2xl font-semibold"), html.Text("Bottom sheet")),
+				html.P(html.AClass("text-sm text-muted-foreground"), html.Text("The mobile pattern: a grab handle, no close button and a list of actions.")),
+			),
+			sheet.Trigger(
+				sheet.TriggerProps{For: "demo-sheet-actions"},
+				button.Props{Variant: button.VariantSecondary},
+				html.Text("Share"),
+			),
+			sheet.Content(
+				sheet.ContentProps{ID: "demo-sheet-actions", Side: sheet.SideBottom, Handle: true, HideCloseButton: true, Class: "gap-4 pt-3"},
+				sheet.Header(
+					sheet.HeaderProps{Class: "pr-0"},
+					sheet.Title(sheet.TitleProps{}, html.Text("Share document")),
+				),
+				html.Div(
+					html.AClass("grid gap-2"),
+					sheet.Close(sheet.CloseProps{For: "demo-sheet-actions"},
+						button.Button(button.Props{Variant: button.VariantGhost, FullWidth: true, Class: "justify-start"}, html.Text("Copy link"))),
+					sheet.Close(sheet.CloseProps{For: "demo-sheet-actions"},
+						button.Button(button.Props{Variant: button.VariantGhost, FullWidth: true, Class: "justify-start"}, html.Text("Send by email"))),
+					sheet.Close(sheet.CloseProps{For: "demo-sheet-actions"},
+						button.Button(button.Props{Variant: button.VariantGhost, FullWidth: true, Class: "justify-start"}, html.Text("Export as PDF"))),
+				),
+			),
+		),
+	)
+}
@@ -35,5 +35,72 @@ func RenderSlidersContent() html.Node {
 				),
 			),
 		),
+		html.Section(
+			html.AClass("space-y-4"),
+			html.Div(
+				html.AClass("space-y-1"),
+				html.H2(html.AClass("text-2xl font-semibold"), html.Text("Ranges and scales")),
+				html.P(html.AClass("text-sm text-muted-foreground"), html.Text("Select a range with two thumbs, snap to fractional steps, label the scale and stand the slider upright.")),
+			),
+			html.Div(
+				html.AClass("grid gap-6 md:grid-cols-2"),
+				html.Form(
+					html.AClass("space-y-4 rounded-lg border bg-card p-6 shadow-xs"),
+					html.AMethod("get"),
+					slider.Range(slider.RangeProps{
+						ID:          "price-filter",
+						Name:        "price",
+						Label:       "Price",
+						ShowValue:   true,
+						Max:         2000,
+						Step:        10,
+						Low:         200,
+						High:        1200,
+						MinDistance: 100,
+						Format:      &priceFormat,
+						Ticks:       []slider.Tick{{Value: 0, Label: "$0"}, {Value: 500, Label: "$500"}, {Value: 1000, Label: "$1k"}, {Value: 1500, Label: "$1.5k"}, {Value: 2000, Label: "$2k"}},
+					}),
+					html.P(html.AClass("text-xs text-muted-foreground"), html.Text("Submits price_min and price_max; the thumbs stay at least $100 apart.")),
+				),
+				slider.Slider(
+					slider.Input(slider.InputProps{ID: "parcel-weight", Name: "weight", Min: 0.5, Max: 5, Step: 0.25, Value: 1.75, Format: &weightFormat}),
+					html.Div(
+						html.AClass("flex items-center justify-between text-sm"),
+						html.Span(html.Text("Parcel weight")),
+						slider.Value(slider.ValueProps{For: "parcel-weight"}),
+					),
+					slider.Ticks(slider.TicksProps{Min: 0.5, Max: 5, Ticks: []slider.Tick{{Value: 0.5, Label: "0.5"}, {Value: 2, Label: "2"}, {Value: 3.5, Label: "3.5"}, {Value: 5, Label: "5 kg"}}}),
+				),
+				html.Div(
+					html.AClass("flex items-start gap-10 rounded-lg border bg-card p-6 shadow-xs"),
+					html.Div(
+						html.AClass("flex flex-col items-center gap-3"),
+						slider.Input(slider.InputProps{ID: "mixer-gain", Name: "gain", Value: 70, Orientation: slider.OrientationVertical, Format: &gainFormat}),
+						slider.Value(slider.ValueProps{For: "mixer-gain"}),
+					),
+					slider.Range(slider.RangeProps{
+						ID:          "thermostat",
+						Name:        "comfort",
+						Label:       "Comfort band",
+						Min:         16,
+						Max:         28,
+						Step:        0.5,
+						Low:         19.5,
+						High:        23,
+						MinDistance: 1,
+						Orientation: slider.OrientationVertical,
+						Format:      &temperatureFormat,
+						Ticks:       []slider.Tick{{Value: 16, Label: "16°"}, {Value: 20, Label: "20°"}, {Value: 24, Label: "24°"}, {Value: 28, Label: "28°"}},
+					}),
+				),
+			),
+		),
 	)
 }
+
+var (
+	priceFormat       = slider.Currency("$")
+	weightFormat      = slider.Unit("kg")
+	gainFormat        = slider.Percent()
+	temperatureFormat = slider.Format{Suffix: " °C"}
+)
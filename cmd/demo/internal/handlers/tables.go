@@ -1,10 +1,54 @@
 package handlers
 
 import (
+	"fmt"
+	"net/http"
+	"time"
+
 	"github.com/plainkit/html"
 	"github.com/plainkit/ui/table"
+	"github.com/plainkit/ui/virtual"
 )
 
+const auditLogSize = 100_000
+
+var auditActions = []string{"user.login", "user.logout", "invoice.create", "invoice.pay", "project.archive", "token.revoke"}
+
+func auditRow(i int) html.Node {
+	at := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC).Add(time.Duration(i) * 97 * time.Second)
+
+	return table.Row(
+		table.RowProps{},
+		table.Cell(table.CellProps{Class: "py-2.5 font-mono text-xs"}, html.Text(fmt.Sprintf("#%06d", i+1))),
+		table.Cell(table.CellProps{Class: "py-2.5 whitespace-nowrap"}, html.Text(at.Format("2006-01-02 15:04:05"))),
+		table.Cell(table.CellProps{Class: "py-2.5"}, html.Text(fmt.Sprintf("user%d@example.com", i%37+1))),
+		table.Cell(table.CellProps{Class: "py-2.5"}, html.Text(auditActions[i%len(auditActions)])),
+	)
+}
+
+func auditHeader() html.Node {
+	return table.Header(
+		table.HeaderProps{},
+		table.Row(
+			table.RowProps{},
+			table.Head(table.HeadProps{}, html.Text("Event")),
+			table.Head(table.HeadProps{}, html.Text("Time")),
+			table.Head(table.HeadProps{}, html.Text("Actor")),
+			table.Head(table.HeadProps{}, html.Text("Action")),
+		),
+	)
+}
+
+// AuditLogChunk serves rows of the streamed audit log demo.
+func AuditLogChunk(_ *http.Request, offset, limit int) ([]html.Node, int, error) {
+	var rows []html.Node
+	for i := offset; i < min(offset+limit, auditLogSize); i++ {
+		rows = append(rows, auditRow(i))
+	}
+
+	return rows, auditLogSize, nil
+}
+
 func RenderTablesContent() html.Node {
 	type invoice struct {
 		Number string
@@ -33,6 +77,11 @@ func RenderTablesContent() html.Node {
 		)
 	}
 
+	auditRows := []html.TbodyArg{table.BodyProps{Virtual: virtual.Props{ItemHeight: 45}}}
+	for i := range 5000 {
+		auditRows = append(auditRows, auditRow(i))
+	}
+
 	return html.Div(
 		html.AClass("space-y-10"),
 		html.Section(
@@ -68,5 +117,35 @@ func RenderTablesContent() html.Node {
 				),
 			),
 		),
+		html.Section(
+			html.AClass("space-y-4"),
+			html.Div(
+				html.AClass("space-y-1"),
+				html.H2(html.AClass("text-2xl font-semibold"), html.Text("Virtualized Rows")),
+				html.P(html.AClass("text-sm text-muted-foreground"), html.Text("5,000 embedded rows; only the ones in view are mounted. Focus the body and use the arrow, Page and Home/End keys.")),
+			),
+			table.Table(
+				table.Props{MaxHeight: "420px"},
+				auditHeader(),
+				table.Body(auditRows...),
+			),
+		),
+		html.Section(
+			html.AClass("space-y-4"),
+			html.Div(
+				html.AClass("space-y-1"),
+				html.H2(html.AClass("text-2xl font-semibold"), html.Text("Streamed Rows")),
+				html.P(html.AClass("text-sm text-muted-foreground"), html.Text("100,000 audit log entries fetched from the server in chunks as you scroll.")),
+			),
+			table.Table(
+				table.Props{MaxHeight: "420px"},
+				auditHeader(),
+				table.Body(table.BodyProps{Virtual: virtual.Props{
+					ItemHeight: 45,
+					URL:        "/api/audit-log",
+					Count:      auditLogSize,
+				}}),
+			),
+		),
 	)
 }
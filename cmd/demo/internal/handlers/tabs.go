@@ -1,12 +1,36 @@
 package handlers
 
 import (
+	"net/http"
+
 	"github.com/plainkit/html"
 	"github.com/plainkit/ui/tabs"
+	"github.com/plainkit/ui/urlstate"
 )
 
-func RenderTabsContent() html.Node {
+// settingsTabs keeps the selected settings tab in ?section=, adding a history entry per
+// switch so Back returns to the previous tab.
+var settingsTabs = urlstate.Sync{Param: "section", Push: true}
+
+func RenderTabsContent(r *http.Request) html.Node {
 	tabsID := "plans-tabs"
+	settingsID := "settings-tabs"
+	section := settingsTabs.ValueOr(r, "profile")
+
+	settingsTab := func(value, title, text string) (html.Node, html.Node) {
+		return tabs.Trigger(tabs.TriggerProps{TabsID: settingsID, Value: value, IsActive: section == value}, html.T(title)),
+			tabs.Content(tabs.ContentProps{TabsID: settingsID, Value: value, IsActive: section == value},
+				html.Div(
+					html.AClass("space-y-2"),
+					html.H3(html.AClass("text-lg font-semibold"), html.Text(title)),
+					html.P(html.AClass("text-sm text-muted-foreground"), html.Text(text)),
+				),
+			)
+	}
+
+	profileTrigger, profileContent := settingsTab("profile", "Profile", "Your name, avatar and public details.")
+	securityTrigger, securityContent := settingsTab("security", "Security", "Passwords, passkeys and active sessions.")
+	billingTrigger, billingContent := settingsTab("billing", "Billing", "Plan, invoices and payment methods.")
 
 	return html.Div(
 		html.AClass("space-y-10"),
@@ -47,5 +71,20 @@ func RenderTabsContent() html.Node {
 				),
 			),
 		),
+		html.Section(
+			html.AClass("space-y-4"),
+			html.Div(
+				html.AClass("space-y-1"),
+				html.H2(html.AClass("text-2xl font-semibold"), html.Text("Linked tabs")),
+				html.P(html.AClass("text-sm text-muted-foreground"), html.Text("The selected tab is kept in ?section=, so the URL can be shared and Back returns to the previous tab. The server renders the linked tab, even without JavaScript.")),
+			),
+			tabs.Tabs(
+				tabs.Props{ID: settingsID, Sync: settingsTabs, Default: "profile"},
+				tabs.List(tabs.ListProps{TabsID: settingsID}, profileTrigger, securityTrigger, billingTrigger),
+				profileContent,
+				securityContent,
+				billingContent,
+			),
+		),
 	)
 }
@@ -1,11 +1,32 @@
 package handlers
 
 import (
+	"net/http"
+	"strings"
+
 	"github.com/plainkit/html"
 	"github.com/plainkit/ui/card"
 	"github.com/plainkit/ui/tagsinput"
 )
 
+var demoTopics = []string{
+	"accessibility", "api", "backend", "billing", "bug", "design", "docs", "frontend",
+	"infrastructure", "performance", "security", "testing", "ux",
+}
+
+// SuggestTopics backs the remote tags input suggestions demo.
+func SuggestTopics(_ *http.Request, query string) ([]string, error) {
+	var matches []string
+
+	for _, topic := range demoTopics {
+		if strings.Contains(topic, strings.ToLower(query)) {
+			matches = append(matches, topic)
+		}
+	}
+
+	return matches, nil
+}
+
 func RenderTagsInputContent() html.Node {
 	return card.Card(card.Props{},
 		card.Header(card.HeaderProps{},
@@ -46,6 +67,41 @@ func RenderTagsInputContent() html.Node {
 					),
 				),
 
+				// Suggestions
+				html.Div(
+					html.AClass("space-y-2"),
+					html.H3(html.AClass("text-lg font-semibold"), html.Text("Suggestions")),
+					html.Div(
+						html.AClass("max-w-md"),
+						tagsinput.TagsInput(tagsinput.Props{
+							ID:          "suggested-tags",
+							Name:        "languages",
+							Placeholder: "Pick languages...",
+							Suggestions: []string{"Go", "Rust", "TypeScript", "Python", "Elixir", "Zig"},
+							MaxTags:     3,
+						}),
+					),
+					html.P(html.AClass("text-sm text-muted-foreground"), html.Text("Use the arrow keys to pick a suggestion. At most three tags.")),
+				),
+
+				// Remote suggestions with validation
+				html.Div(
+					html.AClass("space-y-2"),
+					html.H3(html.AClass("text-lg font-semibold"), html.Text("Remote Suggestions")),
+					html.Div(
+						html.AClass("max-w-md"),
+						tagsinput.TagsInput(tagsinput.Props{
+							ID:          "topic-tags",
+							Name:        "topics",
+							Placeholder: "Add topics...",
+							SuggestURL:  "/api/topics",
+							Pattern:     "[a-z0-9-]+",
+							Delimiters:  ", ",
+						}),
+					),
+					html.P(html.AClass("text-sm text-muted-foreground"), html.Text("Lowercase letters, digits and dashes only. Space or comma completes a tag; pasted lists are split.")),
+				),
+
 				// Disabled Tags Input
 				html.Div(
 					html.AClass("space-y-2"),
@@ -1,11 +1,62 @@
 package handlers
 
 import (
+	"net/http"
+	"strconv"
+	"strings"
+
 	"github.com/plainkit/html"
 	"github.com/plainkit/ui/label"
 	"github.com/plainkit/ui/textarea"
 )
 
+var demoIssues = []textarea.MentionItem{
+	{ID: "128", Label: "128", Hint: "Dropdown closes when scrolling inside it"},
+	{ID: "131", Label: "131", Hint: "Dark mode contrast on disabled buttons"},
+	{ID: "142", Label: "142", Hint: "Calendar week starts on Sunday in de-DE"},
+	{ID: "157", Label: "157", Hint: "Toast stacking overlaps the footer"},
+	{ID: "163", Label: "163", Hint: "Select box search ignores accents"},
+}
+
+// CommentTriggers configures the mentions, issue references and slash commands of the comment demo.
+var CommentTriggers = []textarea.Trigger{
+	{
+		Char: "@",
+		Name: "mentions",
+		Items: []textarea.MentionItem{
+			{ID: "u1", Label: "ana", Hint: "Ana López"},
+			{ID: "u2", Label: "ben", Hint: "Ben Okafor"},
+			{ID: "u3", Label: "chen", Hint: "Chen Wei"},
+			{ID: "u4", Label: "dana", Hint: "Dana Fischer"},
+		},
+	},
+	{Char: "#", Name: "issues", URL: "/api/issues"},
+	{
+		Char:        "/",
+		StartOfLine: true,
+		Items: []textarea.MentionItem{
+			{ID: "shrug", Label: "shrug", Hint: "Insert ¯\\_(ツ)_/¯", Insert: "¯\\_(ツ)_/¯"},
+			{ID: "todo", Label: "todo", Hint: "Insert a task list item", Insert: "- [ ] "},
+			{ID: "quote", Label: "quote", Hint: "Start a block quote", Insert: "> "},
+		},
+	},
+}
+
+// SearchIssues backs the "#" issue references of the comment demo.
+func SearchIssues(_ *http.Request, _ string, query string) ([]textarea.MentionItem, error) {
+	var matches []textarea.MentionItem
+
+	for _, issue := range demoIssues {
+		if _, err := strconv.Atoi(query); err == nil && strings.HasPrefix(issue.ID, query) {
+			matches = append(matches, issue)
+		} else if strings.Contains(strings.ToLower(issue.Hint), strings.ToLower(query)) {
+			matches = append(matches, issue)
+		}
+	}
+
+	return matches, nil
+}
+
 func RenderTextareasContent() html.Node {
 	return html.Div(
 		html.AClass("space-y-10"),
@@ -28,6 +79,49 @@ func RenderTextareasContent() html.Node {
 					label.Label(label.Props{For: "notes"}, html.Text("Meeting notes")),
 					textarea.Textarea(textarea.Props{ID: "notes", Name: "notes", AutoResize: true, Placeholder: "Notes will auto-resize as you type."}),
 				),
+				html.Div(
+					html.AClass("space-y-2"),
+					label.Label(label.Props{For: "sms-template"}, html.Text("SMS template")),
+					textarea.Textarea(textarea.Props{ID: "sms-template", Name: "sms_template", Rows: 3, MaxLength: 160, SoftLimit: true, CountWords: true, Placeholder: "Hi {name}, your order has shipped."}),
+				),
+				html.Div(
+					html.AClass("space-y-2"),
+					label.Label(label.Props{For: "ticket-description"}, html.Text("Ticket description")),
+					textarea.Textarea(textarea.Props{ID: "ticket-description", Name: "ticket_description", Rows: 3, MinLength: 30, MaxLength: 500, ShowCount: true}),
+				),
+			),
+		),
+		html.Section(
+			html.AClass("space-y-4"),
+			html.Div(
+				html.AClass("space-y-1"),
+				html.H2(html.AClass("text-2xl font-semibold"), html.Text("Mentions and commands")),
+				html.P(html.AClass("text-sm text-muted-foreground"), html.Text("Type @ to mention a teammate, # to reference an issue from the server, or / at the start of a line for commands.")),
+			),
+			html.Div(
+				html.AClass("space-y-2"),
+				label.Label(label.Props{For: "comment"}, html.Text("Comment")),
+				textarea.Textarea(textarea.Props{ID: "comment", Name: "comment", Rows: 4, Triggers: CommentTriggers, Placeholder: "Thanks @ana, this fixes #142."}),
+			),
+		),
+		html.Section(
+			html.AClass("space-y-4"),
+			html.Div(
+				html.AClass("space-y-1"),
+				html.H2(html.AClass("text-2xl font-semibold"), html.Text("Markdown editor")),
+				html.P(html.AClass("text-sm text-muted-foreground"), html.Text("Format with the toolbar or Ctrl+B, Ctrl+I and Ctrl+K, and check the server-rendered preview with Ctrl+Shift+P.")),
+			),
+			html.Div(
+				html.AClass("space-y-2"),
+				label.Label(label.Props{For: "release-notes"}, html.Text("Release notes")),
+				textarea.Textarea(textarea.Props{
+					ID:         "release-notes",
+					Name:       "release_notes",
+					Rows:       8,
+					Markdown:   true,
+					PreviewURL: "/api/markdown-preview",
+					Value:      "## What's new\n\n- **Faster** search results\n- Export to [CSV](https://example.com/docs)\n",
+				}),
 			),
 		),
 	)
@@ -13,12 +13,29 @@ import (
 	"github.com/plainkit/icons/lucide"
 	democss "github.com/plainkit/ui/cmd/demo/internal/css"
 	"github.com/plainkit/ui/cmd/demo/internal/handlers"
+	"github.com/plainkit/ui/fileupload"
+	"github.com/plainkit/ui/hovercard"
+	"github.com/plainkit/ui/markdown"
+	"github.com/plainkit/ui/popover"
+	"github.com/plainkit/ui/selectbox"
+	"github.com/plainkit/ui/tagsinput"
+	"github.com/plainkit/ui/textarea"
+	"github.com/plainkit/ui/virtual"
 )
 
 type page struct {
 	Path    string
 	Label   string
 	Content func() html.Node
+	Request func(r *http.Request) html.Node // Used instead of Content by pages whose initial state comes from the URL
+}
+
+func (p page) render(r *http.Request) html.Node {
+	if p.Request != nil {
+		return p.Request(r)
+	}
+
+	return p.Content()
 }
 
 var pages = []page{
@@ -35,12 +52,16 @@ var pages = []page{
 	{Path: "/checkboxes", Label: "Checkboxes", Content: handlers.RenderCheckboxesContent},
 	{Path: "/code", Label: "Code", Content: handlers.RenderCodeContent},
 	{Path: "/collapsible", Label: "Collapsible", Content: handlers.RenderCollapsibleContent},
-	{Path: "/dialogs", Label: "Dialogs", Content: handlers.RenderDialogsContent},
+	{Path: "/command", Label: "Command Palette", Content: handlers.RenderCommandContent},
+	{Path: "/dialogs", Label: "Dialogs", Request: handlers.RenderDialogsContent},
 	{Path: "/dropdowns", Label: "Dropdowns", Content: handlers.RenderDropdownsContent},
+	{Path: "/file-upload", Label: "File Upload", Content: handlers.RenderFileUploadContent},
 	{Path: "/forms", Label: "Form Helpers", Content: handlers.RenderFormContent},
+	{Path: "/hover-cards", Label: "Hover Cards", Content: handlers.RenderHovercardsContent},
 	{Path: "/input-otp", Label: "Input OTP", Content: handlers.RenderInputOTPContent},
 	{Path: "/inputs", Label: "Inputs", Content: handlers.RenderInputsContent},
 	{Path: "/labels", Label: "Labels", Content: handlers.RenderLabelsContent},
+	{Path: "/number-inputs", Label: "Number Inputs", Content: handlers.RenderNumberInputsContent},
 	{Path: "/pagination", Label: "Pagination", Content: handlers.RenderPaginationContent},
 	{Path: "/popovers", Label: "Popovers", Content: handlers.RenderPopoversContent},
 	{Path: "/progress", Label: "Progress", Content: handlers.RenderProgressContent},
@@ -48,11 +69,12 @@ var pages = []page{
 	{Path: "/ratings", Label: "Ratings", Content: handlers.RenderRatingsContent},
 	{Path: "/selectboxes", Label: "Select Boxes", Content: handlers.RenderSelectBoxesContent},
 	{Path: "/separators", Label: "Separators", Content: handlers.RenderSeparatorsContent},
+	{Path: "/sheets", Label: "Sheets", Content: handlers.RenderSheetsContent},
 	{Path: "/skeletons", Label: "Skeletons", Content: handlers.RenderSkeletonsContent},
 	{Path: "/sliders", Label: "Sliders", Content: handlers.RenderSlidersContent},
 	{Path: "/switches", Label: "Switches", Content: handlers.RenderSwitchesContent},
 	{Path: "/tables", Label: "Tables", Content: handlers.RenderTablesContent},
-	{Path: "/tabs", Label: "Tabs", Content: handlers.RenderTabsContent},
+	{Path: "/tabs", Label: "Tabs", Request: handlers.RenderTabsContent},
 	{Path: "/tags-input", Label: "Tags Input", Content: handlers.RenderTagsInputContent},
 	{Path: "/textareas", Label: "Textareas", Content: handlers.RenderTextareasContent},
 	{Path: "/timepicker", Label: "Time Picker", Content: handlers.RenderTimePickerContent},
@@ -77,16 +99,33 @@ func main() {
 		return
 	}
 
+	uploads, err := fileupload.NewLocalStorage(filepath.Join(os.TempDir(), "plainkit-ui-uploads"))
+	if err != nil {
+		log.Fatalf("Failed to prepare upload storage: %v", err)
+	}
+
 	mux := http.NewServeMux()
 	mux.HandleFunc("/assets/styles.css", cssHandler)
 	mux.HandleFunc("/robots.txt", robotsHandler)
+	mux.Handle("/api/customers", selectbox.RemoteHandler(handlers.SearchCustomers))
+	mux.Handle("/api/audit-log", virtual.Handler(handlers.AuditLogChunk))
+	mux.Handle("/api/topics", tagsinput.SuggestHandler(handlers.SuggestTopics))
+	mux.Handle("/api/uploads", fileupload.Handler(uploads, handlers.DocumentUpload))
+	mux.Handle("/api/markdown-preview", markdown.Handler())
+	mux.Handle("/api/issues", textarea.MentionHandler(handlers.SearchIssues))
+	mux.HandleFunc("/api/two-factor/verify", handlers.VerifyTwoFactor)
+	mux.HandleFunc("/api/signup", handlers.CreateAccount)
+	mux.HandleFunc("/api/contacts", handlers.Contact)
+	mux.HandleFunc("/api/projects/delete", handlers.DeleteProject)
+	mux.Handle("/api/people", hovercard.Handler(handlers.PersonPreview))
+	mux.Handle(popover.FallbackPath, popover.FallbackHandler())
 
 	for _, pg := range pages {
 		p := pg
 		mux.HandleFunc(p.Path, func(w http.ResponseWriter, r *http.Request) {
 			w.Header().Set("Content-Type", "text/html; charset=utf-8")
 
-			body := p.Content()
+			body := p.render(r)
 			if _, err := w.Write([]byte(renderPage(p.Path, body, false))); err != nil {
 				log.Printf("write response: %v", err)
 			}
@@ -309,7 +348,12 @@ Disallow:
 		log.Printf("Generating page: %s", pg.Label)
 
 		// Generate page content
-		body := pg.Content()
+		req, err := http.NewRequest(http.MethodGet, pg.Path, nil)
+		if err != nil {
+			return fmt.Errorf("failed to build request for %s: %w", pg.Path, err)
+		}
+
+		body := pg.render(req)
 		htmlContent := renderPage(pg.Path, body, true)
 
 		// Create subdirectory if needed
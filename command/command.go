@@ -0,0 +1,307 @@
+package command
+
+import (
+	_ "embed"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/plainkit/html"
+	"github.com/plainkit/icons/lucide"
+	"github.com/plainkit/ui/button"
+	"github.com/plainkit/ui/dialog"
+	"github.com/plainkit/ui/dropdown"
+	"github.com/plainkit/ui/internal/randomid"
+	"github.com/plainkit/ui/internal/styles"
+)
+
+// QueryParam is the query string parameter carrying the search text sent to SearchURL.
+const QueryParam = "q"
+
+type Props struct {
+	ID             string
+	Class          string
+	Attrs          []html.Global
+	Placeholder    string
+	EmptyText      string
+	Hotkey         string // Global shortcut toggling the palette, e.g. "mod+k"
+	SearchURL      string // Endpoint returning rendered items for the typed query (optional)
+	SearchDebounce int    // Milliseconds to wait after typing before querying SearchURL
+	Open           bool
+}
+
+type TriggerProps struct {
+	ID       string
+	Class    string
+	Attrs    []html.Global
+	For      string // ID of the command palette to open
+	Disabled bool
+}
+
+type GroupProps struct {
+	ID      string
+	Class   string
+	Attrs   []html.Global
+	Heading string
+}
+
+type ItemProps struct {
+	ID       string
+	Class    string
+	Attrs    []html.Global
+	Value    string
+	Keywords []string // Additional terms matched by the client-side search
+	Href     string   // Navigate to this URL when the item is chosen
+	Shortcut string   // Key hint displayed next to the item, e.g. "⌘N"
+	Hotkey   string   // Global shortcut choosing the item without opening the palette, e.g. "mod+n"
+	Disabled bool
+}
+
+type SeparatorProps struct {
+	ID    string
+	Class string
+	Attrs []html.Global
+}
+
+// Command renders a searchable command palette hosted in a dialog.
+func Command(props Props, args ...html.DivArg) html.Node {
+	id := props.ID
+	if id == "" {
+		id = randomid.New("command")
+	}
+
+	placeholder := props.Placeholder
+	if placeholder == "" {
+		placeholder = "Type a command or search..."
+	}
+
+	emptyText := props.EmptyText
+	if emptyText == "" {
+		emptyText = "No results found."
+	}
+
+	debounce := props.SearchDebounce
+	if debounce <= 0 {
+		debounce = 200
+	}
+
+	attrs := []html.Global{
+		html.AData("pui-command", ""),
+		html.AData("pui-command-debounce", strconv.Itoa(debounce)),
+	}
+
+	if props.Hotkey != "" {
+		attrs = append(attrs, html.AData("pui-command-hotkey", props.Hotkey))
+	}
+
+	if props.SearchURL != "" {
+		attrs = append(attrs, html.AData("pui-command-search-url", props.SearchURL))
+	}
+
+	attrs = append(attrs, props.Attrs...)
+
+	search := html.Div(
+		html.AClass("flex items-center gap-3 border-b border-border/60 px-4"),
+		lucide.Search(html.AClass("size-4 shrink-0 text-muted-foreground/70")),
+		html.Input(
+			html.AType("text"),
+			html.AClass(html.ClassMerge(
+				"h-12 w-full bg-transparent text-sm outline-none",
+				"placeholder:text-muted-foreground/80",
+			)),
+			html.APlaceholder(placeholder),
+			html.AAutocomplete("off"),
+			html.ASpellcheck(false),
+			html.ACustom("role", "combobox"),
+			html.AAria("expanded", "true"),
+			html.AAria("controls", id+"-list"),
+			html.AAria("autocomplete", "list"),
+			html.AData("pui-command-input", ""),
+		),
+		html.Span(
+			html.AClass("hidden shrink-0 text-muted-foreground/70 data-[pui-command-loading=true]:inline-flex"),
+			html.AData("pui-command-spinner", ""),
+			lucide.LoaderCircle(html.AClass("size-4 animate-spin")),
+		),
+	)
+
+	listArgs := []html.DivArg{
+		html.AId(id + "-list"),
+		html.AClass("flex max-h-[min(60vh,360px)] flex-col overflow-y-auto overscroll-contain p-2"),
+		html.ACustom("role", "listbox"),
+		html.AData("pui-command-list", ""),
+	}
+	listArgs = append(listArgs, args...)
+	listArgs = append(listArgs,
+		html.Div(html.AData("pui-command-remote", "")),
+		html.Div(
+			html.AClass(styles.SubtleText("hidden py-8 text-center")),
+			html.AData("pui-command-empty", ""),
+			html.Text(emptyText),
+		),
+	)
+
+	content := dialog.Content(
+		dialog.ContentProps{
+			ID:              id,
+			HideCloseButton: true,
+			Open:            props.Open,
+			Class: html.ClassMerge(
+				"top-[15vh] translate-y-0 max-w-[min(90vw,560px)] gap-0 overflow-hidden p-0",
+				props.Class,
+			),
+			Attrs: attrs,
+		},
+		search,
+		html.Div(listArgs...),
+	)
+
+	return html.Div(html.Child(content)).WithAssets("", commandJS, "ui-command")
+}
+
+// Trigger creates a button that opens the command palette.
+func Trigger(triggerProps TriggerProps, buttonProps button.Props, args ...html.ButtonArg) html.Node {
+	return dialog.Trigger(
+		dialog.TriggerProps{
+			ID:       triggerProps.ID,
+			Class:    triggerProps.Class,
+			Attrs:    triggerProps.Attrs,
+			Disabled: triggerProps.Disabled,
+			For:      triggerProps.For,
+		},
+		buttonProps,
+		args...,
+	)
+}
+
+// Group creates a titled section of command items.
+func Group(props GroupProps, args ...html.DivArg) html.Node {
+	divArgs := []html.DivArg{
+		html.AClass(html.ClassMerge("flex flex-col py-1", props.Class)),
+		html.ACustom("role", "group"),
+		html.AData("pui-command-group", ""),
+	}
+
+	if props.ID != "" {
+		divArgs = append(divArgs, html.AId(props.ID))
+	}
+
+	for _, attr := range props.Attrs {
+		divArgs = append(divArgs, attr)
+	}
+
+	if props.Heading != "" {
+		divArgs = append(divArgs, html.Div(
+			html.AClass(styles.SubHeading("px-3 py-2 text-xs uppercase tracking-wide text-muted-foreground/60")),
+			html.AData("pui-command-group-heading", ""),
+			html.Text(props.Heading),
+		))
+	}
+
+	divArgs = append(divArgs, args...)
+
+	return html.Div(divArgs...)
+}
+
+// Item creates a selectable command entry.
+func Item(props ItemProps, args ...html.DivArg) html.Node {
+	divArgs := []html.DivArg{
+		html.AClass(html.ClassMerge(
+			styles.InteractiveGhost(
+				"flex w-full cursor-pointer select-none items-center justify-start gap-3",
+				"rounded-lg px-3 py-2 text-left text-sm",
+			),
+			"data-[pui-command-active=true]:bg-muted/70 data-[pui-command-active=true]:text-foreground",
+			func() string {
+				if props.Disabled {
+					return "pointer-events-none opacity-50"
+				}
+
+				return ""
+			}(),
+			props.Class,
+		)),
+		html.ACustom("role", "option"),
+		html.AAria("selected", "false"),
+		html.AData("pui-command-item", ""),
+		html.AData("pui-command-value", props.Value),
+	}
+
+	if props.ID != "" {
+		divArgs = append(divArgs, html.AId(props.ID))
+	}
+
+	if len(props.Keywords) > 0 {
+		divArgs = append(divArgs, html.AData("pui-command-keywords", strings.Join(props.Keywords, " ")))
+	}
+
+	if props.Href != "" {
+		divArgs = append(divArgs, html.AData("pui-command-href", props.Href))
+	}
+
+	if props.Hotkey != "" {
+		divArgs = append(divArgs, html.AData("pui-command-hotkey", props.Hotkey))
+	}
+
+	if props.Disabled {
+		divArgs = append(divArgs,
+			html.AAria("disabled", "true"),
+			html.AData("pui-command-disabled", "true"),
+		)
+	}
+
+	for _, attr := range props.Attrs {
+		divArgs = append(divArgs, attr)
+	}
+
+	divArgs = append(divArgs, args...)
+
+	if props.Shortcut != "" {
+		divArgs = append(divArgs, dropdown.Shortcut(dropdown.ShortcutProps{}, html.Text(props.Shortcut)))
+	}
+
+	return html.Div(divArgs...)
+}
+
+// Separator creates a divider between command groups.
+func Separator(props SeparatorProps) html.Node {
+	divArgs := []html.DivArg{
+		html.AClass(html.ClassMerge("my-1 -mx-2 h-px bg-border/60", props.Class)),
+		html.ACustom("role", "separator"),
+		html.AData("pui-command-separator", ""),
+	}
+
+	if props.ID != "" {
+		divArgs = append(divArgs, html.AId(props.ID))
+	}
+
+	for _, attr := range props.Attrs {
+		divArgs = append(divArgs, attr)
+	}
+
+	return html.Div(divArgs...)
+}
+
+// SearchFunc resolves server-side results for the text typed into the palette.
+type SearchFunc func(r *http.Request, query string) []html.Node
+
+// SearchHandler serves the rendered items requested by a palette's SearchURL.
+// The returned nodes are usually Group or Item components.
+func SearchHandler(fn SearchFunc) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := strings.TrimSpace(r.URL.Query().Get(QueryParam))
+
+		var sb strings.Builder
+		for _, node := range fn(r, query) {
+			sb.WriteString(html.Render(node))
+		}
+
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Header().Set("Cache-Control", "no-store")
+
+		_, _ = w.Write([]byte(sb.String()))
+	})
+}
+
+//go:embed command.js
+var commandJS string
@@ -0,0 +1,438 @@
+(function () {
+  "use strict";
+
+  const isMac = /Mac|iPhone|iPad/.test(navigator.platform || "");
+  const hotkeys = [];
+  const searchTimers = new WeakMap();
+  const searchControllers = new WeakMap();
+
+  // Parse "mod+shift+k" into a matcher descriptor
+  function parseHotkey(hotkey) {
+    const parts = hotkey.toLowerCase().split("+").map((p) => p.trim());
+    const combo = { key: parts.pop(), ctrl: false, meta: false, alt: false, shift: false };
+
+    parts.forEach((part) => {
+      if (part === "mod") {
+        isMac ? (combo.meta = true) : (combo.ctrl = true);
+      } else if (part === "ctrl" || part === "control") {
+        combo.ctrl = true;
+      } else if (part === "cmd" || part === "meta") {
+        combo.meta = true;
+      } else if (part === "alt" || part === "option") {
+        combo.alt = true;
+      } else if (part === "shift") {
+        combo.shift = true;
+      }
+    });
+
+    return combo;
+  }
+
+  function matchesHotkey(e, combo) {
+    return (
+      (e.key || "").toLowerCase() === combo.key &&
+      e.ctrlKey === combo.ctrl &&
+      e.metaKey === combo.meta &&
+      e.altKey === combo.alt &&
+      e.shiftKey === combo.shift
+    );
+  }
+
+  // Whether the combo can be typed as text: no modifier, or only Shift
+  function isPlain(combo) {
+    return !combo.ctrl && !combo.meta && !combo.alt;
+  }
+
+  function isEditable(el) {
+    return (
+      el instanceof Element &&
+      (el.isContentEditable || !!el.closest("input, textarea, select, [contenteditable]:not([contenteditable='false'])"))
+    );
+  }
+
+  // Register a global hotkey, returns an unregister function
+  function registerHotkey(hotkey, handler) {
+    const entry = { combo: parseHotkey(hotkey), handler };
+    hotkeys.push(entry);
+
+    return () => {
+      const index = hotkeys.indexOf(entry);
+      if (index >= 0) hotkeys.splice(index, 1);
+    };
+  }
+
+  function paletteId(palette) {
+    return palette.getAttribute("data-dialog-instance");
+  }
+
+  function isOpen(palette) {
+    return palette.getAttribute("data-pui-dialog-open") === "true";
+  }
+
+  function openPalette(id) {
+    const palette = getPalette(id);
+    if (!palette || isOpen(palette)) return;
+
+    window.tui?.dialog?.open(id);
+    reset(palette);
+    setTimeout(() => palette.querySelector("[data-pui-command-input]")?.focus(), 60);
+  }
+
+  function closePalette(id) {
+    window.tui?.dialog?.close(id);
+  }
+
+  function togglePalette(id) {
+    const palette = getPalette(id);
+    if (!palette) return;
+
+    isOpen(palette) ? closePalette(id) : openPalette(id);
+  }
+
+  function getPalette(id) {
+    return document.querySelector(
+      '[data-pui-command][data-dialog-instance="' + CSS.escape(id) + '"]',
+    );
+  }
+
+  // Subsequence match, returns a score (higher is better) or -1
+  function fuzzyScore(query, text) {
+    if (!query) return 0;
+
+    text = text.toLowerCase();
+    const direct = text.indexOf(query);
+    if (direct >= 0) return 1000 - direct;
+
+    let score = 0;
+    let last = -1;
+    for (const ch of query) {
+      const index = text.indexOf(ch, last + 1);
+      if (index < 0) return -1;
+
+      score += index === last + 1 ? 5 : 1;
+      last = index;
+    }
+
+    return score;
+  }
+
+  function itemText(item) {
+    return [
+      item.textContent,
+      item.getAttribute("data-pui-command-value"),
+      item.getAttribute("data-pui-command-keywords"),
+    ]
+      .filter(Boolean)
+      .join(" ");
+  }
+
+  function staticItems(palette) {
+    return Array.from(
+      palette.querySelectorAll("[data-pui-command-item]"),
+    ).filter((item) => !item.closest("[data-pui-command-remote]"));
+  }
+
+  function visibleItems(palette) {
+    return Array.from(
+      palette.querySelectorAll("[data-pui-command-item]"),
+    ).filter(
+      (item) =>
+        !item.hidden &&
+        item.getAttribute("data-pui-command-disabled") !== "true",
+    );
+  }
+
+  function filter(palette, query) {
+    query = query.trim().toLowerCase();
+
+    staticItems(palette).forEach((item) => {
+      const score = fuzzyScore(query, itemText(item));
+      item.hidden = score < 0;
+      item.style.order = query && score >= 0 ? String(-score) : "";
+    });
+
+    palette.querySelectorAll("[data-pui-command-group]").forEach((group) => {
+      const hasItems = Array.from(
+        group.querySelectorAll("[data-pui-command-item]"),
+      ).some((item) => !item.hidden);
+      group.hidden = !hasItems;
+    });
+
+    palette
+      .querySelectorAll("[data-pui-command-separator]")
+      .forEach((separator) => {
+        separator.hidden = !!query;
+      });
+
+    updateEmpty(palette);
+    setActive(palette, firstVisible(palette));
+  }
+
+  function firstVisible(palette) {
+    return (
+      visibleItems(palette).sort(
+        (a, b) => a.getBoundingClientRect().top - b.getBoundingClientRect().top,
+      )[0] || null
+    );
+  }
+
+  function updateEmpty(palette) {
+    const empty = palette.querySelector("[data-pui-command-empty]");
+    if (!empty) return;
+
+    const loading =
+      palette
+        .querySelector("[data-pui-command-spinner]")
+        ?.getAttribute("data-pui-command-loading") === "true";
+    empty.classList.toggle(
+      "hidden",
+      loading || visibleItems(palette).length > 0,
+    );
+  }
+
+  function setActive(palette, item) {
+    palette
+      .querySelectorAll('[data-pui-command-active="true"]')
+      .forEach((el) => {
+        el.setAttribute("data-pui-command-active", "false");
+        el.setAttribute("aria-selected", "false");
+      });
+
+    const input = palette.querySelector("[data-pui-command-input]");
+    if (!item) {
+      input?.removeAttribute("aria-activedescendant");
+      return;
+    }
+
+    if (!item.id) {
+      item.id =
+        "pui-command-item-" + Math.random().toString(36).slice(2, 10);
+    }
+
+    item.setAttribute("data-pui-command-active", "true");
+    item.setAttribute("aria-selected", "true");
+    input?.setAttribute("aria-activedescendant", item.id);
+    item.scrollIntoView({ block: "nearest" });
+  }
+
+  function move(palette, delta) {
+    // Sort by rendered position since ranking reorders items visually
+    const items = visibleItems(palette).sort(
+      (a, b) => a.getBoundingClientRect().top - b.getBoundingClientRect().top,
+    );
+    if (items.length === 0) return;
+
+    const current = palette.querySelector('[data-pui-command-active="true"]');
+    let index = items.indexOf(current) + delta;
+    if (index < 0) index = items.length - 1;
+    if (index >= items.length) index = 0;
+
+    setActive(palette, items[index]);
+  }
+
+  function setLoading(palette, loading) {
+    palette
+      .querySelector("[data-pui-command-spinner]")
+      ?.setAttribute("data-pui-command-loading", loading ? "true" : "false");
+    updateEmpty(palette);
+  }
+
+  function remoteSearch(palette, query) {
+    const url = palette.getAttribute("data-pui-command-search-url");
+    const remote = palette.querySelector("[data-pui-command-remote]");
+    if (!url || !remote) return;
+
+    clearTimeout(searchTimers.get(palette));
+    searchControllers.get(palette)?.abort();
+
+    if (!query.trim()) {
+      remote.innerHTML = "";
+      setLoading(palette, false);
+      return;
+    }
+
+    const delay =
+      parseInt(palette.getAttribute("data-pui-command-debounce")) || 200;
+
+    searchTimers.set(
+      palette,
+      setTimeout(() => {
+        const controller = new AbortController();
+        searchControllers.set(palette, controller);
+        setLoading(palette, true);
+
+        const target = new URL(url, window.location.href);
+        target.searchParams.set("q", query.trim());
+
+        fetch(target, {
+          signal: controller.signal,
+          headers: { Accept: "text/html" },
+        })
+          .then((res) => (res.ok ? res.text() : ""))
+          .then((markup) => {
+            remote.innerHTML = markup;
+            setLoading(palette, false);
+
+            if (!palette.querySelector('[data-pui-command-active="true"]')) {
+              setActive(palette, firstVisible(palette));
+            }
+          })
+          .catch((err) => {
+            if (err.name !== "AbortError") setLoading(palette, false);
+          });
+      }, delay),
+    );
+  }
+
+  function reset(palette) {
+    const input = palette.querySelector("[data-pui-command-input]");
+    if (input) input.value = "";
+
+    const remote = palette.querySelector("[data-pui-command-remote]");
+    if (remote) remote.innerHTML = "";
+
+    filter(palette, "");
+  }
+
+  function choose(item) {
+    if (!item || item.getAttribute("data-pui-command-disabled") === "true") {
+      return;
+    }
+
+    const palette = item.closest("[data-pui-command]");
+    const detail = { value: item.getAttribute("data-pui-command-value") };
+    item.dispatchEvent(
+      new CustomEvent("pui-command:select", { bubbles: true, detail }),
+    );
+
+    if (palette) closePalette(paletteId(palette));
+
+    const href = item.getAttribute("data-pui-command-href");
+    if (href) window.location.href = href;
+  }
+
+  // Palette and item hotkeys are read from the page on each key press, so palettes
+  // rendered after load, or swapped in by a partial update, respond as well
+  function pageHotkey(e, typing) {
+    for (const palette of document.querySelectorAll("[data-pui-command]")) {
+      const targets = [
+        palette,
+        ...palette.querySelectorAll("[data-pui-command-item][data-pui-command-hotkey]"),
+      ];
+
+      for (const el of targets) {
+        const hotkey = el.getAttribute("data-pui-command-hotkey");
+        if (!hotkey) continue;
+
+        const combo = parseHotkey(hotkey);
+        if (typing && isPlain(combo)) continue;
+        if (matchesHotkey(e, combo)) return el;
+      }
+    }
+
+    return null;
+  }
+
+  document.addEventListener("input", (e) => {
+    const input = e.target.closest("[data-pui-command-input]");
+    if (!input) return;
+
+    const palette = input.closest("[data-pui-command]");
+    filter(palette, input.value);
+    remoteSearch(palette, input.value);
+  });
+
+  document.addEventListener("keydown", (e) => {
+    const input = e.target.closest?.("[data-pui-command-input]");
+    if (input) {
+      const palette = input.closest("[data-pui-command]");
+
+      if (e.key === "ArrowDown" || e.key === "ArrowUp") {
+        e.preventDefault();
+        move(palette, e.key === "ArrowDown" ? 1 : -1);
+        return;
+      }
+
+      if (e.key === "Enter") {
+        e.preventDefault();
+        palette
+          .querySelector('[data-pui-command-active="true"]')
+          ?.click();
+        return;
+      }
+    }
+
+    // Plain keys typed into a field are text, not hotkeys
+    const typing = isEditable(e.target);
+
+    for (const entry of hotkeys) {
+      if (typing && isPlain(entry.combo)) continue;
+
+      if (matchesHotkey(e, entry.combo)) {
+        e.preventDefault();
+        entry.handler(e);
+        return;
+      }
+    }
+
+    const target = pageHotkey(e, typing);
+    if (!target) return;
+
+    e.preventDefault();
+    target.hasAttribute("data-pui-command")
+      ? togglePalette(paletteId(target))
+      : choose(target);
+  });
+
+  document.addEventListener("click", (e) => {
+    const item = e.target.closest("[data-pui-command-item]");
+    if (item && item.closest("[data-pui-command]")) {
+      choose(item);
+    }
+  });
+
+  document.addEventListener("mousemove", (e) => {
+    const item = e.target.closest?.("[data-pui-command-item]");
+    if (!item || item.getAttribute("data-pui-command-active") === "true") {
+      return;
+    }
+
+    const palette = item.closest("[data-pui-command]");
+    if (palette && !item.hidden) setActive(palette, item);
+  });
+
+  // Dialog triggers open the palette through dialog.js, reset it here
+  document.addEventListener("click", (e) => {
+    const trigger = e.target.closest("[data-pui-dialog-trigger]");
+    if (!trigger) return;
+
+    const palette = getPalette(trigger.getAttribute("data-dialog-instance"));
+    if (palette) {
+      reset(palette);
+      setTimeout(
+        () => palette.querySelector("[data-pui-command-input]")?.focus(),
+        60,
+      );
+    }
+  });
+
+  // Palettes are reset whenever they open; this only settles those shown at load
+  function init() {
+    document.querySelectorAll("[data-pui-command]").forEach((palette) => filter(palette, ""));
+  }
+
+  if (document.readyState === "loading") {
+    document.addEventListener("DOMContentLoaded", init);
+  } else {
+    init();
+  }
+
+  // Expose public API
+  window.tui = window.tui || {};
+  window.tui.command = {
+    open: openPalette,
+    close: closePalette,
+    toggle: togglePalette,
+    registerHotkey: registerHotkey,
+  };
+})();
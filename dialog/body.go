@@ -0,0 +1,102 @@
+package dialog
+
+import (
+	"github.com/plainkit/html"
+	"github.com/plainkit/ui/skeleton"
+)
+
+type BodyProps struct {
+	ID        string
+	Class     string
+	Attrs     []html.Global
+	URL       string // Loads the body from URL every time the dialog opens; TriggerProps.URL takes precedence
+	ErrorText string // Shown when loading fails (default "Could not load this content.")
+}
+
+func bodyDivArgsFromProps(baseClass string, extra ...string) func(p BodyProps) []html.DivArg {
+	return func(p BodyProps) []html.DivArg {
+		args := []html.DivArg{
+			html.AClass(html.ClassMerge(append([]string{baseClass}, append(extra, p.Class)...)...)),
+			html.AData("pui-dialog-body", ""),
+			html.AData("pui-dialog-error", orDefault(p.ErrorText, "Could not load this content.")),
+		}
+
+		// dialog.js marks the body with its dialog instance, which RespondReplace targets
+		if p.ID != "" {
+			args = append(args, html.AId(p.ID))
+		}
+
+		if p.URL != "" {
+			args = append(args, html.AData("pui-dialog-url", p.URL))
+		}
+
+		for _, a := range p.Attrs {
+			args = append(args, a)
+		}
+
+		return args
+	}
+}
+
+func (p BodyProps) ApplyDiv(attrs *html.DivAttrs, children *[]html.Component) {
+	for _, a := range bodyDivArgsFromProps("grid gap-6")(p) {
+		a.ApplyDiv(attrs, children)
+	}
+}
+
+// Body creates the region of a dialog that is loaded from a URL or replaced by the server.
+// Without children it shows Placeholder until the content arrives; dialog.js restores
+// whatever it initially held before every load.
+func Body(args ...html.DivArg) html.Node {
+	var (
+		props BodyProps
+		rest  []html.DivArg
+	)
+
+	for _, a := range args {
+		if v, ok := a.(BodyProps); ok {
+			props = v
+		} else {
+			rest = append(rest, a)
+		}
+	}
+
+	if len(rest) == 0 {
+		rest = append(rest, Placeholder())
+	}
+
+	rest = append(rest, runtime())
+
+	return html.Div(append([]html.DivArg{props}, rest...)...).WithAssets("", dialogJS, "ui-dialog")
+}
+
+// Placeholder renders a skeleton shaped like a dialog header, a short form and a footer.
+func Placeholder() html.Node {
+	return html.Div(
+		html.AClass("grid gap-6"),
+		html.AAria("hidden", "true"),
+		html.Div(
+			html.AClass("space-y-3"),
+			skeleton.Skeleton(skeleton.Props{Class: "h-6 w-1/2"}),
+			skeleton.Skeleton(skeleton.Props{Class: "h-4 w-3/4"}),
+		),
+		html.Div(
+			html.AClass("space-y-4"),
+			skeleton.Skeleton(skeleton.Props{Class: "h-9 w-full"}),
+			skeleton.Skeleton(skeleton.Props{Class: "h-9 w-full"}),
+		),
+		html.Div(
+			html.AClass("flex justify-end gap-2"),
+			skeleton.Skeleton(skeleton.Props{Class: "h-9 w-20"}),
+			skeleton.Skeleton(skeleton.Props{Class: "h-9 w-24"}),
+		),
+	)
+}
+
+func orDefault(value, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+
+	return value
+}
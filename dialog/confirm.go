@@ -0,0 +1,155 @@
+package dialog
+
+import (
+	"net/http"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/plainkit/html"
+	"github.com/plainkit/ui/button"
+	"github.com/plainkit/ui/input"
+	"github.com/plainkit/ui/internal/randomid"
+	"github.com/plainkit/ui/label"
+)
+
+// PhraseField is the form field carrying the phrase typed into a Confirm with Phrase set.
+const PhraseField = "confirm_phrase"
+
+// MethodField carries the method of a Confirm whose Method a plain form cannot send,
+// such as DELETE, which is then submitted as POST.
+const MethodField = "_method"
+
+// ConfirmProps configures a confirmation dialog guarding a destructive action.
+type ConfirmProps struct {
+	ID             string // Instance ID of the dialog
+	Class          string // Classes for the dialog panel
+	Title          string
+	Message        string
+	ConfirmLabel   string         // Default "Continue"
+	ConfirmVariant button.Variant // Default button.VariantDestructive
+	CancelLabel    string         // Default "Cancel"
+	Action         string         // URL the confirmation is submitted to
+	Method         string         // HTTP method (default POST)
+	Fields         map[string]string
+	HTMX           bool          // Sends the request with hx-<method> instead of navigating
+	FormAttrs      []html.Global // Added to the form, such as hx-target or hx-swap
+	Phrase         string        // Must be typed before confirming, such as the name of what is deleted
+}
+
+// Confirm renders a trigger button styled by buttonProps and the alert dialog it opens.
+// Confirming submits a form to Action with Fields as hidden inputs; with Phrase set, the
+// confirm button stays disabled until it is typed, and handlers can check it with
+// Confirmed.
+func Confirm(props ConfirmProps, buttonProps button.Props, args ...html.ButtonArg) html.Node {
+	instanceID := props.ID
+	if instanceID == "" {
+		instanceID = randomid.New("confirm")
+	}
+
+	header := []html.DivArg{HeaderProps{}, Title(html.Text(props.Title))}
+	if props.Message != "" {
+		header = append(header, Description(html.Text(props.Message)))
+	}
+
+	return html.Div(
+		html.AClass("contents"),
+		Trigger(TriggerProps{For: instanceID}, buttonProps, args...),
+		Content(
+			ContentProps{ID: instanceID, Class: html.ClassMerge("max-w-md", props.Class), Alert: true, HideCloseButton: true},
+			Header(header...),
+			confirmForm(instanceID, props),
+		),
+	)
+}
+
+func confirmForm(instanceID string, props ConfirmProps) html.Node {
+	method := strings.ToUpper(orDefault(props.Method, http.MethodPost))
+
+	formArgs := []html.FormArg{
+		html.AClass("grid gap-6"),
+		html.AData("pui-dialog-confirm-form", ""),
+	}
+
+	switch {
+	case props.HTMX:
+		formArgs = append(formArgs, html.ACustom("hx-"+strings.ToLower(method), props.Action))
+	case method == http.MethodGet || method == http.MethodPost:
+		formArgs = append(formArgs, html.AAction(props.Action), html.AMethod(method))
+	default:
+		formArgs = append(formArgs,
+			html.AAction(props.Action),
+			html.AMethod(http.MethodPost),
+			html.Input(html.AType("hidden"), html.AName(MethodField), html.AValue(method)),
+		)
+	}
+
+	for _, a := range props.FormAttrs {
+		formArgs = append(formArgs, a)
+	}
+
+	// Sorted so the markup is stable between renders
+	names := make([]string, 0, len(props.Fields))
+	for name := range props.Fields {
+		names = append(names, name)
+	}
+
+	slices.Sort(names)
+
+	for _, name := range names {
+		formArgs = append(formArgs, html.Input(html.AType("hidden"), html.AName(name), html.AValue(props.Fields[name])))
+	}
+
+	confirmArgs := []html.ButtonArg{
+		button.Props{
+			Variant:  orVariant(props.ConfirmVariant, button.VariantDestructive),
+			Type:     button.TypeSubmit,
+			Disabled: props.Phrase != "", // Enabled by dialog.js once the phrase is typed
+			Attrs:    []html.Global{html.AData("pui-dialog-confirm", "")},
+		},
+		html.Text(orDefault(props.ConfirmLabel, "Continue")),
+	}
+
+	if props.Phrase != "" {
+		phraseID := instanceID + "-phrase"
+		formArgs = append(formArgs, html.Div(
+			html.AClass("grid gap-2"),
+			label.Label(
+				label.Props{For: phraseID},
+				html.Text("Type "),
+				html.Strong(html.AClass("font-semibold"), html.Text(props.Phrase)),
+				html.Text(" to confirm"),
+			),
+			input.Input(
+				input.Props{ID: phraseID, Name: PhraseField, Required: true},
+				html.APattern(regexp.QuoteMeta(props.Phrase)),
+				html.AAutocomplete("off"),
+				html.AData("pui-dialog-phrase", props.Phrase),
+			),
+		))
+	}
+
+	// The least destructive choice takes focus first
+	formArgs = append(formArgs, Footer(
+		FooterProps{},
+		Close(CloseProps{For: instanceID},
+			button.Button(button.Props{Variant: button.VariantOutline, Attrs: []html.Global{html.AAutofocus()}},
+				html.Text(orDefault(props.CancelLabel, "Cancel")))),
+		button.Button(confirmArgs...),
+	))
+
+	return html.Form(formArgs...)
+}
+
+// Confirmed reports whether a request submitted by a Confirm carries its phrase.
+func Confirmed(r *http.Request, phrase string) bool {
+	return r.FormValue(PhraseField) == phrase
+}
+
+func orVariant(value, fallback button.Variant) button.Variant {
+	if value == "" {
+		return fallback
+	}
+
+	return value
+}
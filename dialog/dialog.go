@@ -1,14 +1,16 @@
 package dialog
 
 import (
-	"crypto/rand"
 	_ "embed"
-	"encoding/hex"
+	"strconv"
 
 	"github.com/plainkit/html"
 	"github.com/plainkit/icons/lucide"
 	"github.com/plainkit/ui/button"
+	"github.com/plainkit/ui/internal/randomid"
 	"github.com/plainkit/ui/internal/styles"
+	"github.com/plainkit/ui/layer"
+	"github.com/plainkit/ui/urlstate"
 )
 
 type Props struct {
@@ -26,6 +28,8 @@ type TriggerProps struct {
 	Attrs    []html.Global
 	Disabled bool
 	For      string // Reference to a specific dialog ID (for external triggers)
+	Native   bool   // Links to a Native content panel so it opens through :target without JavaScript
+	URL      string // Loads the Body of the dialog from URL when this trigger opens it
 }
 
 type ContentProps struct {
@@ -33,14 +37,24 @@ type ContentProps struct {
 	Class           string
 	Attrs           []html.Global
 	HideCloseButton bool
-	Open            bool // Initial open state for standalone usage
+	Open            bool   // Initial open state for standalone usage
+	Label           string // Accessible name for dialogs without a Title
+	Alert           bool   // Renders role="alertdialog", which also ignores clicks outside, for confirmations
+	// Sync mirrors the open state into the URL as Param=ID; render Open with Sync.Is(r, ID)
+	// so links to the dialog work without JavaScript too
+	Sync urlstate.Sync
+	// Native renders a <dialog> element opened with showModal(), which puts it in the top
+	// layer above any popover and makes the page inert natively. Without JavaScript it
+	// still opens from a Native trigger through :target.
+	Native bool
 }
 
 type CloseProps struct {
-	ID    string
-	Class string
-	Attrs []html.Global
-	For   string // ID of the dialog to close (optional, defaults to closest dialog)
+	ID     string
+	Class  string
+	Attrs  []html.Global
+	For    string // ID of the dialog to close (optional, defaults to closest dialog)
+	Native bool   // Wraps the children in a method="dialog" form; give buttons inside Type: button.TypeSubmit
 }
 
 type HeaderProps struct {
@@ -71,7 +85,7 @@ func dialogDivArgsFromProps(baseClass string, extra ...string) func(p Props) []h
 	return func(p Props) []html.DivArg {
 		instanceID := p.ID
 		if instanceID == "" {
-			instanceID = randomID("dialog")
+			instanceID = randomid.New("dialog")
 		}
 
 		args := []html.DivArg{
@@ -121,6 +135,8 @@ func Dialog(args ...html.DivArg) html.Node {
 		}
 	}
 
+	rest = append(rest, runtime())
+
 	return html.Div(append([]html.DivArg{props}, rest...)...).WithAssets("", dialogJS, "ui-dialog")
 }
 
@@ -141,7 +157,16 @@ func Trigger(triggerProps TriggerProps, buttonProps button.Props, args ...html.B
 		html.AData("pui-dialog-trigger", instanceID),
 		html.AData("dialog-instance", instanceID),
 		html.AData("pui-dialog-trigger-open", "false"),
+		html.AAria("haspopup", "dialog"),
+		html.AAria("expanded", "false"),
 	)
+	// Without an instance there is no panel to point at
+	if instanceID != "" {
+		attrs = append(attrs, html.AAria("controls", ContentID(instanceID)))
+	}
+	if triggerProps.URL != "" {
+		attrs = append(attrs, html.AData("pui-dialog-url", triggerProps.URL))
+	}
 	attrs = append(attrs, triggerProps.Attrs...)
 
 	if triggerProps.Disabled {
@@ -152,11 +177,19 @@ func Trigger(triggerProps TriggerProps, buttonProps button.Props, args ...html.B
 		buttonProps.Class = html.ClassMerge(buttonProps.Class, triggerProps.Class)
 	}
 
+	// Render a link to the panel: dialog.js opens it modally, :target shows it otherwise
+	if triggerProps.Native && !buttonProps.Disabled && instanceID != "" {
+		buttonProps.Href = "#" + ContentID(instanceID)
+		attrs = append(attrs, html.ACustom("role", "button"))
+	}
+
 	buttonProps.Attrs = attrs
 	if triggerProps.ID != "" {
 		buttonProps.ID = triggerProps.ID
 	}
 
+	args = append(args, runtime())
+
 	return button.Button(append([]html.ButtonArg{buttonProps}, args...)...).WithAssets("", dialogJS, "ui-dialog")
 }
 
@@ -164,22 +197,16 @@ func Trigger(triggerProps TriggerProps, buttonProps button.Props, args ...html.B
 func Content(props ContentProps, args ...html.DivArg) html.Node {
 	instanceID := props.ID
 	if instanceID == "" {
-		instanceID = randomID("dialog-content")
+		instanceID = randomid.New("dialog-content")
 	}
 
-	// Overlay/backdrop
-	overlayClasses := html.ClassMerge(
-		"fixed inset-0 z-40 bg-black/50",
-		"transition-opacity duration-300",
-		"data-[pui-dialog-open=false]:opacity-0",
-		"data-[pui-dialog-open=true]:opacity-100",
-		"data-[pui-dialog-open=false]:pointer-events-none",
-		"data-[pui-dialog-open=true]:pointer-events-auto",
-		"data-[pui-dialog-hidden=true]:!hidden",
-	)
+	if props.Native {
+		return nativeContent(instanceID, props, args...)
+	}
 
+	// Overlay/backdrop
 	overlayArgs := []html.DivArg{
-		html.AClass(overlayClasses),
+		html.AClass(OverlayClass),
 		html.AData("pui-dialog-backdrop", ""),
 		html.AData("dialog-instance", instanceID),
 	}
@@ -200,17 +227,29 @@ func Content(props ContentProps, args ...html.DivArg) html.Node {
 		"transition-all duration-200",
 		"data-[pui-dialog-open=false]:scale-95 data-[pui-dialog-open=false]:opacity-0",
 		"data-[pui-dialog-open=true]:scale-100 data-[pui-dialog-open=true]:opacity-100",
-		"data-[pui-dialog-open=false]:pointer-events-none data-[pui-dialog-open=true]:pointer-events-auto",
-		"data-[pui-dialog-hidden=true]:!hidden",
+		PanelStateClass,
 		props.Class,
 	)
 
 	contentArgs := []html.DivArg{
+		html.AId(ContentID(instanceID)),
 		html.AClass(contentClasses),
+		html.ACustom("role", role(props.Alert)),
+		html.AAria("modal", "true"),
+		html.ATabindex(-1), // Focused when the dialog has nothing focusable
 		html.AData("pui-dialog-content", ""),
 		html.AData("dialog-instance", instanceID),
 	}
 
+	// dialog.js links the Title and Description inside to aria-labelledby and aria-describedby
+	if props.Label != "" {
+		contentArgs = append(contentArgs, html.AAria("label", props.Label))
+	}
+
+	if props.Alert {
+		contentArgs = append(contentArgs, html.AData("pui-dialog-disable-click-away", "true"))
+	}
+
 	if props.Open {
 		contentArgs = append(contentArgs, html.AData("pui-dialog-open", "true"))
 	} else {
@@ -220,6 +259,10 @@ func Content(props ContentProps, args ...html.DivArg) html.Node {
 		)
 	}
 
+	for _, attr := range props.Sync.Attrs() {
+		contentArgs = append(contentArgs, attr)
+	}
+
 	for _, attr := range props.Attrs {
 		contentArgs = append(contentArgs, attr)
 	}
@@ -229,18 +272,7 @@ func Content(props ContentProps, args ...html.DivArg) html.Node {
 	// Add close button if not hidden
 	if !props.HideCloseButton {
 		closeButton := html.Button(
-			html.AClass(html.ClassMerge(
-				"absolute right-4 top-4",
-				styles.InteractiveGhost(
-					"size-8 rounded-full",
-					"bg-muted/40 text-muted-foreground/80",
-					"hover:text-foreground",
-				),
-				"transition-opacity hover:opacity-100",
-				"data-[pui-dialog-open=false]:opacity-0",
-				"data-[pui-dialog-open=true]:opacity-80",
-				"[&_svg]:pointer-events-none [&_svg]:shrink-0 [&_svg:not([class*='size-'])]:size-4",
-			)),
+			html.AClass(CloseButtonClass),
 			html.AData("pui-dialog-close", instanceID),
 			html.AAria("label", "Close"),
 			html.AType("button"),
@@ -250,10 +282,137 @@ func Content(props ContentProps, args ...html.DivArg) html.Node {
 		contentArgs = append(contentArgs, closeButton)
 	}
 
-	return html.Div(
+	wrapperArgs := []html.DivArg{
 		html.Div(overlayArgs...),
 		html.Div(contentArgs...),
-	).WithAssets("", dialogJS, "ui-dialog")
+		runtime(),
+	}
+
+	return html.Div(wrapperArgs...).WithAssets("", dialogJS, "ui-dialog")
+}
+
+// Classes shared with panels built on dialog.js, such as sheet, so they look and hide
+// the same way.
+var (
+	// OverlayClass styles the backdrop, fading it with the open state.
+	OverlayClass = html.ClassMerge(
+		"fixed inset-0 z-40 bg-black/50",
+		"transition-opacity duration-300",
+		"data-[pui-dialog-open=false]:opacity-0",
+		"data-[pui-dialog-open=true]:opacity-100",
+		"data-[pui-dialog-open=false]:pointer-events-none",
+		"data-[pui-dialog-open=true]:pointer-events-auto",
+		"data-[pui-dialog-hidden=true]:!hidden",
+	)
+
+	// PanelStateClass makes a closed panel inert and removes it once hidden.
+	PanelStateClass = html.ClassMerge(
+		"data-[pui-dialog-open=false]:pointer-events-none data-[pui-dialog-open=true]:pointer-events-auto",
+		"data-[pui-dialog-hidden=true]:!hidden",
+	)
+
+	// CloseButtonClass styles the close button in the corner of a panel.
+	CloseButtonClass = html.ClassMerge(
+		"absolute right-4 top-4",
+		styles.InteractiveGhost(
+			"size-8 rounded-full",
+			"bg-muted/40 text-muted-foreground/80",
+			"hover:text-foreground",
+		),
+		"transition-opacity hover:opacity-100",
+		"data-[pui-dialog-open=false]:opacity-0",
+		"data-[pui-dialog-open=true]:opacity-80",
+		"[&_svg]:pointer-events-none [&_svg]:shrink-0 [&_svg:not([class*='size-'])]:size-4",
+	)
+)
+
+// nativeContent renders the panel as a <dialog> element. Its ::backdrop replaces the
+// overlay div, and the UA hides it until showModal() or :target shows it.
+func nativeContent(instanceID string, props ContentProps, args ...html.DivArg) html.Node {
+	contentClasses := html.ClassMerge(
+		"fixed inset-0 z-50 m-auto h-fit max-h-[calc(100dvh-2rem)] w-full max-w-[min(90vw,620px)] overflow-y-auto",
+		styles.Panel("hidden gap-6 p-8 open:grid target:grid"),
+		"backdrop:bg-black/50 backdrop:transition-opacity backdrop:duration-300",
+		"data-[pui-dialog-open=false]:backdrop:opacity-0",
+		"transition-all duration-200",
+		"data-[pui-dialog-open=false]:scale-95 data-[pui-dialog-open=false]:opacity-0",
+		"data-[pui-dialog-open=true]:scale-100 data-[pui-dialog-open=true]:opacity-100",
+		// Without JavaScript :target shows the panel outside the top layer, dimmed by a shadow
+		"target:scale-100 target:opacity-100 target:shadow-[0_0_0_100vmax_rgb(0_0_0/0.5)]",
+		props.Class,
+	)
+
+	dialogArgs := []html.DialogArg{
+		html.AId(ContentID(instanceID)),
+		html.AClass(contentClasses),
+		html.AAria("modal", "true"),
+		html.AData("pui-dialog-content", ""),
+		html.AData("pui-dialog-native", ""),
+		html.AData("dialog-instance", instanceID),
+		html.AData("pui-dialog-open", strconv.FormatBool(props.Open)),
+	}
+
+	// The open attribute shows the panel without JavaScript; dialog.js reopens it modally
+	if props.Open {
+		dialogArgs = append(dialogArgs, html.AOpen())
+	}
+
+	if props.Label != "" {
+		dialogArgs = append(dialogArgs, html.AAria("label", props.Label))
+	}
+
+	if props.Alert {
+		dialogArgs = append(dialogArgs,
+			html.ACustom("role", "alertdialog"),
+			html.AData("pui-dialog-disable-click-away", "true"),
+		)
+	}
+
+	for _, attr := range props.Sync.Attrs() {
+		dialogArgs = append(dialogArgs, attr)
+	}
+
+	for _, attr := range props.Attrs {
+		dialogArgs = append(dialogArgs, attr)
+	}
+
+	dialogArgs = append(dialogArgs, runtime())
+
+	// Children and global attributes carry over; div-only options have no <dialog> equivalent
+	for _, a := range args {
+		if d, ok := a.(html.DialogArg); ok {
+			dialogArgs = append(dialogArgs, d)
+		}
+	}
+
+	// A link rather than a button, so it also clears :target without JavaScript
+	if !props.HideCloseButton {
+		dialogArgs = append(dialogArgs, html.A(
+			html.AHref("#_"),
+			html.AClass(CloseButtonClass),
+			html.ACustom("role", "button"),
+			html.AData("pui-dialog-close", instanceID),
+			html.AAria("label", "Close"),
+			lucide.X(),
+			html.Span(html.AClass("sr-only"), html.Text("Close")),
+		))
+	}
+
+	return html.Dialog(dialogArgs...).WithAssets("", dialogJS, "ui-dialog")
+}
+
+func role(alert bool) string {
+	if alert {
+		return "alertdialog"
+	}
+
+	return "dialog"
+}
+
+// ContentID returns the id of the panel of the dialog with the given instance ID, which
+// triggers reference through aria-controls.
+func ContentID(instanceID string) string {
+	return instanceID + "-content"
 }
 
 func closeSpanArgsFromProps(baseClass string, extra ...string) func(p CloseProps) []html.SpanArg {
@@ -301,9 +460,38 @@ func Close(args ...html.SpanArg) html.Node {
 		}
 	}
 
+	if props.Native {
+		return nativeClose(props, rest...)
+	}
+
 	return html.Span(append([]html.SpanArg{props}, rest...)...)
 }
 
+// nativeClose closes the enclosing <dialog> by submitting a method="dialog" form, which
+// needs no JavaScript once the dialog is open.
+func nativeClose(props CloseProps, args ...html.SpanArg) html.Node {
+	formArgs := []html.FormArg{
+		html.AMethod("dialog"),
+		html.AClass(html.ClassMerge("contents", props.Class)),
+	}
+
+	if props.ID != "" {
+		formArgs = append(formArgs, html.AId(props.ID))
+	}
+
+	for _, a := range props.Attrs {
+		formArgs = append(formArgs, a)
+	}
+
+	for _, a := range args {
+		if f, ok := a.(html.FormArg); ok {
+			formArgs = append(formArgs, f)
+		}
+	}
+
+	return html.Form(formArgs...)
+}
+
 func headerDivArgsFromProps(baseClass string, extra ...string) func(p HeaderProps) []html.DivArg {
 	return func(p HeaderProps) []html.DivArg {
 		args := []html.DivArg{html.AClass(html.ClassMerge(append([]string{baseClass}, append(extra, p.Class)...)...))}
@@ -398,7 +586,8 @@ func titleH2ArgsFromProps(baseClass string, extra ...string) func(p TitleProps)
 }
 
 func (p TitleProps) ApplyH2(attrs *html.H2Attrs, children *[]html.Component) {
-	for _, a := range titleH2ArgsFromProps(styles.DisplayHeading("text-pretty text-balance"))(p) {
+	args := append(titleH2ArgsFromProps(styles.DisplayHeading("text-pretty text-balance"))(p), html.AData("pui-dialog-title", ""))
+	for _, a := range args {
 		a.ApplyH2(attrs, children)
 	}
 }
@@ -437,7 +626,8 @@ func descriptionPArgsFromProps(baseClass string, extra ...string) func(p Descrip
 }
 
 func (p DescriptionProps) ApplyP(attrs *html.PAttrs, children *[]html.Component) {
-	for _, a := range descriptionPArgsFromProps(styles.SubtleText("leading-relaxed"))(p) {
+	args := append(descriptionPArgsFromProps(styles.SubtleText("leading-relaxed"))(p), html.AData("pui-dialog-description", ""))
+	for _, a := range args {
 		a.ApplyP(attrs, children)
 	}
 }
@@ -460,13 +650,15 @@ func Description(args ...html.PArg) html.Node {
 	return html.P(append([]html.PArg{props}, rest...)...)
 }
 
-func randomID(prefix string) string {
-	buf := make([]byte, 4)
-	if _, err := rand.Read(buf); err != nil {
-		return prefix + "-id"
-	}
+// Assets returns a component that loads dialog.js without rendering output, for panels
+// built on the dialog data attributes, such as sheet.
+func Assets() html.Component {
+	return html.AssetHook("ui-dialog", "", dialogJS)
+}
 
-	return prefix + "-" + hex.EncodeToString(buf)
+// runtime loads the scripts dialog.js builds on, for every node carrying dialog.js.
+func runtime() html.ChildOpt {
+	return html.Fragment(urlstate.Assets(), layer.Assets())
 }
 
 //go:embed dialog.js
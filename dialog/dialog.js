@@ -1,21 +1,394 @@
 (function () {
   "use strict";
 
-  let openDialogs = new Set();
+  const FOCUSABLE = [
+    "a[href]",
+    "area[href]",
+    "button:not([disabled])",
+    'input:not([disabled]):not([type="hidden"])',
+    "select:not([disabled])",
+    "textarea:not([disabled])",
+    "iframe",
+    "audio[controls]",
+    "video[controls]",
+    '[contenteditable]:not([contenteditable="false"])',
+    '[tabindex]:not([tabindex="-1"])',
+  ].join(",");
+
+  // Open dialogs, topmost last. Each entry remembers what to undo on close.
+  const stack = [];
+
+  let scrollLock = null;
+
+  // Bodies keep their initial markup, restored as the placeholder before every load
+  const placeholders = new WeakMap();
+
+  // Pending body loads by dialog ID
+  const loads = new Map();
+
+  // Set while applying the URL, so the changes are not written back to it
+  let fromUrl = false;
+
+  // Panels to remove from the URL once a history step taken on close has landed
+  let pendingClear = [];
+
+  // find returns the wrapper, backdrop or content part of a dialog
+  function find(part, dialogId) {
+    const attr = part ? "data-pui-dialog-" + part : "data-pui-dialog";
+    return document.querySelector(
+      "[" + attr + '][data-dialog-instance="' + CSS.escape(dialogId) + '"]',
+    );
+  }
+
+  // Native panels are <dialog> elements shown in the top layer with showModal()
+  function isNative(content) {
+    return content.tagName === "DIALOG";
+  }
+
+  function entryFor(dialogId) {
+    return stack.find((entry) => entry.id === dialogId) || null;
+  }
 
-  // Open dialog
-  function openDialog(dialogId) {
-    // Find backdrop and content by instance ID
-    const backdrop = document.querySelector(
-      '[data-pui-dialog-backdrop][data-dialog-instance="' + dialogId + '"]',
+  function topmost() {
+    return stack[stack.length - 1] || null;
+  }
+
+  // The shared layer manager from layer.js, which routes ESC and outside clicks
+  function layers() {
+    return window.tui && window.tui.layers;
+  }
+
+  function bodyOf(content) {
+    return (
+      Array.from(content.querySelectorAll("[data-pui-dialog-body]")).find(
+        (el) => el.closest("[data-pui-dialog-content]") === content,
+      ) || null
     );
-    const content = document.querySelector(
-      '[data-pui-dialog-content][data-dialog-instance="' + dialogId + '"]',
+  }
+
+  // Title and Description become the accessible name and description of the panel,
+  // unless the author already set them
+  function link(content) {
+    const dialogId = content.getAttribute("data-dialog-instance");
+    const own = (el) => el.closest("[data-pui-dialog-content]") === content;
+
+    const title = Array.from(content.querySelectorAll("[data-pui-dialog-title]")).find(own);
+    if (title && !content.hasAttribute("aria-labelledby") && !content.hasAttribute("aria-label")) {
+      if (!title.id) title.id = dialogId + "-title";
+      content.setAttribute("aria-labelledby", title.id);
+    }
+
+    const description = Array.from(content.querySelectorAll("[data-pui-dialog-description]")).find(own);
+    if (description && !content.hasAttribute("aria-describedby")) {
+      if (!description.id) description.id = dialogId + "-description";
+      content.setAttribute("aria-describedby", description.id);
+    }
+
+    // Mirrors bodySelector in response.go, so the server can retarget responses at it
+    const body = bodyOf(content);
+    if (body) {
+      body.setAttribute("data-dialog-instance", dialogId);
+      if (!placeholders.has(body)) placeholders.set(body, body.innerHTML);
+    }
+  }
+
+  // Dispatch the events of an HX-Trigger style header: a JSON object of event names to
+  // details, or a comma separated list of names
+  function dispatchEvents(header, el) {
+    if (!header) return;
+
+    let events;
+    try {
+      events = JSON.parse(header);
+    } catch {
+      events = {};
+      header.split(",").forEach((name) => {
+        if (name.trim()) events[name.trim()] = null;
+      });
+    }
+
+    const target = el && el.isConnected ? el : document.body;
+    Object.entries(events).forEach(([name, detail]) => {
+      target.dispatchEvent(
+        new CustomEvent(name, {
+          bubbles: true,
+          detail: detail && typeof detail === "object" ? detail : { value: detail },
+        }),
+      );
+    });
+  }
+
+  // Put markup into a body (or the element a response retargeted) and wire it up
+  function swap(target, markup) {
+    target.innerHTML = markup;
+    target.removeAttribute("aria-busy");
+
+    const content = target.closest("[data-pui-dialog-content]");
+    if (!content) return;
+
+    link(content);
+    if (window.htmx) window.htmx.process(target);
+
+    // Focus sat on the close button or the panel while the placeholder showed
+    const active = document.activeElement;
+    const entry = entryFor(content.getAttribute("data-dialog-instance"));
+    if (entry && (!content.contains(active) || active === content || active.hasAttribute("data-pui-dialog-close"))) {
+      focusInitial(content);
+    }
+
+    content.dispatchEvent(
+      new CustomEvent("pui-dialog:load", {
+        bubbles: true,
+        detail: { id: content.getAttribute("data-dialog-instance") },
+      }),
     );
+  }
+
+  // Apply a response the way HTMX would: HX-Trigger events, the swap into the body or
+  // the HX-Retarget element, then HX-Trigger-After-Swap events. Only innerHTML swaps
+  // are supported.
+  function apply(res, body) {
+    return res.text().then((markup) => {
+      dispatchEvents(res.headers.get("HX-Trigger"), body);
+
+      const retarget = res.headers.get("HX-Retarget");
+      const target = retarget ? document.querySelector(retarget) : body;
+      if (target && res.status !== 204) swap(target, markup);
+      else body.removeAttribute("aria-busy");
+
+      dispatchEvents(res.headers.get("HX-Trigger-After-Swap"), target || body);
+    });
+  }
+
+  // Mirrors HeaderInstance in response.go
+  function request(dialogId, url, init) {
+    const headers = { Accept: "text/html" };
+    if (dialogId) headers["X-Dialog-Instance"] = dialogId;
+
+    return fetch(url, Object.assign({}, init, { headers: headers })).then((res) => {
+      if (!res.ok) throw new Error("HTTP " + res.status);
+      return res;
+    });
+  }
+
+  function showError(body) {
+    const message = document.createElement("p");
+    message.setAttribute("role", "alert");
+    message.className = "text-sm text-destructive";
+    message.textContent = body.getAttribute("data-pui-dialog-error") || "";
+
+    body.replaceChildren(message);
+    body.removeAttribute("aria-busy");
+  }
+
+  // Fetch the body of a dialog, showing its placeholder meanwhile
+  function loadBody(dialogId, url) {
+    const content = find("content", dialogId);
+    const body = content && bodyOf(content);
+    if (!body || !url) return;
+
+    loads.get(dialogId)?.abort();
+    const controller = new AbortController();
+    loads.set(dialogId, controller);
+
+    if (placeholders.has(body)) body.innerHTML = placeholders.get(body);
+    body.setAttribute("aria-busy", "true");
+
+    request(dialogId, url, { signal: controller.signal })
+      .then((res) => apply(res, body))
+      .catch((err) => {
+        if (err.name !== "AbortError") showError(body);
+      })
+      .finally(() => {
+        if (loads.get(dialogId) === controller) loads.delete(dialogId);
+      });
+  }
+
+  // The trigger URL takes precedence over the URL of the body itself
+  function loadOnOpen(dialogId, content, opener) {
+    const body = bodyOf(content);
+    if (!body) return;
+
+    const url =
+      (opener && opener.getAttribute && opener.getAttribute("data-pui-dialog-url")) ||
+      body.getAttribute("data-pui-dialog-url");
+    if (url) loadBody(dialogId, url);
+  }
+
+  // Lock page scrolling while any dialog is open; nested dialogs share the lock
+  function lockScroll() {
+    if (scrollLock) return;
+
+    const body = document.body;
+    const scrollbar = window.innerWidth - document.documentElement.clientWidth;
+    scrollLock = {
+      overflow: body.style.overflow,
+      paddingRight: body.style.paddingRight,
+    };
+
+    body.style.overflow = "hidden";
+    if (scrollbar > 0) {
+      const padding = parseFloat(getComputedStyle(body).paddingRight) || 0;
+      body.style.paddingRight = padding + scrollbar + "px";
+    }
+  }
+
+  function unlockScroll() {
+    if (!scrollLock || stack.length > 0) return;
+
+    document.body.style.overflow = scrollLock.overflow;
+    document.body.style.paddingRight = scrollLock.paddingRight;
+    scrollLock = null;
+  }
+
+  // Make everything outside the dialog inert, walking up from the panel and marking the
+  // siblings at each level. Only elements inert because of this dialog are restored.
+  function inertOutside(content, backdrop) {
+    const marked = [];
+
+    for (let node = content; node && node !== document.body; node = node.parentElement) {
+      const parent = node.parentElement;
+      if (!parent) break;
+
+      for (const sibling of parent.children) {
+        if (sibling === node || sibling === backdrop || sibling.inert) continue;
+        if (/^(SCRIPT|STYLE|TEMPLATE|LINK|META)$/.test(sibling.tagName)) continue;
+        // Popovers opened from the dialog are portaled here and must stay usable
+        if (sibling.hasAttribute("data-pui-popover-portal-container")) continue;
+
+        sibling.inert = true;
+        marked.push(sibling);
+      }
+    }
+
+    return marked;
+  }
+
+  function focusables(content) {
+    return Array.from(content.querySelectorAll(FOCUSABLE)).filter(
+      (el) => !el.closest("[inert]") && el.getClientRects().length > 0,
+    );
+  }
+
+  function focusInitial(content) {
+    const target =
+      content.querySelector("[autofocus]") ||
+      focusables(content).find((el) => !el.hasAttribute("data-pui-dialog-close")) ||
+      focusables(content)[0] ||
+      content;
+    target.focus({ preventScroll: true });
+  }
+
+  function setTriggers(dialogId, open) {
+    document
+      .querySelectorAll('[data-pui-dialog-trigger][data-dialog-instance="' + CSS.escape(dialogId) + '"]')
+      .forEach((trigger) => {
+        trigger.setAttribute("data-pui-dialog-trigger-open", String(open));
+        trigger.setAttribute("aria-expanded", String(open));
+      });
+  }
+
+  // A Confirm with a phrase keeps its confirm button disabled until the phrase is typed
+  function updateConfirm(form) {
+    const phrase = form.querySelector("[data-pui-dialog-phrase]");
+    const confirm = form.querySelector("[data-pui-dialog-confirm]");
+    if (!phrase || !confirm) return;
+
+    const matches = phrase.value === phrase.getAttribute("data-pui-dialog-phrase");
+    confirm.disabled = !matches;
+  }
+
+  // Confirmations start over every time they open
+  function resetConfirm(content) {
+    content.querySelectorAll("[data-pui-dialog-confirm-form]").forEach((form) => {
+      form.reset();
+      updateConfirm(form);
+    });
+  }
+
+  // URL sync through urlstate.js, for panels rendered with a Sync
+  function synced(content) {
+    return content.hasAttribute("data-pui-url-param") && window.tui && window.tui.url;
+  }
+
+  function syncOpen(entry) {
+    if (!synced(entry.content)) return;
+
+    if (fromUrl) {
+      const pushed = window.tui.url.pushed();
+      entry.pushed = !!pushed && pushed.value === entry.id;
+      return;
+    }
 
-    if (!backdrop || !content) return;
+    entry.pushed = window.tui.url.set(entry.content, entry.id);
+  }
+
+  // Closing steps back over the history entries its dialogs pushed, so Back afterwards
+  // leaves the page instead of reopening them. Everything else is removed in place.
+  function syncClose(closing) {
+    if (fromUrl) return;
+
+    const entries = closing.filter((entry) => synced(entry.content));
+    if (entries.length === 0) return;
+
+    const pushed = entries.filter((entry) => entry.pushed);
+    const current = window.tui.url.pushed();
+    if (pushed.length > 0 && current && current.value === pushed[0].id) {
+      pendingClear = entries.filter((entry) => !entry.pushed).map((entry) => entry.content);
+      history.go(-pushed.length);
+      return;
+    }
+
+    entries.forEach((entry) => window.tui.url.set(entry.content, ""));
+  }
+
+  // Track a dialog as open: lock scrolling, make the rest of the page inert and
+  // remember where focus was
+  function activate(dialogId, content, backdrop, opener) {
+    link(content);
+    resetConfirm(content);
+    lockScroll();
+
+    const entry = {
+      id: dialogId,
+      content: content,
+      returnFocus: opener || document.activeElement,
+      // showModal() already makes the rest of the page inert
+      inert: isNative(content) ? [] : inertOutside(content, backdrop),
+      pushed: false,
+    };
+    stack.push(entry);
+    syncOpen(entry);
+
+    layers()?.open(content, {
+      parts: backdrop ? [backdrop, content] : [content],
+      escape: () => {
+        if (!isDisabled(dialogId, "esc")) closeDialog(dialogId);
+      },
+      // Only the backdrop counts; a native dialog's ::backdrop is handled on click below
+      outside: (e) => {
+        if (e.target === backdrop && !isDisabled(dialogId, "click-away")) closeDialog(dialogId);
+      },
+    });
+
+    setTriggers(dialogId, true);
+  }
+
+  // Open dialog. With load false its Body is kept as is instead of reloading its URL.
+  function openDialog(dialogId, opener, load = true) {
+    const backdrop = find("backdrop", dialogId);
+    const content = find("content", dialogId);
+
+    if (!content || entryFor(dialogId)) return;
+    if (isNative(content)) {
+      openNative(dialogId, content, opener);
+      if (load) loadOnOpen(dialogId, content, opener);
+      return;
+    }
+    if (!backdrop) return;
 
-    openDialogs.add(dialogId);
+    activate(dialogId, content, backdrop, opener);
+    if (load) loadOnOpen(dialogId, content, opener);
 
     // First, remove hidden state to make visible (but still in closed position)
     backdrop.removeAttribute("data-pui-dialog-hidden");
@@ -25,64 +398,77 @@
     requestAnimationFrame(() => {
       backdrop.setAttribute("data-pui-dialog-open", "true");
       content.setAttribute("data-pui-dialog-open", "true");
-      document.body.style.overflow = "hidden";
-
-      // Update triggers
-      document
-        .querySelectorAll(
-          '[data-pui-dialog-trigger][data-dialog-instance="' + dialogId + '"]',
-        )
-        .forEach((trigger) => {
-          trigger.setAttribute("data-pui-dialog-trigger-open", "true");
-        });
-
-      // Focus first focusable element
-      setTimeout(() => {
-        const focusable = content.querySelector(
-          'button, [href], input, select, textarea, [tabindex]:not([tabindex="-1"])',
-        );
-        focusable?.focus();
-      }, 50);
+      focusInitial(content);
+      content.dispatchEvent(new CustomEvent("pui-dialog:open", { bubbles: true, detail: { id: dialogId } }));
     });
   }
 
-  // Close dialog
-  function closeDialog(dialogId) {
-    // Find backdrop and content by instance ID
-    const backdrop = document.querySelector(
-      '[data-pui-dialog-backdrop][data-dialog-instance="' + dialogId + '"]',
-    );
-    const content = document.querySelector(
-      '[data-pui-dialog-content][data-dialog-instance="' + dialogId + '"]',
-    );
+  function openNative(dialogId, content, opener) {
+    activate(dialogId, content, null, opener);
+
+    // A dialog rendered with the open attribute is shown non-modally; reopen it modally
+    if (content.open) content.close();
+    content.showModal();
+
+    requestAnimationFrame(() => {
+      content.setAttribute("data-pui-dialog-open", "true");
+      focusInitial(content);
+      content.dispatchEvent(new CustomEvent("pui-dialog:open", { bubbles: true, detail: { id: dialogId } }));
+    });
+  }
 
-    if (!backdrop || !content) return;
+  // Close dialog. Dialogs stacked above close with it; cascade marks those calls.
+  function closeDialog(dialogId, cascade) {
+    const backdrop = find("backdrop", dialogId);
+    const content = find("content", dialogId);
+    const entry = entryFor(dialogId);
+
+    if (!content || !entry) return;
+    if (!backdrop && !isNative(content)) return;
+
+    // Dialogs opened on top of this one close with it, and leave the URL together
+    const above = stack.slice(stack.indexOf(entry) + 1).reverse();
+    if (!cascade) syncClose(above.concat(entry));
+    above.forEach((a) => closeDialog(a.id, true));
+
+    stack.splice(stack.indexOf(entry), 1);
+    layers()?.close(content);
+    loads.get(dialogId)?.abort();
+    entry.inert.forEach((el) => {
+      el.inert = false;
+    });
 
     // Start close animation
-    backdrop.setAttribute("data-pui-dialog-open", "false");
+    backdrop?.setAttribute("data-pui-dialog-open", "false");
     content.setAttribute("data-pui-dialog-open", "false");
-
-    // Update triggers
-    document
-      .querySelectorAll(
-        '[data-pui-dialog-trigger][data-dialog-instance="' + dialogId + '"]',
-      )
-      .forEach((trigger) => {
-        trigger.setAttribute("data-pui-dialog-trigger-open", "false");
-      });
+    setTriggers(dialogId, false);
 
     // Wait for animation to complete before hiding
     setTimeout(() => {
+      if (entryFor(dialogId)) return; // Reopened meanwhile
+      if (isNative(content)) {
+        if (content.open) content.close();
+        return;
+      }
       backdrop.setAttribute("data-pui-dialog-hidden", "true");
       content.setAttribute("data-pui-dialog-hidden", "true");
-    }, 300);
-
-    openDialogs.delete(dialogId);
+    }, isNative(content) ? 200 : 300);
 
-    // Restore body overflow if no dialogs are open
-    if (openDialogs.size === 0) {
-      document.body.style.overflow = "";
+    // Leaving :target clears the no-JS fallback too
+    if (location.hash === "#" + content.id) {
+      history.replaceState(history.state, "", location.pathname + location.search);
     }
+
+    unlockScroll();
+
+    // Return focus to what opened the dialog, or to one of its triggers
+    const fallback = document.querySelector(
+      '[data-pui-dialog-trigger][data-dialog-instance="' + CSS.escape(dialogId) + '"]',
+    );
+    const target = entry.returnFocus && entry.returnFocus.isConnected ? entry.returnFocus : fallback;
+    if (target && target !== document.body) target.focus({ preventScroll: true });
+
+    content.dispatchEvent(new CustomEvent("pui-dialog:close", { bubbles: true, detail: { id: dialogId } }));
   }
 
   // Get dialog instance from element
@@ -92,9 +478,9 @@
     if (instance) return instance;
 
     // Try to find parent dialog
-    const parentDialog = element.closest("[data-pui-dialog]");
-    if (parentDialog) {
-      return parentDialog.getAttribute("data-dialog-instance");
+    const parent = element.closest("[data-pui-dialog-content], [data-pui-dialog]");
+    if (parent) {
+      return parent.getAttribute("data-dialog-instance");
     }
 
     return null;
@@ -102,30 +488,33 @@
 
   // Helper function for checking dialog state
   function isDialogOpen(dialogId) {
-    const content = document.querySelector(
-      '[data-pui-dialog-content][data-dialog-instance="' + dialogId + '"]',
-    );
-    return content?.getAttribute("data-pui-dialog-open") === "true" || false;
+    return entryFor(dialogId) !== null;
   }
 
   // Helper function for toggling dialog
-  function toggleDialog(dialogId) {
-    isDialogOpen(dialogId) ? closeDialog(dialogId) : openDialog(dialogId);
+  function toggleDialog(dialogId, opener) {
+    isDialogOpen(dialogId) ? closeDialog(dialogId) : openDialog(dialogId, opener);
+  }
+
+  function isDisabled(dialogId, option) {
+    const wrapper = find("", dialogId);
+    const content = find("content", dialogId);
+    const attr = "data-pui-dialog-disable-" + option;
+
+    return wrapper?.hasAttribute(attr) || content?.hasAttribute(attr);
   }
 
   // Event delegation
   document.addEventListener("click", (e) => {
     // Handle trigger clicks
     const trigger = e.target.closest("[data-pui-dialog-trigger]");
-    if (
-      trigger &&
-      !trigger.hasAttribute("disabled") &&
-      !trigger.classList.contains("opacity-50")
-    ) {
+    if (trigger && !trigger.hasAttribute("disabled") && !trigger.classList.contains("opacity-50")) {
       const dialogId = trigger.getAttribute("data-dialog-instance");
       if (!dialogId) return;
 
-      toggleDialog(dialogId);
+      // Native triggers are links to the panel for clients without JavaScript
+      if (trigger.tagName === "A") e.preventDefault();
+      toggleDialog(dialogId, trigger);
       return;
     }
 
@@ -136,82 +525,215 @@
       const forValue = closeBtn.getAttribute("data-pui-dialog-close");
       const dialogId = forValue || getDialogInstance(closeBtn);
 
+      if (closeBtn.tagName === "A") e.preventDefault();
       if (dialogId) {
         closeDialog(dialogId);
       }
       return;
     }
 
-    // Handle click away - close when clicking on backdrop
-    const backdrop = e.target.closest("[data-pui-dialog-backdrop]");
-    if (backdrop) {
-      const dialogId = backdrop.getAttribute("data-dialog-instance");
-      if (!dialogId) return;
-
-      // Check if click away is disabled
-      const wrapper = document.querySelector(
-        '[data-pui-dialog][data-dialog-instance="' + dialogId + '"]',
-      );
-      const content = document.querySelector(
-        '[data-pui-dialog-content][data-dialog-instance="' + dialogId + '"]',
-      );
-
-      const isDisabled =
-        wrapper?.hasAttribute("data-pui-dialog-disable-click-away") ||
-        content?.hasAttribute("data-pui-dialog-disable-click-away");
+    // A click on a native dialog itself but outside its box landed on ::backdrop
+    if (e.target.tagName === "DIALOG" && e.target.hasAttribute("data-pui-dialog-native")) {
+      if (layers() && !layers().isTop(e.target)) return;
 
-      if (!isDisabled) {
+      const rect = e.target.getBoundingClientRect();
+      const inside =
+        e.clientX >= rect.left && e.clientX <= rect.right && e.clientY >= rect.top && e.clientY <= rect.bottom;
+      const dialogId = e.target.getAttribute("data-dialog-instance");
+      if (!inside && dialogId && !isDisabled(dialogId, "click-away")) {
         closeDialog(dialogId);
       }
     }
   });
 
-  // ESC key handler
+  // ESC and clicks on the backdrop reach the topmost layer through layer.js
   document.addEventListener("keydown", (e) => {
-    if (e.key === "Escape" && openDialogs.size > 0) {
-      // Close the most recently opened dialog
-      const dialogId = Array.from(openDialogs).pop();
-
-      // Check if ESC is disabled
-      const wrapper = document.querySelector(
-        '[data-pui-dialog][data-dialog-instance="' + dialogId + '"]',
-      );
-      const content = document.querySelector(
-        '[data-pui-dialog-content][data-dialog-instance="' + dialogId + '"]',
-      );
+    const top = topmost();
+    if (!top) return;
+
+    // Keep Tab cycling inside the topmost dialog, unless focus is in a layer above it
+    if (e.key === "Tab" && !layers()?.above(top.content, document.activeElement)) {
+      const items = focusables(top.content);
+      if (items.length === 0) {
+        e.preventDefault();
+        top.content.focus();
+        return;
+      }
 
-      const isDisabled =
-        wrapper?.hasAttribute("data-pui-dialog-disable-esc") ||
-        content?.hasAttribute("data-pui-dialog-disable-esc");
+      const first = items[0];
+      const last = items[items.length - 1];
+      const active = document.activeElement;
 
-      if (!isDisabled) {
-        closeDialog(dialogId);
+      if (e.shiftKey && (active === first || !top.content.contains(active))) {
+        e.preventDefault();
+        last.focus();
+      } else if (!e.shiftKey && (active === last || !top.content.contains(active))) {
+        e.preventDefault();
+        first.focus();
       }
     }
   });
 
-  // Initialize dialogs that should be open on load
-  document.addEventListener("DOMContentLoaded", () => {
-    // Find all dialogs that should be initially open
+  // The browser cancels a modal <dialog> on ESC; route it through closeDialog so the
+  // animation, stack and focus restore behave the same as the div panels
+  document.addEventListener(
+    "cancel",
+    (e) => {
+      const content = e.target;
+      if (!(content instanceof HTMLDialogElement) || !content.hasAttribute("data-pui-dialog-native")) return;
+
+      e.preventDefault();
+      if (layers() && !layers().isTop(content)) return;
+      const dialogId = content.getAttribute("data-dialog-instance");
+      if (dialogId && !isDisabled(dialogId, "esc")) closeDialog(dialogId);
+    },
+    true,
+  );
+
+  // method="dialog" forms and dialog.close() bypass closeDialog; keep the stack in sync
+  document.addEventListener(
+    "close",
+    (e) => {
+      const content = e.target;
+      if (!(content instanceof HTMLDialogElement) || !content.hasAttribute("data-pui-dialog-native")) return;
+
+      const dialogId = content.getAttribute("data-dialog-instance");
+      if (dialogId && entryFor(dialogId)) closeDialog(dialogId);
+    },
+    true,
+  );
+
+  document.addEventListener("input", (e) => {
+    if (!e.target.matches || !e.target.matches("[data-pui-dialog-phrase]")) return;
+
+    const form = e.target.closest("[data-pui-dialog-confirm-form]");
+    if (form) updateConfirm(form);
+  });
+
+  // Follow the URL after Back, Forward or a hash change, and apply hash state on load
+  document.addEventListener("pui-url:change", () => {
+    const pending = pendingClear;
+    pendingClear = [];
+    pending.forEach((content) => window.tui.url.set(content, ""));
+
+    fromUrl = true;
+    try {
+      document.querySelectorAll("[data-pui-dialog-content][data-pui-url-param]").forEach((content) => {
+        const dialogId = content.getAttribute("data-dialog-instance");
+        const wanted = window.tui.url.get(content) === dialogId;
+
+        if (wanted && !entryFor(dialogId)) openDialog(dialogId, null);
+        else if (!wanted && entryFor(dialogId)) closeDialog(dialogId);
+      });
+    } finally {
+      fromUrl = false;
+    }
+  });
+
+  // Open and close requested by the server through HX-Trigger, or by other scripts
+  function requestedIds(detail) {
+    if (!detail) return [];
+    return detail.ids || (detail.id ? [detail.id] : []);
+  }
+
+  document.addEventListener("pui-dialog:request-open", (e) => {
+    requestedIds(e.detail).forEach((id) => openDialog(id, null, e.detail.load !== false));
+  });
+
+  document.addEventListener("pui-dialog:request-close", (e) => {
+    requestedIds(e.detail).forEach((id) => closeDialog(id));
+  });
+
+  // Without HTMX, forms in a Body submit in place and their response is applied like a load
+  document.addEventListener("submit", (e) => {
+    const form = e.target;
+    if (!(form instanceof HTMLFormElement) || e.defaultPrevented || window.htmx) return;
+
+    const body = form.closest("[data-pui-dialog-body]");
+    if (!body) return;
+
+    const submitter = e.submitter;
+    const method = ((submitter && submitter.getAttribute("formmethod")) || form.getAttribute("method") || "get").toLowerCase();
+    if (method === "dialog") return;
+
+    e.preventDefault();
+
+    const content = body.closest("[data-pui-dialog-content]");
+    const dialogId = content && content.getAttribute("data-dialog-instance");
+    const url = new URL((submitter && submitter.getAttribute("formaction")) || form.action, location.href);
+    const data = new FormData(form, submitter);
+    const init = { method: method.toUpperCase() };
+
+    if (method === "get") {
+      url.search = new URLSearchParams(data).toString();
+    } else {
+      init.body = form.enctype === "multipart/form-data" ? data : new URLSearchParams(data);
+    }
+
+    body.setAttribute("aria-busy", "true");
+    request(dialogId, url, init)
+      .then((res) => apply(res, body))
+      .catch(() => {
+        body.removeAttribute("aria-busy");
+        form.dispatchEvent(new CustomEvent("pui-dialog:error", { bubbles: true, detail: { id: dialogId } }));
+      });
+  });
+
+  // With HTMX, tell the server which dialog a request comes from and link swapped content
+  document.addEventListener("htmx:configRequest", (e) => {
+    const content = e.target.closest && e.target.closest("[data-pui-dialog-content]");
+    if (content) e.detail.headers["X-Dialog-Instance"] = content.getAttribute("data-dialog-instance");
+  });
+
+  document.addEventListener("htmx:afterSwap", (e) => {
+    const content = e.target.closest && e.target.closest("[data-pui-dialog-content]");
+    if (content) link(content);
+  });
+
+  // Pull focus back if it escapes the topmost dialog, e.g. into browser-injected UI
+  document.addEventListener("focusin", (e) => {
+    const top = topmost();
+    if (!top || top.content.contains(e.target)) return;
+    if (e.target.closest && e.target.closest("[data-pui-dialog-content]")) return;
+    if (layers()?.above(top.content, e.target)) return;
+
+    focusInitial(top.content);
+  });
+
+  // Dialogs rendered open must be tracked as open too
+  function init() {
+    document.querySelectorAll("[data-pui-dialog-content]").forEach(link);
     document
-      .querySelectorAll(
-        '[data-pui-dialog-content][data-pui-dialog-open="true"]',
-      )
+      .querySelectorAll('[data-pui-dialog-content][data-pui-dialog-open="true"]')
       .forEach((content) => {
         const dialogId = content.getAttribute("data-dialog-instance");
-        if (dialogId) {
-          openDialogs.add(dialogId);
-          document.body.style.overflow = "hidden";
+        const backdrop = dialogId && find("backdrop", dialogId);
+        if (!dialogId || entryFor(dialogId)) return;
+
+        if (isNative(content)) {
+          openNative(dialogId, content, null);
+          return;
         }
+
+        activate(dialogId, content, backdrop, null);
+        focusInitial(content);
       });
-  });
+  }
+
+  if (document.readyState === "loading") {
+    document.addEventListener("DOMContentLoaded", init);
+  } else {
+    init();
+  }
 
   // Expose public API
   window.tui = window.tui || {};
   window.tui.dialog = {
     open: openDialog,
-    close: closeDialog,
+    close: (dialogId) => closeDialog(dialogId),
     toggle: toggleDialog,
     isOpen: isDialogOpen,
+    link: link,
+    load: loadBody,
   };
 })();
@@ -0,0 +1,128 @@
+package dialog
+
+import (
+	"encoding/json"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/plainkit/html"
+)
+
+// HeaderInstance is the request header carrying the instance ID of the dialog a Body
+// is loaded or submitted from.
+const HeaderInstance = "X-Dialog-Instance"
+
+// Response headers understood by HTMX and by dialog.js, which applies them to the
+// requests it makes itself.
+const (
+	HeaderTrigger          = "HX-Trigger"
+	HeaderTriggerAfterSwap = "HX-Trigger-After-Swap"
+	HeaderRetarget         = "HX-Retarget"
+	HeaderReswap           = "HX-Reswap"
+)
+
+// Client events dialog.js listens for. Their detail lists the instance IDs to act on.
+const (
+	EventOpen  = "pui-dialog:request-open"
+	EventClose = "pui-dialog:request-close"
+)
+
+// Instance returns the dialog instance a request came from, or "" when it was not made
+// by a Body.
+func Instance(r *http.Request) string {
+	return r.Header.Get(HeaderInstance)
+}
+
+// RespondOpen makes the client open the dialog once the response arrives. Call it before
+// writing the response.
+func RespondOpen(w http.ResponseWriter, instanceID string) {
+	addEvent(w, HeaderTrigger, EventOpen, instanceID, true)
+}
+
+// RespondClose makes the client close the dialog, such as after a form in it was saved.
+// Call it before writing the response, which is usually empty with status 204.
+func RespondClose(w http.ResponseWriter, instanceID string) {
+	addEvent(w, HeaderTrigger, EventClose, instanceID, true)
+}
+
+// RespondReplace writes node as the new Body of the dialog and opens it if needed, such
+// as to show a form again with its form.Message errors. The dialog does not reload its
+// URL when opened this way. Instance IDs are limited to letters, digits, "-" and "_",
+// since they usually come from the request; others get a 400 response.
+func RespondReplace(w http.ResponseWriter, instanceID string, node html.Node) {
+	if !validInstance(instanceID) {
+		http.Error(w, "invalid dialog instance", http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set(HeaderRetarget, bodySelector(instanceID))
+	w.Header().Set(HeaderReswap, "innerHTML")
+	// A replaced body must not be overwritten by reloading its URL
+	addEvent(w, HeaderTriggerAfterSwap, EventOpen, instanceID, false)
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+
+	_, _ = w.Write([]byte(html.Render(node)))
+}
+
+// bodySelector matches the Body of a dialog by the instance dialog.js marks it with, so
+// a custom BodyProps.ID is kept.
+func bodySelector(instanceID string) string {
+	return `[data-pui-dialog-body][data-dialog-instance="` + instanceID + `"]`
+}
+
+func validInstance(instanceID string) bool {
+	if instanceID == "" {
+		return false
+	}
+
+	for _, c := range instanceID {
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '-' || c == '_') {
+			return false
+		}
+	}
+
+	return true
+}
+
+// eventDetail is the detail of EventOpen and EventClose.
+type eventDetail struct {
+	IDs  []string `json:"ids"`
+	Load *bool    `json:"load,omitempty"`
+}
+
+// addEvent adds the instance to event in the JSON object of header, keeping any events
+// already set there by the handler. Without load, an opened dialog keeps its Body.
+func addEvent(w http.ResponseWriter, header, event, instanceID string, load bool) {
+	events := map[string]json.RawMessage{}
+
+	if existing := strings.TrimSpace(w.Header().Get(header)); existing != "" {
+		if err := json.Unmarshal([]byte(existing), &events); err != nil {
+			// Plain comma separated event names
+			for _, name := range strings.Split(existing, ",") {
+				if name = strings.TrimSpace(name); name != "" {
+					events[name] = json.RawMessage(`""`)
+				}
+			}
+		}
+	}
+
+	var detail eventDetail
+	if raw, ok := events[event]; ok {
+		_ = json.Unmarshal(raw, &detail)
+	}
+
+	if !slices.Contains(detail.IDs, instanceID) {
+		detail.IDs = append(detail.IDs, instanceID)
+	}
+
+	if !load {
+		detail.Load = &load
+	}
+
+	events[event], _ = json.Marshal(detail)
+	data, _ := json.Marshal(events)
+	w.Header().Set(header, string(data))
+}
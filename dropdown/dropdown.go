@@ -8,6 +8,7 @@ import (
 	"github.com/plainkit/icons/lucide"
 	"github.com/plainkit/ui/button"
 	"github.com/plainkit/ui/internal/styles"
+	"github.com/plainkit/ui/option"
 	"github.com/plainkit/ui/popover"
 )
 
@@ -70,6 +71,8 @@ type ItemProps struct {
 	Href         string
 	Target       string
 	PreventClose bool
+	Name         string // When set, the item submits its Value under this form field name
+	Value        string
 }
 
 type SeparatorProps struct {
@@ -388,6 +391,10 @@ func Item(props ItemProps, args ...html.Node) html.Node {
 		attrs = append(attrs, html.AData("pui-dropdown-prevent-close", "true"))
 	}
 
+	if props.Value != "" {
+		attrs = append(attrs, html.AData("pui-dropdown-value", props.Value))
+	}
+
 	attrs = append(attrs, props.Attrs...)
 
 	if props.Href != "" {
@@ -412,11 +419,20 @@ func Item(props ItemProps, args ...html.Node) html.Node {
 		return html.A(aArgs...)
 	}
 
-	// Create button
+	// Create button, submitting its value when part of a form field
+	buttonType := "button"
+	if props.Name != "" {
+		buttonType = "submit"
+	}
+
 	buttonArgs := []html.ButtonArg{
 		html.AId(id),
 		html.AClass(itemClass),
-		html.AType("button"),
+		html.AType(buttonType),
+	}
+
+	if props.Name != "" {
+		buttonArgs = append(buttonArgs, html.AName(props.Name), html.AValue(props.Value))
 	}
 	if props.Disabled {
 		buttonArgs = append(buttonArgs, html.ADisabled())
@@ -433,6 +449,49 @@ func Item(props ItemProps, args ...html.Node) html.Node {
 	return html.Button(buttonArgs...)
 }
 
+// Options renders a typed option list as dropdown items, grouped by Option.Group.
+// Every item starts from props and carries its encoded value; set props.Name to
+// submit the chosen value and read it back with List.Parse.
+func Options[T any](props ItemProps, list option.List[T]) html.Node {
+	groups := list.Groups()
+	args := make([]html.DivArg, 0, len(groups))
+
+	for i, g := range groups {
+		if i > 0 {
+			args = append(args, Separator(SeparatorProps{}))
+		}
+
+		items := make([]html.DivArg, 0, len(g.Options)+1)
+		if g.Name != "" {
+			items = append(items, Label(LabelProps{}, html.Text(g.Name)))
+		}
+
+		for _, o := range g.Options {
+			itemProps := props
+			itemProps.ID = ""
+			itemProps.Value = list.Encode(o.Value)
+			itemProps.Disabled = props.Disabled || o.Disabled
+
+			content := []html.Node{}
+			if o.Icon != nil {
+				content = append(content, html.Span(html.AClass("text-muted-foreground [&_svg]:size-4"), html.Child(o.Icon)))
+			}
+
+			text := []html.SpanArg{html.AClass("mr-auto grid gap-0.5"), html.Span(html.Text(list.Label(o)))}
+			if o.Description != "" {
+				text = append(text, html.Span(html.AClass(styles.SubtleText("text-xs")), html.Text(o.Description)))
+			}
+
+			content = append(content, html.Span(text...))
+			items = append(items, Item(itemProps, content...))
+		}
+
+		args = append(args, Group(append([]html.DivArg{GroupProps{}}, items...)...))
+	}
+
+	return html.Div(args...)
+}
+
 func separatorDivArgsFromProps(baseClass string, extra ...string) func(p SeparatorProps) []html.DivArg {
 	return func(p SeparatorProps) []html.DivArg {
 		args := []html.DivArg{
@@ -609,7 +668,7 @@ func SubContent(props SubContentProps, args ...html.DivArg) html.Node {
 		HoverDelay:    100,
 		HoverOutDelay: 200,
 		Class: html.ClassMerge(
-			styles.Panel("z-[9999] min-w-[8rem] p-2 shadow-xl"),
+			styles.Panel("min-w-[8rem] p-2 shadow-xl"),
 			props.Class,
 		),
 		Attrs: props.Attrs,
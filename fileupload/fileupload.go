@@ -0,0 +1,533 @@
+package fileupload
+
+import (
+	_ "embed"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/plainkit/html"
+	"github.com/plainkit/icons/lucide"
+	"github.com/plainkit/ui/internal/randomid"
+	"github.com/plainkit/ui/internal/styles"
+)
+
+var (
+	// ErrTooLarge is returned when a file exceeds MaxSize.
+	ErrTooLarge = errors.New("fileupload: file is too large")
+	// ErrType is returned when a file matches none of the Accept entries.
+	ErrType = errors.New("fileupload: file type is not allowed")
+	// ErrTooMany is returned when more files than allowed were submitted.
+	ErrTooMany = errors.New("fileupload: too many files")
+	// ErrRequired is returned when a required field has no files.
+	ErrRequired = errors.New("fileupload: a file is required")
+	// ErrIncomplete is returned when a submitted upload has not received all its bytes.
+	ErrIncomplete = errors.New("fileupload: upload is incomplete")
+)
+
+// DefaultChunkSize is the chunk size used for resumable uploads when Props.ChunkSize is unset.
+const DefaultChunkSize = 5 << 20
+
+type Props struct {
+	ID        string
+	Class     string
+	Attrs     []html.Global
+	Name      string   // Field name: the files themselves, or upload IDs when UploadURL is set
+	Form      string   // Associates the hidden fields with a form by ID
+	Accept    []string // Extensions and MIME types such as ".pdf", "image/*" or "application/pdf"
+	MaxSize   int64    // Maximum bytes per file, 0 for no limit
+	MaxFiles  int      // Maximum number of files when Multiple, 0 for no limit
+	Multiple  bool
+	UploadURL string // Endpoint served by Handler; files upload in chunks as soon as they are picked
+	ChunkSize int64  // Bytes per upload request (default DefaultChunkSize)
+	Value     []Info // Completed uploads shown when rendering, e.g. on an edit form
+	Title     string // Dropzone call to action (default "Drop files here or click to browse")
+	Hint      string // Secondary dropzone text (default: accepted types and maximum size)
+	Disabled  bool
+	Required  bool
+	HasError  bool
+}
+
+func divArgsFromProps(baseClass string, extra ...string) func(p Props) []html.DivArg {
+	return func(p Props) []html.DivArg {
+		args := []html.DivArg{html.AClass(html.ClassMerge(append([]string{baseClass}, append(extra, p.Class)...)...))}
+		if p.ID != "" {
+			args = append(args, html.AId(p.ID+"-container"))
+		}
+
+		for _, a := range p.Attrs {
+			args = append(args, a)
+		}
+
+		return args
+	}
+}
+
+func (p Props) ApplyDiv(attrs *html.DivAttrs, children *[]html.Component) {
+	id := p.ID
+	if id == "" {
+		id = randomid.New("fileupload")
+	}
+
+	args := divArgsFromProps("w-full space-y-3")(p)
+	args = append(args,
+		html.AData("pui-fileupload", ""),
+		html.AData("pui-fileupload-name", p.Name),
+		html.AData("pui-fileupload-form", p.Form),
+		html.AData("pui-fileupload-max-files", strconv.Itoa(p.maxFiles())),
+	)
+
+	if len(p.Accept) > 0 {
+		args = append(args, html.AData("pui-fileupload-accept", strings.Join(p.Accept, ",")))
+	}
+
+	if p.MaxSize > 0 {
+		args = append(args, html.AData("pui-fileupload-max-size", strconv.FormatInt(p.MaxSize, 10)))
+	}
+
+	if p.UploadURL != "" {
+		args = append(args,
+			html.AData("pui-fileupload-url", p.UploadURL),
+			html.AData("pui-fileupload-chunk-size", strconv.FormatInt(p.chunkSize(), 10)),
+		)
+	}
+
+	fileArgs := []html.InputArg{
+		html.AId(id),
+		html.AType("file"),
+		html.AClass("sr-only"),
+		html.AData("pui-fileupload-input", ""),
+	}
+
+	// With an upload URL the files travel through Handler and only their IDs are submitted
+	if p.UploadURL == "" {
+		if p.Name != "" {
+			fileArgs = append(fileArgs, html.AName(p.Name))
+		}
+
+		if p.Form != "" {
+			fileArgs = append(fileArgs, html.AForm(p.Form))
+		}
+
+		if p.Required {
+			fileArgs = append(fileArgs, html.ARequired())
+		}
+	}
+
+	if len(p.Accept) > 0 {
+		fileArgs = append(fileArgs, html.AAccept(strings.Join(p.Accept, ",")))
+	}
+
+	if p.Multiple {
+		fileArgs = append(fileArgs, html.AMultiple())
+	}
+
+	if p.Disabled {
+		fileArgs = append(fileArgs, html.ADisabled())
+	}
+
+	if p.HasError {
+		fileArgs = append(fileArgs, html.AAria("invalid", "true"))
+	}
+
+	title := p.Title
+	if title == "" {
+		title = "Drop files here or click to browse"
+	}
+
+	hint := p.Hint
+	if hint == "" {
+		hint = p.describe()
+	}
+
+	dropzone := html.Label(
+		html.AFor(id),
+		html.AClass(html.ClassMerge(
+			"flex w-full cursor-pointer flex-col items-center justify-center gap-2 rounded-2xl border-2 border-dashed border-border/70 bg-background/60 px-6 py-8 text-center transition-colors",
+			"hover:border-primary/50 hover:bg-muted/40",
+			"has-[:focus-visible]:border-ring has-[:focus-visible]:ring-2 has-[:focus-visible]:ring-ring/40",
+			"data-[pui-fileupload-dragging=true]:border-primary data-[pui-fileupload-dragging=true]:bg-primary/5",
+			func() string {
+				if p.Disabled {
+					return "pointer-events-none cursor-not-allowed opacity-60"
+				}
+
+				return ""
+			}(),
+			func() string {
+				if p.HasError {
+					return "border-destructive/70"
+				}
+
+				return ""
+			}(),
+		)),
+		html.AData("pui-fileupload-dropzone", ""),
+		html.Input(fileArgs...),
+		html.Span(
+			html.AClass("flex size-10 items-center justify-center rounded-full bg-muted/70 text-muted-foreground"),
+			lucide.CloudUpload(html.AClass("size-5")),
+		),
+		html.Span(html.AClass("text-sm font-medium text-foreground"), html.Text(title)),
+		html.Span(html.AClass(styles.SubtleText("text-xs")), html.Text(hint)),
+	)
+
+	listArgs := []html.UlArg{
+		html.AClass("space-y-2 empty:hidden"),
+		html.AData("pui-fileupload-list", ""),
+	}
+	valueArgs := []html.DivArg{
+		html.AClass("hidden"),
+		html.AData("pui-fileupload-values", ""),
+	}
+
+	for _, info := range p.Value {
+		listArgs = append(listArgs, item(&info))
+		valueArgs = append(valueArgs, html.Input(
+			html.AType("hidden"),
+			html.AName(p.Name),
+			html.AValue(info.ID),
+			func() html.InputArg {
+				if p.Form != "" {
+					return html.AForm(p.Form)
+				}
+
+				return html.AAria("", "")
+			}(),
+		))
+	}
+
+	// Rows for newly picked files are cloned from this template
+	template := html.Div(html.AData("pui-fileupload-template", ""), item(nil))
+	template.Tag = "template"
+
+	*children = append(*children,
+		dropzone,
+		html.Ul(listArgs...),
+		template,
+		html.Div(valueArgs...),
+		html.Div(
+			html.AClass("sr-only"),
+			html.ACustom("role", "status"),
+			html.AAria("live", "polite"),
+			html.AData("pui-fileupload-status", ""),
+		),
+	)
+
+	for _, a := range args {
+		a.ApplyDiv(attrs, children)
+	}
+}
+
+// FileUpload renders a drag-and-drop zone with a list of picked files. Without UploadURL the
+// files are posted with the form (read them with ParseFiles); with it they upload in resumable
+// chunks to Handler and the form submits their IDs (read them with ParseUploads).
+func FileUpload(args ...html.DivArg) html.Node {
+	var (
+		props Props
+		rest  []html.DivArg
+	)
+
+	for _, a := range args {
+		if v, ok := a.(Props); ok {
+			props = v
+		} else {
+			rest = append(rest, a)
+		}
+	}
+
+	return html.Div(append([]html.DivArg{props}, rest...)...).WithAssets("", fileuploadJS, "ui-fileupload")
+}
+
+// item renders one row of the file list; nil renders the empty row used as the client template.
+func item(info *Info) html.Node {
+	name, size, state := "", "", "queued"
+	if info != nil {
+		name, size, state = info.Name, FormatSize(info.Size), "done"
+	}
+
+	args := []html.LiArg{
+		html.AClass(styles.SurfaceMuted("flex items-center gap-3 p-2 data-[pui-fileupload-state=error]:border-destructive/50")),
+		html.AData("pui-fileupload-item", ""),
+		html.AData("pui-fileupload-state", state),
+		html.Div(
+			html.AClass("flex size-10 shrink-0 items-center justify-center overflow-hidden rounded-lg bg-background/70"),
+			html.Img(
+				html.AClass("hidden size-full object-cover"),
+				html.AAlt(""),
+				html.AData("pui-fileupload-thumb", ""),
+			),
+			lucide.File(html.AClass("size-5"), html.AData("pui-fileupload-icon", "")),
+		),
+		html.Div(
+			html.AClass("min-w-0 flex-1 space-y-1"),
+			html.Div(
+				html.AClass("flex items-baseline justify-between gap-2"),
+				html.Span(html.AClass("truncate text-sm font-medium text-foreground"), html.AData("pui-fileupload-name", ""), html.Text(name)),
+				html.Span(html.AClass("shrink-0 text-xs tabular-nums"), html.AData("pui-fileupload-size", ""), html.Text(size)),
+			),
+			html.Div(
+				html.AClass("hidden h-1.5 w-full overflow-hidden rounded-full bg-muted/60"),
+				html.ACustom("role", "progressbar"),
+				html.AAria("valuemin", "0"),
+				html.AAria("valuemax", "100"),
+				html.AAria("valuenow", "0"),
+				html.AData("pui-fileupload-progress", ""),
+				html.Div(
+					html.AClass("h-full rounded-full bg-primary transition-[width]"),
+					html.AStyle("width: 0%;"),
+					html.AData("pui-fileupload-bar", ""),
+				),
+			),
+			html.P(html.AClass("hidden text-xs text-destructive"), html.AData("pui-fileupload-error", "")),
+		),
+		html.Button(
+			html.AType("button"),
+			html.AClass(styles.InteractiveGhost("hidden size-8 shrink-0 rounded-lg text-muted-foreground")),
+			html.AAria("label", "Retry upload"),
+			html.AData("pui-fileupload-retry", ""),
+			lucide.RotateCw(html.AClass("pointer-events-none size-4")),
+		),
+		html.Button(
+			html.AType("button"),
+			html.AClass(styles.InteractiveGhost("size-8 shrink-0 rounded-lg text-muted-foreground hover:text-destructive")),
+			html.AAria("label", "Remove "+name),
+			html.AData("pui-fileupload-remove", ""),
+			lucide.X(html.AClass("pointer-events-none size-4")),
+		),
+	}
+
+	if info != nil {
+		args = append(args,
+			html.AData("pui-fileupload-id", info.ID),
+			html.AData("pui-fileupload-persisted", "true"),
+		)
+	}
+
+	return html.Li(args...)
+}
+
+// Check applies the Accept and MaxSize rules the client enforces to one file.
+func Check(p Props, name, contentType string, size int64) error {
+	if p.MaxSize > 0 && size > p.MaxSize {
+		return fmt.Errorf("%w: %s exceeds %s", ErrTooLarge, name, FormatSize(p.MaxSize))
+	}
+
+	if !accepts(p.Accept, name, contentType, false) {
+		return fmt.Errorf("%w: %s", ErrType, name)
+	}
+
+	return nil
+}
+
+// CheckContent sniffs the first bytes of a file and rejects content contradicting Accept,
+// e.g. an HTML document renamed to photo.png. Content the sniffer cannot identify passes.
+func CheckContent(p Props, name string, head []byte) error {
+	sniffed, _, _ := strings.Cut(http.DetectContentType(head), ";")
+	if sniffed == "application/octet-stream" || sniffed == "text/plain" {
+		return nil
+	}
+
+	if !accepts(p.Accept, name, sniffed, true) {
+		return fmt.Errorf("%w: %s looks like %s", ErrType, name, sniffed)
+	}
+
+	return nil
+}
+
+// ParseFiles reads the files posted under p.Name by a FileUpload without UploadURL and
+// checks them like the client does.
+func ParseFiles(r *http.Request, p Props) ([]*multipart.FileHeader, error) {
+	maxMemory := int64(32 << 20)
+	if err := r.ParseMultipartForm(maxMemory); err != nil && !errors.Is(err, http.ErrNotMultipart) {
+		return nil, err
+	}
+
+	var files []*multipart.FileHeader
+	if r.MultipartForm != nil {
+		files = r.MultipartForm.File[p.Name]
+	}
+
+	if err := p.checkCount(len(files)); err != nil {
+		return nil, err
+	}
+
+	for _, fh := range files {
+		if err := Check(p, fh.Filename, fh.Header.Get("Content-Type"), fh.Size); err != nil {
+			return nil, err
+		}
+
+		head, err := readHead(fh)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := CheckContent(p, fh.Filename, head); err != nil {
+			return nil, err
+		}
+	}
+
+	return files, nil
+}
+
+// FormatSize renders a byte count for people, e.g. "1.5 MB".
+func FormatSize(n int64) string {
+	const unit = 1024
+
+	if n < unit {
+		return strconv.FormatInt(n, 10) + " B"
+	}
+
+	size := float64(n)
+	units := []string{"KB", "MB", "GB", "TB"}
+	i := -1
+
+	for size >= unit && i < len(units)-1 {
+		size /= unit
+		i++
+	}
+
+	s := strconv.FormatFloat(size, 'f', 1, 64)
+
+	return strings.TrimSuffix(s, ".0") + " " + units[i]
+}
+
+// accepts mirrors the browser's accept attribute: extensions match the name,
+// "type/*" a MIME family and anything else the exact MIME type. A sniffed contentType
+// comes from the bytes, and an extension then also has to fit it.
+func accepts(accept []string, name, contentType string, sniffed bool) bool {
+	if len(accept) == 0 {
+		return true
+	}
+
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		mediaType = ""
+	}
+
+	ext := strings.ToLower(path.Ext(name))
+
+	for _, a := range accept {
+		a = strings.ToLower(strings.TrimSpace(a))
+
+		switch {
+		case strings.HasPrefix(a, "."):
+			if ext == a && (!sniffed || fitsExtension(mediaType, a)) {
+				return true
+			}
+		case strings.HasSuffix(a, "/*"):
+			if strings.HasPrefix(mediaType, strings.TrimSuffix(a, "*")) {
+				return true
+			}
+		case a != "" && mediaType == a:
+			return true
+		}
+	}
+
+	return false
+}
+
+// fitsExtension reports whether sniffed content may carry ext: the type registered for
+// ext or one of its family. HTML must match exactly, as it is what a renamed upload
+// usually hides. Extensions without a registered type are judged by name alone.
+func fitsExtension(mediaType, ext string) bool {
+	want, _, err := mime.ParseMediaType(mime.TypeByExtension(ext))
+	if err != nil || mediaType == want {
+		return true
+	}
+
+	// XML formats such as SVG sniff as text/xml
+	if mediaType == "text/xml" && strings.HasSuffix(want, "+xml") {
+		return true
+	}
+
+	family, _, _ := strings.Cut(want, "/")
+	sniffedFamily, _, _ := strings.Cut(mediaType, "/")
+
+	return mediaType != "text/html" && family == sniffedFamily
+}
+
+// describe summarizes the accepted types and size for the dropzone hint.
+func (p Props) describe() string {
+	var types []string
+
+	for _, a := range p.Accept {
+		a = strings.TrimSpace(a)
+
+		switch {
+		case strings.HasPrefix(a, "."):
+			if ext := a[1:]; ext != "" {
+				types = append(types, strings.ToUpper(ext))
+			}
+		case strings.HasSuffix(a, "/*"):
+			if family := strings.TrimSuffix(a, "/*"); family != "" {
+				types = append(types, strings.ToUpper(family[:1])+family[1:]+"s")
+			}
+		case strings.Contains(a, "/"):
+			_, sub, _ := strings.Cut(a, "/")
+			types = append(types, strings.ToUpper(sub))
+		}
+	}
+
+	hint := "Any file"
+	if len(types) > 0 {
+		hint = strings.Join(types, ", ")
+	}
+
+	if p.MaxSize > 0 {
+		hint += " up to " + FormatSize(p.MaxSize)
+	}
+
+	if n := p.maxFiles(); n > 1 {
+		hint += ", at most " + strconv.Itoa(n) + " files"
+	}
+
+	return hint
+}
+
+func (p Props) checkCount(n int) error {
+	if n == 0 && p.Required {
+		return ErrRequired
+	}
+
+	if limit := p.maxFiles(); limit > 0 && n > limit {
+		return fmt.Errorf("%w: at most %d", ErrTooMany, limit)
+	}
+
+	return nil
+}
+
+func (p Props) maxFiles() int {
+	if !p.Multiple {
+		return 1
+	}
+
+	return p.MaxFiles
+}
+
+func (p Props) chunkSize() int64 {
+	if p.ChunkSize <= 0 {
+		return DefaultChunkSize
+	}
+
+	return p.ChunkSize
+}
+
+func readHead(fh *multipart.FileHeader) ([]byte, error) {
+	f, err := fh.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return io.ReadAll(io.LimitReader(f, 512))
+}
+
+//go:embed fileupload.js
+var fileuploadJS string
@@ -0,0 +1,522 @@
+(function () {
+  "use strict";
+
+  const PARALLEL = 2;
+  const RETRIES = 3;
+  const THUMB_MAX_BYTES = 20 * 1024 * 1024;
+
+  const entries = new WeakMap(); // item element -> { file, id, xhr, thumb }
+  const picked = new WeakMap(); // root -> DataTransfer holding the files posted with the form
+
+  function options(root) {
+    return {
+      name: root.getAttribute("data-pui-fileupload-name") || "",
+      form: root.getAttribute("data-pui-fileupload-form") || "",
+      accept: (root.getAttribute("data-pui-fileupload-accept") || "")
+        .split(",")
+        .map((a) => a.trim().toLowerCase())
+        .filter(Boolean),
+      maxSize: parseInt(root.getAttribute("data-pui-fileupload-max-size")) || 0,
+      maxFiles: parseInt(root.getAttribute("data-pui-fileupload-max-files")) || 0,
+      url: root.getAttribute("data-pui-fileupload-url") || "",
+      chunkSize:
+        parseInt(root.getAttribute("data-pui-fileupload-chunk-size")) || 5 * 1024 * 1024,
+    };
+  }
+
+  function formatSize(n) {
+    if (n < 1024) return n + " B";
+
+    const units = ["KB", "MB", "GB", "TB"];
+    let size = n;
+    let i = -1;
+    while (size >= 1024 && i < units.length - 1) {
+      size /= 1024;
+      i++;
+    }
+
+    return size.toFixed(1).replace(/\.0$/, "") + " " + units[i];
+  }
+
+  // Mirrors accepts in fileupload.go
+  function accepts(accept, file) {
+    if (accept.length === 0) return true;
+
+    const name = file.name.toLowerCase();
+    const type = (file.type || "").toLowerCase();
+
+    return accept.some((a) => {
+      if (a.startsWith(".")) return name.endsWith(a);
+      if (a.endsWith("/*")) return type.startsWith(a.slice(0, -1));
+      return type === a;
+    });
+  }
+
+  function validate(root, file, count) {
+    const o = options(root);
+
+    if (o.maxFiles > 0 && count >= o.maxFiles) {
+      return o.maxFiles === 1 ? "Only one file can be added" : "At most " + o.maxFiles + " files";
+    }
+    if (o.maxSize > 0 && file.size > o.maxSize) {
+      return "Larger than " + formatSize(o.maxSize);
+    }
+    if (!accepts(o.accept, file)) {
+      return "File type not allowed";
+    }
+
+    return "";
+  }
+
+  function items(root) {
+    return Array.from(root.querySelectorAll("[data-pui-fileupload-item]"));
+  }
+
+  function counted(root) {
+    return items(root).filter((item) => item.getAttribute("data-pui-fileupload-state") !== "invalid")
+      .length;
+  }
+
+  function announce(root, message) {
+    const status = root.querySelector("[data-pui-fileupload-status]");
+    if (status) status.textContent = message;
+  }
+
+  function setState(item, state, message) {
+    item.setAttribute("data-pui-fileupload-state", state);
+
+    const error = item.querySelector("[data-pui-fileupload-error]");
+    if (error) {
+      error.textContent = message || "";
+      error.classList.toggle("hidden", !message);
+    }
+
+    const progress = item.querySelector("[data-pui-fileupload-progress]");
+    if (progress) {
+      progress.classList.toggle("hidden", state !== "uploading" && state !== "queued");
+    }
+
+    const retry = item.querySelector("[data-pui-fileupload-retry]");
+    if (retry) retry.classList.toggle("hidden", state !== "error");
+  }
+
+  function setProgress(item, fraction) {
+    const pct = Math.round(Math.min(1, Math.max(0, fraction)) * 100);
+    const progress = item.querySelector("[data-pui-fileupload-progress]");
+    const bar = item.querySelector("[data-pui-fileupload-bar]");
+
+    if (progress) progress.setAttribute("aria-valuenow", String(pct));
+    if (bar) bar.style.width = pct + "%";
+  }
+
+  function createItem(root, file) {
+    const template = root.querySelector("[data-pui-fileupload-template]");
+    const list = root.querySelector("[data-pui-fileupload-list]");
+    if (!template || !list) return null;
+
+    const item = template.content.firstElementChild.cloneNode(true);
+    const entry = { file: file, id: "", xhr: null, thumb: "" };
+
+    item.querySelector("[data-pui-fileupload-name]").textContent = file.name;
+    item.querySelector("[data-pui-fileupload-size]").textContent = formatSize(file.size);
+    item.querySelector("[data-pui-fileupload-remove]")?.setAttribute("aria-label", "Remove " + file.name);
+
+    if (file.type.startsWith("image/") && file.size <= THUMB_MAX_BYTES) {
+      const img = item.querySelector("[data-pui-fileupload-thumb]");
+      if (img) {
+        entry.thumb = URL.createObjectURL(file);
+        img.src = entry.thumb;
+        img.classList.remove("hidden");
+        item.querySelector("[data-pui-fileupload-icon]")?.classList.add("hidden");
+      }
+    }
+
+    entries.set(item, entry);
+    list.appendChild(item);
+
+    return item;
+  }
+
+  // Files posted with the form live in the input; keep it in sync with the list
+  function syncInput(root) {
+    const input = root.querySelector("[data-pui-fileupload-input]");
+    const dt = picked.get(root);
+    if (!input || !dt) return;
+
+    input.files = dt.files;
+  }
+
+  function addFiles(root, files) {
+    const o = options(root);
+    const input = root.querySelector("[data-pui-fileupload-input]");
+    if (!input || input.disabled) return;
+
+    let added = 0;
+    let rejected = 0;
+
+    for (const file of files) {
+      const message = validate(root, file, counted(root));
+      const item = createItem(root, file);
+      if (!item) continue;
+
+      if (message) {
+        setState(item, "invalid", message);
+        rejected++;
+        root.dispatchEvent(
+          new CustomEvent("pui-fileupload:invalid", {
+            bubbles: true,
+            detail: { file: file, message: message },
+          }),
+        );
+        continue;
+      }
+
+      added++;
+
+      if (o.url) {
+        setState(item, "queued");
+        setProgress(item, 0);
+      } else {
+        if (!picked.has(root)) picked.set(root, new DataTransfer());
+        picked.get(root).items.add(file);
+        setState(item, "done");
+      }
+
+      root.dispatchEvent(new CustomEvent("pui-fileupload:add", { bubbles: true, detail: { file: file } }));
+    }
+
+    if (o.url) {
+      input.value = "";
+      pump(root);
+    } else {
+      syncInput(root);
+    }
+
+    const parts = [];
+    if (added) parts.push(added === 1 ? "1 file added" : added + " files added");
+    if (rejected) parts.push(rejected === 1 ? "1 file rejected" : rejected + " files rejected");
+    announce(root, parts.join(", "));
+  }
+
+  function removeItem(item) {
+    const root = item.closest("[data-pui-fileupload]");
+    const entry = entries.get(item);
+    const o = options(root);
+    const state = item.getAttribute("data-pui-fileupload-state");
+    const id = item.getAttribute("data-pui-fileupload-id") || entry?.id || "";
+
+    if (entry?.xhr) entry.xhr.abort();
+    if (entry?.thumb) URL.revokeObjectURL(entry.thumb);
+
+    // Uploads from this page are discarded on the server; persisted ones only leave the form
+    if (o.url && id && !item.hasAttribute("data-pui-fileupload-persisted")) {
+      request("DELETE", withID(o.url, id), {}).catch(() => {});
+      if (entry) localStorage.removeItem(storageKey(o.url, entry.file));
+    }
+
+    if (id) {
+      root
+        .querySelectorAll("[data-pui-fileupload-values] input")
+        .forEach((input) => input.value === id && input.remove());
+    }
+
+    if (!o.url && entry && state !== "invalid") {
+      const dt = new DataTransfer();
+      for (const file of picked.get(root)?.files || []) {
+        if (file !== entry.file) dt.items.add(file);
+      }
+      picked.set(root, dt);
+      syncInput(root);
+    }
+
+    const name = item.querySelector("[data-pui-fileupload-name]")?.textContent || "File";
+    item.remove();
+    announce(root, name + " removed");
+
+    root.dispatchEvent(
+      new CustomEvent("pui-fileupload:remove", { bubbles: true, detail: { id: id, name: name } }),
+    );
+
+    pump(root);
+  }
+
+  // Resumable upload protocol, see handler.go
+
+  function withID(url, id) {
+    return url + (url.includes("?") ? "&" : "?") + "id=" + encodeURIComponent(id);
+  }
+
+  function storageKey(url, file) {
+    return "pui-fileupload:" + url + ":" + [file.name, file.size, file.lastModified].join(":");
+  }
+
+  function request(method, url, headers, body, entry, onProgress) {
+    return new Promise((resolve, reject) => {
+      const xhr = new XMLHttpRequest();
+      xhr.open(method, url);
+      for (const name in headers) xhr.setRequestHeader(name, headers[name]);
+
+      if (onProgress) {
+        xhr.upload.addEventListener("progress", (e) => onProgress(e.loaded));
+      }
+
+      xhr.addEventListener("load", () => resolve(xhr));
+      xhr.addEventListener("error", () => reject(new Error("network")));
+      xhr.addEventListener("abort", () => reject(new Error("abort")));
+
+      if (entry) entry.xhr = xhr;
+      xhr.send(body ?? null);
+    });
+  }
+
+  function statusMessage(status) {
+    if (status === 413) return "File is too large";
+    if (status === 415) return "File type not allowed";
+    return "Upload failed";
+  }
+
+  async function resume(url, file) {
+    const key = storageKey(url, file);
+    const id = localStorage.getItem(key);
+    if (!id) return null;
+
+    try {
+      const res = await request("HEAD", withID(url, id), {});
+      if (res.status === 200) {
+        return { id: id, offset: parseInt(res.getResponseHeader("Upload-Offset")) || 0 };
+      }
+    } catch (_) {
+      return null;
+    }
+
+    localStorage.removeItem(key);
+    return null;
+  }
+
+  async function upload(root, item) {
+    const o = options(root);
+    const entry = entries.get(item);
+    const file = entry.file;
+    const key = storageKey(o.url, file);
+
+    setState(item, "uploading");
+
+    let session = await resume(o.url, file);
+
+    if (!session) {
+      const res = await request("POST", o.url, {
+        "Upload-Length": String(file.size),
+        "Upload-Name": encodeURIComponent(file.name),
+        "Upload-Type": file.type,
+      });
+      if (res.status !== 201) throw { status: res.status };
+
+      session = { id: res.getResponseHeader("Upload-ID"), offset: 0 };
+      localStorage.setItem(key, session.id);
+    }
+
+    entry.id = session.id;
+    let offset = session.offset;
+    setProgress(item, file.size ? offset / file.size : 1);
+
+    while (offset < file.size) {
+      const chunk = file.slice(offset, offset + o.chunkSize);
+      const start = offset;
+      const res = await request(
+        "PATCH",
+        withID(o.url, entry.id),
+        {
+          "Upload-Offset": String(offset),
+          "Content-Type": "application/offset+octet-stream",
+        },
+        chunk,
+        entry,
+        (loaded) => setProgress(item, (start + loaded) / file.size),
+      );
+
+      const next = parseInt(res.getResponseHeader("Upload-Offset"));
+      if (res.status === 409 && !isNaN(next)) {
+        offset = next;
+        continue;
+      }
+      if (res.status !== 204) throw { status: res.status };
+
+      offset = isNaN(next) ? offset + chunk.size : next;
+    }
+
+    entry.xhr = null;
+    localStorage.removeItem(key);
+    return entry.id;
+  }
+
+  async function run(root, item) {
+    const o = options(root);
+    const entry = entries.get(item);
+    let attempt = 0;
+
+    for (;;) {
+      try {
+        const id = await upload(root, item);
+        if (!item.isConnected) return;
+
+        item.setAttribute("data-pui-fileupload-id", id);
+        setProgress(item, 1);
+        setState(item, "done");
+        addValue(root, o, id);
+        announce(root, entry.file.name + " uploaded");
+
+        root.dispatchEvent(
+          new CustomEvent("pui-fileupload:uploaded", {
+            bubbles: true,
+            detail: { id: id, file: entry.file },
+          }),
+        );
+        return;
+      } catch (err) {
+        if (!item.isConnected || err?.message === "abort") return;
+
+        // Network failures retry with backoff and resume where the server stopped
+        if (err?.message === "network" && ++attempt <= RETRIES) {
+          await new Promise((resolve) => setTimeout(resolve, attempt * 1000));
+          continue;
+        }
+
+        const message = err?.status ? statusMessage(err.status) : "Connection lost";
+        setState(item, "error", message);
+        announce(root, entry.file.name + ": " + message);
+
+        root.dispatchEvent(
+          new CustomEvent("pui-fileupload:error", {
+            bubbles: true,
+            detail: { file: entry.file, status: err?.status || 0 },
+          }),
+        );
+        return;
+      }
+    }
+  }
+
+  function addValue(root, o, id) {
+    const values = root.querySelector("[data-pui-fileupload-values]");
+    if (!values) return;
+
+    const input = document.createElement("input");
+    input.type = "hidden";
+    input.name = o.name;
+    input.value = id;
+    if (o.form) input.setAttribute("form", o.form);
+    values.appendChild(input);
+  }
+
+  function pump(root) {
+    const all = items(root);
+    let active = all.filter((item) => item.getAttribute("data-pui-fileupload-state") === "uploading")
+      .length;
+
+    for (const item of all) {
+      if (active >= PARALLEL) break;
+      if (item.getAttribute("data-pui-fileupload-state") !== "queued") continue;
+
+      active++;
+      run(root, item).finally(() => pump(root));
+    }
+  }
+
+  function pending(root) {
+    return items(root).some((item) => {
+      const state = item.getAttribute("data-pui-fileupload-state");
+      return state === "queued" || state === "uploading";
+    });
+  }
+
+  document.addEventListener("change", (e) => {
+    const input = e.target.closest?.("[data-pui-fileupload-input]");
+    if (!input) return;
+
+    const root = input.closest("[data-pui-fileupload]");
+    if (!root) return;
+
+    // The browser replaced the selection; the list keeps everything picked so far
+    const files = Array.from(input.files || []);
+    syncInput(root);
+    if (files.length) addFiles(root, files);
+  });
+
+  document.addEventListener("click", (e) => {
+    const remove = e.target.closest?.("[data-pui-fileupload-remove]");
+    if (remove) {
+      const item = remove.closest("[data-pui-fileupload-item]");
+      if (item) removeItem(item);
+      return;
+    }
+
+    const retry = e.target.closest?.("[data-pui-fileupload-retry]");
+    if (retry) {
+      const item = retry.closest("[data-pui-fileupload-item]");
+      const root = item?.closest("[data-pui-fileupload]");
+      if (!root) return;
+
+      setState(item, "queued");
+      pump(root);
+    }
+  });
+
+  function dropzoneOf(e) {
+    const zone = e.target.closest?.("[data-pui-fileupload-dropzone]");
+    if (!zone || !e.dataTransfer?.types.includes("Files")) return null;
+
+    const input = zone.querySelector("[data-pui-fileupload-input]");
+    return input && !input.disabled ? zone : null;
+  }
+
+  document.addEventListener("dragover", (e) => {
+    const zone = dropzoneOf(e);
+    if (!zone) return;
+
+    e.preventDefault();
+    e.dataTransfer.dropEffect = "copy";
+    zone.setAttribute("data-pui-fileupload-dragging", "true");
+  });
+
+  document.addEventListener("dragleave", (e) => {
+    const zone = e.target.closest?.("[data-pui-fileupload-dropzone]");
+    if (zone && !zone.contains(e.relatedTarget)) {
+      zone.removeAttribute("data-pui-fileupload-dragging");
+    }
+  });
+
+  document.addEventListener("drop", (e) => {
+    const zone = dropzoneOf(e);
+    if (!zone) return;
+
+    e.preventDefault();
+    zone.removeAttribute("data-pui-fileupload-dragging");
+
+    const root = zone.closest("[data-pui-fileupload]");
+    if (root) addFiles(root, Array.from(e.dataTransfer.files));
+  });
+
+  // Hold back submission until every upload has finished
+  document.addEventListener("submit", (e) => {
+    const form = e.target;
+    const roots = Array.from(document.querySelectorAll("[data-pui-fileupload-url]")).filter(
+      (root) => root.closest("form") === form || options(root).form === form.id,
+    );
+
+    const busy = roots.find(pending);
+    if (!busy) return;
+
+    e.preventDefault();
+    announce(busy, "Wait for the uploads to finish before submitting");
+    busy.querySelector("[data-pui-fileupload-input]")?.focus();
+  });
+
+  // Expose public API
+  window.tui = window.tui || {};
+  window.tui.fileupload = {
+    add: (root, files) => addFiles(root, Array.from(files)),
+    pending: pending,
+    values: (root) =>
+      Array.from(root.querySelectorAll("[data-pui-fileupload-values] input")).map((i) => i.value),
+  };
+})();
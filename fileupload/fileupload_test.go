@@ -0,0 +1,46 @@
+package fileupload
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/plainkit/html"
+)
+
+func TestCheckContent(t *testing.T) {
+	png := []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+	page := []byte("<!DOCTYPE html><html><body>hi</body></html>")
+
+	tests := []struct {
+		name   string
+		accept []string
+		file   string
+		head   []byte
+		err    error
+	}{
+		{name: "png by extension", accept: []string{".png"}, file: "photo.png", head: png},
+		{name: "renamed html by extension", accept: []string{".png"}, file: "photo.png", head: page, err: ErrType},
+		{name: "renamed html by family", accept: []string{"image/*"}, file: "photo.png", head: page, err: ErrType},
+		{name: "same family", accept: []string{".jpg"}, file: "photo.jpg", head: png},
+		{name: "other family", accept: []string{".pdf"}, file: "doc.pdf", head: png, err: ErrType},
+		{name: "unknown content", accept: []string{".png"}, file: "photo.png", head: []byte{0, 1, 2, 3}},
+		{name: "no accept", file: "page.html", head: page},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := CheckContent(Props{Accept: tt.accept}, tt.file, tt.head); !errors.Is(err, tt.err) {
+				t.Errorf("CheckContent(%q) = %v, want %v", tt.file, err, tt.err)
+			}
+		})
+	}
+}
+
+func TestDescribeMalformedAccept(t *testing.T) {
+	got := Props{Accept: []string{"/*", ".", "image/*"}}.describe()
+	if want := "Images"; got != want {
+		t.Errorf("describe() = %q, want %q", got, want)
+	}
+
+	html.Render(FileUpload(Props{Accept: []string{"/*"}}))
+}
@@ -0,0 +1,200 @@
+package fileupload
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// Protocol between the client runtime and Handler. An upload is created with a POST
+// announcing its length, name and type, resumed by asking for its offset with HEAD,
+// fed with PATCH requests carrying one chunk each, and cancelled with DELETE.
+const (
+	IDParam      = "id"            // Query parameter naming the upload for HEAD, PATCH and DELETE
+	HeaderID     = "Upload-ID"     // ID assigned by POST
+	HeaderOffset = "Upload-Offset" // Bytes received so far, or where a PATCH chunk starts
+	HeaderLength = "Upload-Length" // Total size of the file
+	HeaderName   = "Upload-Name"   // URL-encoded file name
+	HeaderType   = "Upload-Type"   // MIME type reported by the browser
+)
+
+// Handler serves Props.UploadURL, storing chunks in store. Every upload is checked
+// against p's Accept and MaxSize when created and its content sniffed once complete;
+// MaxFiles and Required apply to the submitted form and are checked by ParseUploads.
+func Handler(store Storage, p Props) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Cache-Control", "no-store")
+
+		switch r.Method {
+		case http.MethodPost:
+			create(w, r, store, p)
+		case http.MethodHead:
+			info, err := store.Stat(r.Context(), r.URL.Query().Get(IDParam))
+			if err != nil {
+				fail(w, err)
+				return
+			}
+
+			w.Header().Set(HeaderOffset, strconv.FormatInt(info.Offset, 10))
+			w.Header().Set(HeaderLength, strconv.FormatInt(info.Size, 10))
+			w.WriteHeader(http.StatusOK)
+		case http.MethodPatch:
+			appendChunk(w, r, store, p)
+		case http.MethodDelete:
+			if err := store.Delete(r.Context(), r.URL.Query().Get(IDParam)); err != nil {
+				fail(w, err)
+				return
+			}
+
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.Header().Set("Allow", "POST, HEAD, PATCH, DELETE")
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		}
+	})
+}
+
+func create(w http.ResponseWriter, r *http.Request, store Storage, p Props) {
+	size, err := strconv.ParseInt(r.Header.Get(HeaderLength), 10, 64)
+	if err != nil || size < 0 {
+		http.Error(w, "invalid "+HeaderLength, http.StatusBadRequest)
+		return
+	}
+
+	name, err := url.PathUnescape(r.Header.Get(HeaderName))
+	if err != nil || name == "" {
+		http.Error(w, "invalid "+HeaderName, http.StatusBadRequest)
+		return
+	}
+
+	info := Info{Name: name, Type: r.Header.Get(HeaderType), Size: size}
+	if err := Check(p, info.Name, info.Type, info.Size); err != nil {
+		fail(w, err)
+		return
+	}
+
+	info, err = store.Create(r.Context(), info)
+	if err != nil {
+		fail(w, err)
+		return
+	}
+
+	w.Header().Set(HeaderID, info.ID)
+	w.Header().Set(HeaderOffset, "0")
+	w.WriteHeader(http.StatusCreated)
+}
+
+func appendChunk(w http.ResponseWriter, r *http.Request, store Storage, p Props) {
+	offset, err := strconv.ParseInt(r.Header.Get(HeaderOffset), 10, 64)
+	if err != nil || offset < 0 {
+		http.Error(w, "invalid "+HeaderOffset, http.StatusBadRequest)
+		return
+	}
+
+	id := r.URL.Query().Get(IDParam)
+
+	info, err := store.Append(r.Context(), id, offset, r.Body)
+
+	// Only a chunk overflowing an upload in progress discards it; a complete upload is
+	// kept whatever a retried request carries
+	if errors.Is(err, ErrTooLarge) && offset < info.Size {
+		_ = store.Delete(r.Context(), id)
+
+		fail(w, err)
+
+		return
+	}
+
+	if err != nil {
+		// Tell the client where to continue after a mismatch or a partial write
+		if info.ID != "" {
+			w.Header().Set(HeaderOffset, strconv.FormatInt(info.Offset, 10))
+		}
+
+		fail(w, err)
+
+		return
+	}
+
+	if info.Complete() {
+		if err := verify(r, store, p, info); err != nil {
+			_ = store.Delete(r.Context(), id)
+
+			fail(w, err)
+
+			return
+		}
+	}
+
+	w.Header().Set(HeaderOffset, strconv.FormatInt(info.Offset, 10))
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// verify sniffs a completed upload so a renamed file cannot slip past Accept.
+func verify(r *http.Request, store Storage, p Props, info Info) error {
+	f, err := store.Open(r.Context(), info.ID)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	head, err := io.ReadAll(io.LimitReader(f, 512))
+	if err != nil {
+		return err
+	}
+
+	return CheckContent(p, info.Name, head)
+}
+
+func fail(w http.ResponseWriter, err error) {
+	status := http.StatusInternalServerError
+
+	switch {
+	case errors.Is(err, ErrNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, ErrOffset):
+		status = http.StatusConflict
+	case errors.Is(err, ErrTooLarge):
+		status = http.StatusRequestEntityTooLarge
+	case errors.Is(err, ErrType):
+		status = http.StatusUnsupportedMediaType
+	}
+
+	http.Error(w, http.StatusText(status), status)
+}
+
+// ParseUploads reads the upload IDs submitted under p.Name by a FileUpload with UploadURL
+// and returns the completed uploads, checking them like the client and Handler do.
+func ParseUploads(r *http.Request, p Props, store Storage) ([]Info, error) {
+	if err := r.ParseForm(); err != nil {
+		return nil, err
+	}
+
+	ids := r.Form[p.Name]
+	if err := p.checkCount(len(ids)); err != nil {
+		return nil, err
+	}
+
+	infos := make([]Info, 0, len(ids))
+
+	for _, id := range ids {
+		info, err := store.Stat(r.Context(), id)
+		if err != nil {
+			return nil, err
+		}
+
+		if !info.Complete() {
+			return nil, ErrIncomplete
+		}
+
+		if err := Check(p, info.Name, info.Type, info.Size); err != nil {
+			return nil, err
+		}
+
+		infos = append(infos, info)
+	}
+
+	return infos, nil
+}
@@ -0,0 +1,235 @@
+package fileupload
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+var (
+	// ErrNotFound is returned by a Storage for an unknown upload ID.
+	ErrNotFound = errors.New("fileupload: upload not found")
+	// ErrOffset is returned by Storage.Append when the offset differs from the bytes received so far.
+	ErrOffset = errors.New("fileupload: offset does not match the upload")
+)
+
+// Info describes an upload in progress or completed.
+type Info struct {
+	ID     string `json:"id"`
+	Name   string `json:"name"`
+	Type   string `json:"type"`
+	Size   int64  `json:"size"`   // Total bytes announced by the client
+	Offset int64  `json:"offset"` // Bytes received so far
+}
+
+// Complete reports whether all bytes of the upload were received.
+func (i Info) Complete() bool {
+	return i.Offset == i.Size
+}
+
+// Storage keeps the bytes of resumable uploads. Implementations must be safe for
+// concurrent use; Handler serializes nothing on their behalf.
+type Storage interface {
+	// Create registers a new upload for info.Name, Type and Size and assigns its ID.
+	Create(ctx context.Context, info Info) (Info, error)
+	// Stat returns the upload with its current Offset, or ErrNotFound.
+	Stat(ctx context.Context, id string) (Info, error)
+	// Append writes r at offset and returns the updated upload. It fails with ErrOffset
+	// when offset is not the current Offset or the upload is already complete, and with
+	// ErrTooLarge when r exceeds Size.
+	Append(ctx context.Context, id string, offset int64, r io.Reader) (Info, error)
+	// Open returns the bytes received so far.
+	Open(ctx context.Context, id string) (io.ReadCloser, error)
+	// Delete removes the upload and its bytes.
+	Delete(ctx context.Context, id string) error
+}
+
+// LocalStorage stores uploads as files in a directory: the bytes in <id> and the
+// metadata in <id>.json. The received offset is the size of the data file, so an
+// upload survives a restart of the process.
+type LocalStorage struct {
+	dir   string
+	locks sync.Map // id -> *sync.Mutex
+}
+
+// NewLocalStorage returns a LocalStorage writing to dir, creating it if needed.
+func NewLocalStorage(dir string) (*LocalStorage, error) {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, err
+	}
+
+	return &LocalStorage{dir: dir}, nil
+}
+
+// Path returns the location of an upload's bytes, e.g. to move a completed file into place.
+func (s *LocalStorage) Path(id string) (string, error) {
+	if !validID(id) {
+		return "", ErrNotFound
+	}
+
+	return filepath.Join(s.dir, id), nil
+}
+
+func (s *LocalStorage) Create(_ context.Context, info Info) (Info, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return Info{}, err
+	}
+
+	info.ID = hex.EncodeToString(buf)
+	info.Offset = 0
+
+	meta, err := json.Marshal(info)
+	if err != nil {
+		return Info{}, err
+	}
+
+	if err := os.WriteFile(filepath.Join(s.dir, info.ID+".json"), meta, 0o640); err != nil {
+		return Info{}, err
+	}
+
+	f, err := os.OpenFile(filepath.Join(s.dir, info.ID), os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o640)
+	if err != nil {
+		return Info{}, err
+	}
+
+	return info, f.Close()
+}
+
+func (s *LocalStorage) Stat(_ context.Context, id string) (Info, error) {
+	if !validID(id) {
+		return Info{}, ErrNotFound
+	}
+
+	meta, err := os.ReadFile(filepath.Join(s.dir, id+".json"))
+	if errors.Is(err, fs.ErrNotExist) {
+		return Info{}, ErrNotFound
+	} else if err != nil {
+		return Info{}, err
+	}
+
+	var info Info
+	if err := json.Unmarshal(meta, &info); err != nil {
+		return Info{}, err
+	}
+
+	fi, err := os.Stat(filepath.Join(s.dir, id))
+	if errors.Is(err, fs.ErrNotExist) {
+		return Info{}, ErrNotFound
+	} else if err != nil {
+		return Info{}, err
+	}
+
+	info.ID = id
+	info.Offset = fi.Size()
+
+	return info, nil
+}
+
+func (s *LocalStorage) Append(ctx context.Context, id string, offset int64, r io.Reader) (Info, error) {
+	// Only known uploads get a lock, so made-up IDs cannot fill the map
+	if _, err := s.Stat(ctx, id); err != nil {
+		return Info{}, err
+	}
+
+	mu := s.lock(id)
+	mu.Lock()
+	defer mu.Unlock()
+
+	info, err := s.Stat(ctx, id)
+	if err != nil {
+		return Info{}, err
+	}
+
+	// A retried final chunk must not be read as bytes beyond Size
+	if info.Complete() {
+		s.locks.Delete(id)
+
+		return info, fmt.Errorf("%w: upload is already complete", ErrOffset)
+	}
+
+	if offset != info.Offset {
+		return info, fmt.Errorf("%w: expected %d, got %d", ErrOffset, info.Offset, offset)
+	}
+
+	f, err := os.OpenFile(filepath.Join(s.dir, id), os.O_WRONLY|os.O_APPEND, 0o640)
+	if err != nil {
+		return Info{}, err
+	}
+	defer f.Close()
+
+	// Bytes written before a dropped connection are kept so the client can resume after them
+	n, err := io.Copy(f, io.LimitReader(r, info.Size-info.Offset))
+	info.Offset += n
+
+	if err != nil {
+		return info, err
+	}
+
+	if extra, _ := r.Read(make([]byte, 1)); extra > 0 {
+		return info, fmt.Errorf("%w: more than the announced %d bytes", ErrTooLarge, info.Size)
+	}
+
+	if info.Complete() {
+		s.locks.Delete(id)
+	}
+
+	return info, nil
+}
+
+func (s *LocalStorage) Open(_ context.Context, id string) (io.ReadCloser, error) {
+	if !validID(id) {
+		return nil, ErrNotFound
+	}
+
+	f, err := os.Open(filepath.Join(s.dir, id))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+
+	return f, err
+}
+
+func (s *LocalStorage) Delete(ctx context.Context, id string) error {
+	if _, err := s.Stat(ctx, id); err != nil {
+		return err
+	}
+
+	mu := s.lock(id)
+	mu.Lock()
+	defer mu.Unlock()
+	defer s.locks.Delete(id)
+
+	err := os.Remove(filepath.Join(s.dir, id))
+	if errors.Is(err, fs.ErrNotExist) {
+		return ErrNotFound
+	} else if err != nil {
+		return err
+	}
+
+	return os.Remove(filepath.Join(s.dir, id+".json"))
+}
+
+func (s *LocalStorage) lock(id string) *sync.Mutex {
+	mu, _ := s.locks.LoadOrStore(id, &sync.Mutex{})
+	return mu.(*sync.Mutex)
+}
+
+// validID keeps IDs to the hex strings Create generates so they cannot escape the directory.
+func validID(id string) bool {
+	if len(id) != 32 {
+		return false
+	}
+
+	_, err := hex.DecodeString(id)
+
+	return err == nil
+}
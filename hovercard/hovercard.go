@@ -0,0 +1,194 @@
+// Package hovercard shows rich previews, such as a user profile or a link preview, when
+// the pointer rests on a trigger. Cards open and close after a delay, stay open while
+// the pointer crosses the gap towards them, and open on the first tap on touch screens,
+// where the second tap follows the trigger's link.
+package hovercard
+
+import (
+	_ "embed"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/plainkit/html"
+	"github.com/plainkit/ui/internal/assets"
+	"github.com/plainkit/ui/popover"
+	"github.com/plainkit/ui/skeleton"
+)
+
+type TriggerProps struct {
+	ID    string
+	Class string
+	Attrs []html.Global
+	For   string // ID of the card
+}
+
+type ContentProps struct {
+	ID         string
+	Class      string
+	Attrs      []html.Global
+	Placement  popover.Placement // Default popover.PlacementBottom
+	ShowArrow  bool
+	OpenDelay  int    // Milliseconds the pointer rests on the trigger before the card opens (default 500)
+	CloseDelay int    // Milliseconds after leaving before the card closes (default 300)
+	URL        string // Loads the card body from URL the first time it opens, see Handler
+	ErrorText  string // Shown when loading fails (default "Could not load this preview.")
+	Native     bool   // Shows the card in the top layer, see popover.ContentProps.Native
+}
+
+func (p TriggerProps) ApplySpan(attrs *html.SpanAttrs, children *[]html.Component) {
+	p.popover().ApplySpan(attrs, children)
+}
+
+// popover maps the props onto the popover trigger hovercard.js drives.
+func (p TriggerProps) popover() popover.TriggerProps {
+	return popover.TriggerProps{
+		ID:          p.ID,
+		Class:       p.Class,
+		Attrs:       append([]html.Global{html.AData("pui-hovercard-trigger", p.For)}, p.Attrs...),
+		For:         p.For,
+		TriggerType: popover.TriggerTypeManual,
+	}
+}
+
+// Trigger wraps the element previewed by a card, usually a link or a mention chip.
+func Trigger(args ...html.SpanArg) html.Node {
+	var (
+		props TriggerProps
+		rest  []html.SpanArg
+	)
+
+	for _, a := range args {
+		if v, ok := a.(TriggerProps); ok {
+			props = v
+		} else {
+			rest = append(rest, a)
+		}
+	}
+
+	return popover.Trigger(append([]html.SpanArg{props.popover()}, rest...)...)
+}
+
+func (p ContentProps) ApplyDiv(attrs *html.DivAttrs, children *[]html.Component) {
+	p.popover().ApplyDiv(attrs, children)
+}
+
+// popover maps the props onto the popover panel hosting the card.
+func (p ContentProps) popover() popover.ContentProps {
+	placement := p.Placement
+	if placement == "" {
+		placement = popover.PlacementBottom
+	}
+
+	attrs := []html.Global{
+		html.AData("pui-hovercard", ""),
+		html.AData("pui-hovercard-open-delay", strconv.Itoa(delay(p.OpenDelay, 500))),
+		html.AData("pui-hovercard-close-delay", strconv.Itoa(delay(p.CloseDelay, 300))),
+	}
+
+	return popover.ContentProps{
+		ID:        p.ID,
+		Class:     html.ClassMerge("w-80 p-4", p.Class),
+		Attrs:     append(attrs, p.Attrs...),
+		Placement: placement,
+		ShowArrow: p.ShowArrow,
+		Native:    p.Native,
+	}
+}
+
+// Content renders the card. With a URL, children are shown until the body loads, or
+// Placeholder when there are none.
+func Content(args ...html.DivArg) html.Node {
+	var (
+		props ContentProps
+		rest  []html.DivArg
+	)
+
+	for _, a := range args {
+		if v, ok := a.(ContentProps); ok {
+			props = v
+		} else {
+			rest = append(rest, a)
+		}
+	}
+
+	if props.URL != "" {
+		if len(rest) == 0 {
+			rest = append(rest, Placeholder())
+		}
+
+		rest = []html.DivArg{html.Div(append([]html.DivArg{
+			html.AData("pui-hovercard-body", ""),
+			html.AData("pui-hovercard-url", props.URL),
+			html.AData("pui-hovercard-error", errorText(props.ErrorText)),
+			html.AAria("busy", "true"),
+		}, rest...)...)}
+	}
+
+	rest = append(rest, assets.Script("ui-hovercard", hovercardJS))
+
+	return popover.Content(append([]html.DivArg{props.popover()}, rest...)...)
+}
+
+// Placeholder renders a skeleton shaped like a profile preview: an avatar, a name and a
+// few lines of text.
+func Placeholder() html.Node {
+	return html.Div(
+		html.AClass("flex gap-4"),
+		html.AAria("hidden", "true"),
+		skeleton.Skeleton(skeleton.Props{Class: "size-12 shrink-0 rounded-full"}),
+		html.Div(
+			html.AClass("flex-1 space-y-2"),
+			skeleton.Skeleton(skeleton.Props{Class: "h-4 w-1/2"}),
+			skeleton.Skeleton(skeleton.Props{Class: "h-3 w-full"}),
+			skeleton.Skeleton(skeleton.Props{Class: "h-3 w-3/4"}),
+		),
+	)
+}
+
+// ErrNotFound makes Handler answer 404 Not Found, for previews of deleted entities.
+var ErrNotFound = errors.New("hovercard: preview not found")
+
+// BodyFunc renders the body of a card requested through ContentProps.URL.
+type BodyFunc func(r *http.Request) (html.Node, error)
+
+// Handler serves card bodies for ContentProps.URL. Responses are cached privately for a
+// minute, so cards previewing the same entity on other pages reuse them.
+func Handler(fn BodyFunc) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		node, err := fn(r)
+		if errors.Is(err, ErrNotFound) {
+			http.NotFound(w, r)
+			return
+		}
+
+		if err != nil {
+			http.Error(w, "could not load the preview", http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Header().Set("Cache-Control", "private, max-age=60")
+
+		_, _ = w.Write([]byte(html.Render(node)))
+	})
+}
+
+func delay(ms, fallback int) int {
+	if ms <= 0 {
+		return fallback
+	}
+
+	return ms
+}
+
+func errorText(text string) string {
+	if text == "" {
+		return "Could not load this preview."
+	}
+
+	return text
+}
+
+//go:embed hovercard.js
+var hovercardJS string
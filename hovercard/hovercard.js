@@ -0,0 +1,251 @@
+(function () {
+  "use strict";
+
+  // Pixels the safe triangle's tip sits behind the point where the pointer left the
+  // trigger, so jitter along the trigger's edge does not count as leaving it
+  const TIP_PADDING = 6;
+
+  // Pending open and close timeouts by card ID
+  const timers = new Map();
+
+  // Bodies keep their initial markup, restored as the placeholder before a retry
+  const placeholders = new WeakMap();
+
+  // Set while the pointer travels from a trigger towards its open card
+  let grace = null;
+
+  // Whether the last pointer was a finger, for tap-to-open
+  let touch = false;
+
+  function popover() {
+    return window.tui && window.tui.popover;
+  }
+
+  function cardFor(id) {
+    const el = document.getElementById(id);
+    return el && el.hasAttribute("data-pui-hovercard") ? el : null;
+  }
+
+  function triggerOf(el) {
+    return el instanceof Element ? el.closest("[data-pui-hovercard-trigger]") : null;
+  }
+
+  function isOpen(id) {
+    return !!popover()?.isOpen(id);
+  }
+
+  function delay(card, name) {
+    return parseInt(card.getAttribute("data-pui-hovercard-" + name + "-delay"), 10) || 0;
+  }
+
+  // Whether node is the card with id, or one of its triggers
+  function within(id, node) {
+    if (!(node instanceof Node)) return false;
+    const card = cardFor(id);
+    return (!!card && card.contains(node)) || triggerOf(node)?.getAttribute("data-pui-hovercard-trigger") === id;
+  }
+
+  function cancel(id) {
+    const t = timers.get(id);
+    if (!t) return;
+    clearTimeout(t);
+    timers.delete(id);
+  }
+
+  function schedule(id, ms, fn) {
+    cancel(id);
+    timers.set(
+      id,
+      setTimeout(() => {
+        timers.delete(id);
+        fn();
+      }, ms),
+    );
+  }
+
+  function show(id) {
+    const card = cardFor(id);
+    if (!card || isOpen(id)) return;
+
+    popover()?.open(id);
+    load(card);
+  }
+
+  function open(id, now) {
+    const card = cardFor(id);
+    if (!card) return;
+
+    cancel(id);
+    if (now) show(id);
+    else if (!isOpen(id)) schedule(id, delay(card, "open"), () => show(id));
+  }
+
+  function close(id, now) {
+    const card = cardFor(id);
+    if (!card) return;
+
+    if (grace && grace.id === id) grace = null;
+    cancel(id);
+    if (now) popover()?.close(id);
+    else if (isOpen(id)) schedule(id, delay(card, "close"), () => popover()?.close(id));
+  }
+
+  // Fetch the body the first time the card opens. A failed load shows the error and is
+  // retried on the next open.
+  function load(card) {
+    const body = card.querySelector("[data-pui-hovercard-body][data-pui-hovercard-url]");
+    if (!body || body.hasAttribute("data-pui-hovercard-loaded")) return;
+
+    if (!placeholders.has(body)) placeholders.set(body, body.innerHTML);
+    else body.innerHTML = placeholders.get(body);
+
+    body.setAttribute("data-pui-hovercard-loaded", "");
+    body.setAttribute("aria-busy", "true");
+
+    fetch(body.getAttribute("data-pui-hovercard-url"), { headers: { Accept: "text/html" } })
+      .then((res) => {
+        if (!res.ok) throw new Error("hovercard: " + res.status);
+        return res.text();
+      })
+      .then((markup) => {
+        body.innerHTML = markup;
+        if (window.htmx) window.htmx.process(body);
+        body.dispatchEvent(new CustomEvent("pui-hovercard:load", { bubbles: true }));
+      })
+      .catch(() => {
+        body.removeAttribute("data-pui-hovercard-loaded");
+        body.replaceChildren();
+        const p = document.createElement("p");
+        p.className = "text-sm text-muted-foreground";
+        p.textContent = body.getAttribute("data-pui-hovercard-error");
+        body.appendChild(p);
+      })
+      .finally(() => body.removeAttribute("aria-busy"));
+  }
+
+  // The triangle from where the pointer left the trigger to the near edge of the card.
+  // While the pointer stays inside it, it is on its way to the card.
+  function safeTriangle(e, card) {
+    const r = card.getBoundingClientRect();
+    const x = e.clientX;
+    const y = e.clientY;
+
+    if (r.top >= y) return [[x, y - TIP_PADDING], [r.left, r.top], [r.right, r.top]];
+    if (r.bottom <= y) return [[x, y + TIP_PADDING], [r.left, r.bottom], [r.right, r.bottom]];
+    if (r.left >= x) return [[x - TIP_PADDING, y], [r.left, r.top], [r.left, r.bottom]];
+    return [[x + TIP_PADDING, y], [r.right, r.top], [r.right, r.bottom]];
+  }
+
+  function inTriangle(x, y, [a, b, c]) {
+    const side = (p, q) => (x - q[0]) * (p[1] - q[1]) - (p[0] - q[0]) * (y - q[1]);
+    const d1 = side(a, b);
+    const d2 = side(b, c);
+    const d3 = side(c, a);
+    return !((d1 < 0 || d2 < 0 || d3 < 0) && (d1 > 0 || d2 > 0 || d3 > 0));
+  }
+
+  document.addEventListener("pointerdown", (e) => {
+    touch = e.pointerType === "touch";
+  }, true);
+
+  document.addEventListener("pointerover", (e) => {
+    if (e.pointerType === "touch") return;
+
+    const trigger = triggerOf(e.target);
+    if (trigger && !trigger.contains(e.relatedTarget)) {
+      const id = trigger.getAttribute("data-pui-hovercard-trigger");
+      // Passing over other triggers on the way to a card opens nothing
+      if (grace && grace.id !== id) return;
+      grace = null;
+      open(id);
+      return;
+    }
+
+    const card = e.target.closest?.("[data-pui-hovercard]");
+    if (card && card.id) {
+      if (grace && grace.id === card.id) grace = null;
+      cancel(card.id);
+    }
+  });
+
+  document.addEventListener("pointerout", (e) => {
+    if (e.pointerType === "touch") return;
+
+    const trigger = triggerOf(e.target);
+    if (trigger && !trigger.contains(e.relatedTarget)) {
+      const id = trigger.getAttribute("data-pui-hovercard-trigger");
+      if (within(id, e.relatedTarget)) return;
+
+      const card = cardFor(id);
+      if (!card || !isOpen(id)) {
+        cancel(id);
+        return;
+      }
+
+      grace = { id: id, area: safeTriangle(e, card) };
+      return;
+    }
+
+    const card = e.target.closest?.("[data-pui-hovercard]");
+    if (card && card.id && !card.contains(e.relatedTarget) && !within(card.id, e.relatedTarget)) {
+      close(card.id);
+    }
+  });
+
+  document.addEventListener("pointermove", (e) => {
+    if (!grace || e.pointerType === "touch") return;
+
+    const id = grace.id;
+    if (!isOpen(id)) {
+      grace = null;
+    } else if (within(id, e.target)) {
+      grace = null;
+      cancel(id);
+    } else if (!inTriangle(e.clientX, e.clientY, grace.area)) {
+      close(id);
+    }
+  });
+
+  // Keyboard users get the card on focus, and keep it while tabbing into it
+  document.addEventListener("focusin", (e) => {
+    const trigger = triggerOf(e.target);
+    if (trigger && e.target.matches(":focus-visible")) {
+      open(trigger.getAttribute("data-pui-hovercard-trigger"));
+    }
+  });
+
+  document.addEventListener("focusout", (e) => {
+    const trigger = triggerOf(e.target);
+    const card = e.target.closest?.("[data-pui-hovercard]");
+    const id = trigger ? trigger.getAttribute("data-pui-hovercard-trigger") : card?.id;
+    if (id && !within(id, e.relatedTarget)) close(id);
+  });
+
+  // On touch screens the first tap opens the card and the second follows the link
+  document.addEventListener(
+    "click",
+    (e) => {
+      const trigger = triggerOf(e.target);
+      if (!trigger || !touch) return;
+
+      const id = trigger.getAttribute("data-pui-hovercard-trigger");
+      if (isOpen(id)) return;
+
+      e.preventDefault();
+      e.stopPropagation();
+      open(id, true);
+    },
+    true,
+  );
+
+  // Expose public API
+  window.tui = window.tui || {};
+  window.tui.hovercard = {
+    open: (id) => open(id, true),
+    close: (id) => close(id, true),
+    load: (id) => {
+      const card = cardFor(id);
+      if (card) load(card);
+    },
+  };
+})();
@@ -4,10 +4,14 @@ import (
 	"crypto/rand"
 	_ "embed"
 	"encoding/hex"
+	"errors"
+	"strconv"
+	"strings"
 
 	"github.com/plainkit/html"
 	"github.com/plainkit/icons/lucide"
 	"github.com/plainkit/ui/button"
+	"github.com/plainkit/ui/internal/counter"
 	"github.com/plainkit/ui/internal/styles"
 )
 
@@ -45,6 +49,29 @@ type Props struct {
 	FileAccept         string
 	HasError           bool
 	ShowPasswordToggle bool
+	Mask               Mask // Formats the value while typing; read submissions with Mask.Parse
+	MinLength          int
+	MaxLength          int
+	ShowCount          bool   // Renders a live "120 / 500" counter below the field
+	CountWords         bool   // Adds the word count to the counter
+	SoftLimit          bool   // Lets the value exceed MaxLength and marks it invalid, as HasError does, instead
+	ShowStrength       bool   // Password fields only: renders a strength meter based on Entropy
+	Policy             Policy // Password fields only: renders a live checklist of the rules
+}
+
+var (
+	ErrTooShort = errors.New("input: value is too short")
+	ErrTooLong  = errors.New("input: value is too long")
+)
+
+// CheckLength validates value against MinLength and MaxLength, counting characters the
+// way the browser does. Soft limits are checked too, since the browser lets them pass.
+func CheckLength(p Props, value string) error {
+	return counter.CheckLength(value, p.MinLength, p.MaxLength, ErrTooShort, ErrTooLong)
+}
+
+func (p Props) hasCounter() bool {
+	return p.ShowCount || (p.SoftLimit && p.MaxLength > 0)
 }
 
 func inputArgsFromProps(baseClass string, extra ...string) func(p Props) []html.InputArg {
@@ -54,6 +81,11 @@ func inputArgsFromProps(baseClass string, extra ...string) func(p Props) []html.
 			inputType = TypeText
 		}
 
+		// Number inputs cannot hold group separators or currency symbols
+		if inputType == TypeNumber && p.Mask.Number != nil {
+			inputType = TypeText
+		}
+
 		id := p.ID
 		if id == "" {
 			id = randomID()
@@ -139,6 +171,43 @@ func (p Props) ApplyInput(attrs *html.InputAttrs, children *[]html.Component) {
 		args = append(args, html.AAria("invalid", "true"))
 	}
 
+	if p.MinLength > 0 {
+		args = append(args, html.AMinlength(strconv.Itoa(p.MinLength)))
+	}
+
+	if p.MaxLength > 0 && !p.SoftLimit {
+		args = append(args, html.AMaxlength(strconv.Itoa(p.MaxLength)))
+	}
+
+	var describedBy []string
+
+	if p.hasCounter() {
+		describedBy = append(describedBy, counter.ID(p.ID))
+	}
+
+	if p.hasPasswordFeedback() {
+		describedBy = append(describedBy, passwordID(p.ID))
+
+		// Let the browser block short passwords too
+		if p.MinLength == 0 && p.Policy.MinLength > 0 {
+			args = append(args, html.AMinlength(strconv.Itoa(p.Policy.MinLength)))
+		}
+	}
+
+	if len(describedBy) > 0 {
+		args = append(args, html.AAria("describedby", strings.Join(describedBy, " ")))
+	}
+
+	if p.SoftLimit && counter.Over(p.Value, p.MaxLength) && !p.HasError {
+		args = append(args, html.AAria("invalid", "true"), html.AData("pui-counter-invalid", "true"))
+	}
+
+	if p.Mask.Enabled() {
+		for _, a := range p.Mask.attrs() {
+			args = append(args, a)
+		}
+	}
+
 	for _, a := range args {
 		a.ApplyInput(attrs, children)
 	}
@@ -180,11 +249,36 @@ func Input(args ...html.InputArg) html.Node {
 		divArgs = append(divArgs, html.Child(child))
 	}
 
+	if props.Mask.Enabled() {
+		divArgs = append(divArgs, html.Child(html.AssetHook("ui-input-mask", "", maskJS)))
+	}
+
 	node := html.Div(divArgs...)
 	if props.Type == TypePassword && props.ShowPasswordToggle {
 		node = node.WithAssets("", passwordToggleJS, "ui-input-toggle")
 	}
 
+	var extras []html.DivArg
+
+	if props.hasPasswordFeedback() {
+		extras = append(extras, passwordFeedback(props))
+	}
+
+	if props.hasCounter() {
+		extras = append(extras, counter.Counter(counter.Props{
+			For:   props.ID,
+			Value: props.Value,
+			Min:   props.MinLength,
+			Max:   props.MaxLength,
+			Words: props.CountWords,
+			Soft:  props.SoftLimit,
+		}))
+	}
+
+	if len(extras) > 0 {
+		return html.Div(append([]html.DivArg{html.AClass("w-full space-y-1.5"), node}, extras...)...)
+	}
+
 	return node
 }
 
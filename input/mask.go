@@ -0,0 +1,589 @@
+package input
+
+import (
+	_ "embed"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/plainkit/html"
+)
+
+var (
+	// ErrMaskMismatch is returned when a value contains characters the mask does not allow.
+	ErrMaskMismatch = errors.New("input: value does not match the mask")
+	// ErrMaskIncomplete is returned when a value fills fewer tokens than the mask requires.
+	ErrMaskIncomplete = errors.New("input: value is incomplete")
+)
+
+// Mask formats what the user types and describes how the submitted value is normalized.
+// The same rules run in the browser and in Normalize, so both sides agree.
+type Mask struct {
+	Pattern   string                 // Tokens: 9 digit, a letter, * letter or digit, \ escapes the next character; other characters are literals
+	MinLength int                    // Token characters required for a complete value (default: all of them)
+	Uppercase bool                   // Transform letters to upper case
+	Number    *NumberFormat          // Format as a grouped number instead of a pattern
+	Check     func(raw string) error // Extra server-side validation of the normalized value
+}
+
+// NumberFormat groups digits with the separators of a locale.
+type NumberFormat struct {
+	Locale   string // BCP 47 tag selecting the separators, e.g. "en-US" or "de-DE" (default "en")
+	Currency string // ISO 4217 code adding its symbol and precision, e.g. "EUR"
+	Decimals int    // Maximum fraction digits (defaults to the currency precision)
+	Negative bool   // Allow negative values
+	Group    string // Overrides the locale group separator
+	Decimal  string // Overrides the locale decimal separator
+}
+
+// Common masks.
+var (
+	MaskPhoneUS    = Mask{Pattern: "(999) 999-9999"}
+	MaskPostalUS   = Mask{Pattern: "99999"}
+	MaskPostalCA   = Mask{Pattern: "a9a 9a9", Uppercase: true}
+	MaskCreditCard = Mask{Pattern: "9999 9999 9999 9999 999", MinLength: 13, Check: checkLuhn}
+	MaskIBAN       = Mask{Pattern: "aa99 **** **** **** **** **** **** **", MinLength: 15, Uppercase: true, Check: checkIBAN}
+)
+
+// Enabled reports whether the mask changes the input at all.
+func (m Mask) Enabled() bool {
+	return m.Pattern != "" || m.Number != nil || m.Uppercase
+}
+
+// Normalize strips the mask from value and validates it. Pattern masks return the token
+// characters without literals, number masks a plain decimal such as "-1234.5".
+// An empty value is returned unchanged; use Required to demand one.
+func (m Mask) Normalize(value string) (string, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return "", nil
+	}
+
+	if m.Uppercase {
+		value = strings.ToUpper(value)
+	}
+
+	var (
+		raw string
+		err error
+	)
+
+	switch {
+	case m.Number != nil:
+		raw, err = m.Number.normalize(value)
+	case m.Pattern != "":
+		raw, err = m.normalizePattern(value)
+	default:
+		raw = value
+	}
+
+	if err != nil {
+		return "", err
+	}
+
+	if m.Check != nil {
+		if err := m.Check(raw); err != nil {
+			return "", err
+		}
+	}
+
+	return raw, nil
+}
+
+// Format applies the mask to a normalized value, e.g. to fill Props.Value.
+func (m Mask) Format(raw string) string {
+	if raw == "" {
+		return ""
+	}
+
+	if m.Uppercase {
+		raw = strings.ToUpper(raw)
+	}
+
+	switch {
+	case m.Number != nil:
+		return m.Number.format(raw)
+	case m.Pattern != "":
+		var sb strings.Builder
+
+		rs := []rune(raw)
+		i := 0
+
+		for _, t := range parsePattern(m.Pattern) {
+			if i >= len(rs) {
+				break
+			}
+
+			if t.literal {
+				sb.WriteRune(t.r)
+				continue
+			}
+
+			sb.WriteRune(rs[i])
+			i++
+		}
+
+		return sb.String()
+	default:
+		return raw
+	}
+}
+
+// Parse reads the value submitted under name and normalizes it.
+func (m Mask) Parse(r *http.Request, name string) (string, error) {
+	if err := r.ParseForm(); err != nil {
+		return "", err
+	}
+
+	return m.Normalize(r.Form.Get(name))
+}
+
+type maskToken struct {
+	r       rune
+	literal bool
+}
+
+func parsePattern(pattern string) []maskToken {
+	var (
+		tokens  []maskToken
+		escaped bool
+	)
+
+	for _, r := range pattern {
+		switch {
+		case escaped:
+			tokens = append(tokens, maskToken{r: r, literal: true})
+			escaped = false
+		case r == '\\':
+			escaped = true
+		case r == '9' || r == 'a' || r == '*':
+			tokens = append(tokens, maskToken{r: r})
+		default:
+			tokens = append(tokens, maskToken{r: r, literal: true})
+		}
+	}
+
+	return tokens
+}
+
+func (t maskToken) accepts(r rune) bool {
+	switch t.r {
+	case '9':
+		return r >= '0' && r <= '9'
+	case 'a':
+		return unicode.IsLetter(r)
+	default:
+		return unicode.IsLetter(r) || unicode.IsDigit(r)
+	}
+}
+
+// normalizePattern walks value along the pattern, consuming literals where they were typed
+// and skipping separators between tokens.
+func (m Mask) normalizePattern(value string) (string, error) {
+	var (
+		raw    []rune
+		tokens = parsePattern(m.Pattern)
+		rs     = []rune(value)
+		i      int
+		total  int
+	)
+
+	isSeparator := func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}
+
+	for _, t := range tokens {
+		if t.literal {
+			if i < len(rs) && rs[i] == t.r {
+				i++
+			}
+
+			continue
+		}
+
+		total++
+
+		for i < len(rs) && isSeparator(rs[i]) {
+			i++
+		}
+
+		if i >= len(rs) {
+			continue
+		}
+
+		if !t.accepts(rs[i]) {
+			return "", fmt.Errorf("%w: unexpected %q", ErrMaskMismatch, rs[i])
+		}
+
+		raw = append(raw, rs[i])
+		i++
+	}
+
+	for i < len(rs) && isSeparator(rs[i]) {
+		i++
+	}
+
+	if i < len(rs) {
+		return "", fmt.Errorf("%w: too long", ErrMaskMismatch)
+	}
+
+	required := m.MinLength
+	if required <= 0 || required > total {
+		required = total
+	}
+
+	if len(raw) < required {
+		return "", ErrMaskIncomplete
+	}
+
+	return string(raw), nil
+}
+
+// separators resolves the group and decimal separators and the currency affixes.
+func (f NumberFormat) separators() (group, decimal, prefix, suffix string) {
+	locale := f.Locale
+	if locale == "" {
+		locale = "en"
+	}
+
+	lang, _, _ := strings.Cut(locale, "-")
+
+	seps, ok := localeSeparators[locale]
+	if !ok {
+		seps, ok = localeSeparators[lang]
+	}
+
+	if !ok {
+		seps = localeSeparators["en"]
+	}
+
+	group, decimal = seps[0], seps[1]
+
+	if f.Group != "" {
+		group = f.Group
+	}
+
+	if f.Decimal != "" {
+		decimal = f.Decimal
+	}
+
+	if f.Currency != "" {
+		symbol, ok := currencySymbols[strings.ToUpper(f.Currency)]
+		if !ok {
+			symbol = strings.ToUpper(f.Currency)
+		}
+
+		if symbolFirst[lang] {
+			prefix = symbol
+		} else {
+			suffix = "\u00a0" + symbol
+		}
+	}
+
+	return group, decimal, prefix, suffix
+}
+
+// Precision returns the maximum number of fraction digits, defaulting to the currency precision.
+func (f NumberFormat) Precision() int {
+	if f.Decimals > 0 || f.Currency == "" {
+		return f.Decimals
+	}
+
+	if zeroDecimalCurrencies[strings.ToUpper(f.Currency)] {
+		return 0
+	}
+
+	return 2
+}
+
+func (f NumberFormat) normalize(value string) (string, error) {
+	group, decimal, prefix, suffix := f.separators()
+
+	// Accept the sign on either side of the currency symbol
+	raw := strings.TrimSpace(value)
+	negative := strings.HasPrefix(raw, "-")
+	raw = strings.TrimPrefix(raw, "-")
+
+	if prefix != "" {
+		raw = strings.TrimSpace(strings.TrimPrefix(raw, prefix))
+	}
+
+	if suffix != "" {
+		raw = strings.TrimSpace(strings.TrimSuffix(raw, strings.TrimSpace(suffix)))
+	}
+
+	if strings.HasPrefix(raw, "-") {
+		negative = true
+		raw = raw[1:]
+	}
+
+	if negative && !f.Negative {
+		return "", fmt.Errorf("%w: negative values are not allowed", ErrMaskMismatch)
+	}
+
+	var (
+		intPart, fracPart strings.Builder
+		seenDecimal       bool
+	)
+
+	for i := 0; i < len(raw); {
+		switch {
+		case decimal != "" && strings.HasPrefix(raw[i:], decimal):
+			if seenDecimal {
+				return "", fmt.Errorf("%w: repeated decimal separator", ErrMaskMismatch)
+			}
+
+			seenDecimal = true
+			i += len(decimal)
+		case !seenDecimal && group != "" && strings.HasPrefix(raw[i:], group):
+			i += len(group)
+		case raw[i] >= '0' && raw[i] <= '9':
+			if seenDecimal {
+				fracPart.WriteByte(raw[i])
+			} else {
+				intPart.WriteByte(raw[i])
+			}
+
+			i++
+		default:
+			r := []rune(raw[i:])[0]
+			if !unicode.IsSpace(r) {
+				return "", fmt.Errorf("%w: unexpected %q", ErrMaskMismatch, r)
+			}
+
+			i += len(string(r))
+		}
+	}
+
+	if fracPart.Len() > f.Precision() {
+		return "", fmt.Errorf("%w: at most %d decimals", ErrMaskMismatch, f.Precision())
+	}
+
+	digits := strings.TrimLeft(intPart.String(), "0")
+	if digits == "" {
+		digits = "0"
+	}
+
+	if intPart.Len() == 0 && fracPart.Len() == 0 {
+		return "", fmt.Errorf("%w: no digits", ErrMaskMismatch)
+	}
+
+	out := digits
+	if fracPart.Len() > 0 {
+		out += "." + fracPart.String()
+	}
+
+	if negative && strings.Trim(out, "0.") != "" {
+		out = "-" + out
+	}
+
+	return out, nil
+}
+
+func (f NumberFormat) format(raw string) string {
+	group, decimal, prefix, suffix := f.separators()
+
+	negative := strings.HasPrefix(raw, "-")
+	raw = strings.TrimPrefix(raw, "-")
+	intPart, fracPart, _ := strings.Cut(raw, ".")
+
+	var sb strings.Builder
+
+	if negative && f.Negative {
+		sb.WriteString("-")
+	}
+
+	sb.WriteString(prefix)
+
+	for i, r := range intPart {
+		if i > 0 && (len(intPart)-i)%3 == 0 {
+			sb.WriteString(group)
+		}
+
+		sb.WriteRune(r)
+	}
+
+	// Currencies are padded to their precision, like the client does on blur
+	if decimals := f.Precision(); decimals > 0 && f.Currency != "" {
+		fracPart = (fracPart + strings.Repeat("0", decimals))[:decimals]
+	}
+
+	if fracPart != "" {
+		sb.WriteString(decimal + fracPart)
+	}
+
+	sb.WriteString(suffix)
+
+	return sb.String()
+}
+
+// attrs returns the data attributes read by the client runtime.
+func (m Mask) attrs() []html.Global {
+	attrs := []html.Global{html.AData("pui-mask", "")}
+
+	if m.Uppercase {
+		attrs = append(attrs, html.AData("pui-mask-uppercase", "true"))
+	}
+
+	if m.Number != nil {
+		group, decimal, prefix, suffix := m.Number.separators()
+
+		attrs = append(attrs,
+			html.AData("pui-mask-number", ""),
+			html.AData("pui-mask-group", group),
+			html.AData("pui-mask-decimal", decimal),
+			html.AData("pui-mask-decimals", strconv.Itoa(m.Number.Precision())),
+			html.AData("pui-mask-prefix", prefix),
+			html.AData("pui-mask-suffix", suffix),
+		)
+
+		if m.Number.Negative {
+			attrs = append(attrs, html.AData("pui-mask-negative", "true"))
+		}
+
+		if m.Number.Currency != "" {
+			attrs = append(attrs, html.AData("pui-mask-pad", "true"))
+		}
+
+		mode := "decimal"
+		if m.Number.Precision() == 0 && !m.Number.Negative {
+			mode = "numeric"
+		}
+
+		return append(attrs, html.AInputmode(mode))
+	}
+
+	if m.Pattern != "" {
+		attrs = append(attrs, html.AData("pui-mask-pattern", m.Pattern))
+
+		numeric := true
+
+		for _, t := range parsePattern(m.Pattern) {
+			if !t.literal && t.r != '9' {
+				numeric = false
+			}
+		}
+
+		if numeric {
+			attrs = append(attrs, html.AInputmode("numeric"))
+		}
+	}
+
+	return attrs
+}
+
+// Grouping and decimal separators by locale, falling back from region to language.
+var localeSeparators = map[string][2]string{
+	"en":    {",", "."},
+	"ja":    {",", "."},
+	"ko":    {",", "."},
+	"zh":    {",", "."},
+	"he":    {",", "."},
+	"hi":    {",", "."},
+	"th":    {",", "."},
+	"es-MX": {",", "."},
+	"de":    {".", ","},
+	"es":    {".", ","},
+	"it":    {".", ","},
+	"nl":    {".", ","},
+	"pt":    {".", ","},
+	"da":    {".", ","},
+	"id":    {".", ","},
+	"tr":    {".", ","},
+	"el":    {".", ","},
+	"de-CH": {"\u2019", "."},
+	"de-AT": {"\u00a0", ","},
+	"fr":    {"\u202f", ","},
+	"sv":    {"\u00a0", ","},
+	"nb":    {"\u00a0", ","},
+	"fi":    {"\u00a0", ","},
+	"pl":    {"\u00a0", ","},
+	"cs":    {"\u00a0", ","},
+	"sk":    {"\u00a0", ","},
+	"ru":    {"\u00a0", ","},
+	"uk":    {"\u00a0", ","},
+	"pt-PT": {"\u00a0", ","},
+}
+
+var currencySymbols = map[string]string{
+	"USD": "$",
+	"EUR": "€",
+	"GBP": "£",
+	"JPY": "¥",
+	"CNY": "¥",
+	"INR": "₹",
+	"KRW": "₩",
+	"BRL": "R$",
+	"CAD": "CA$",
+	"AUD": "A$",
+	"CHF": "CHF",
+	"SEK": "kr",
+	"NOK": "kr",
+	"DKK": "kr",
+	"PLN": "zł",
+}
+
+var zeroDecimalCurrencies = map[string]bool{"JPY": true, "KRW": true}
+
+// Languages writing the currency symbol before the amount.
+var symbolFirst = map[string]bool{"en": true, "ja": true, "zh": true, "ko": true, "hi": true, "he": true, "th": true}
+
+// checkLuhn validates card numbers with the Luhn checksum.
+func checkLuhn(raw string) error {
+	sum := 0
+
+	for i := range len(raw) {
+		d := int(raw[len(raw)-1-i] - '0')
+		if i%2 == 1 {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+
+		sum += d
+	}
+
+	if sum%10 != 0 {
+		return fmt.Errorf("%w: invalid card number", ErrMaskMismatch)
+	}
+
+	return nil
+}
+
+// checkIBAN validates the ISO 13616 mod 97 checksum.
+func checkIBAN(raw string) error {
+	// Check the characters before rearranging, so the slicing below works on single bytes
+	for _, r := range raw {
+		if (r < '0' || r > '9') && (r < 'A' || r > 'Z') {
+			return fmt.Errorf("%w: unexpected %q", ErrMaskMismatch, r)
+		}
+	}
+
+	if len(raw) < 5 {
+		return fmt.Errorf("%w: IBAN too short", ErrMaskMismatch)
+	}
+
+	rearranged := raw[4:] + raw[:4]
+	remainder := 0
+
+	for i := range len(rearranged) {
+		if c := rearranged[i]; c <= '9' {
+			remainder = (remainder*10 + int(c-'0')) % 97
+		} else {
+			remainder = (remainder*100 + int(c-'A') + 10) % 97
+		}
+	}
+
+	if remainder != 1 {
+		return fmt.Errorf("%w: invalid IBAN checksum", ErrMaskMismatch)
+	}
+
+	return nil
+}
+
+//go:embed mask.js
+var maskJS string
@@ -0,0 +1,256 @@
+(function () {
+  "use strict";
+
+  const patterns = new WeakMap();
+
+  // Mirrors parsePattern in mask.go
+  function parsePattern(pattern) {
+    const tokens = [];
+    let escaped = false;
+
+    for (const ch of pattern) {
+      if (escaped) {
+        tokens.push({ literal: ch });
+        escaped = false;
+      } else if (ch === "\\") {
+        escaped = true;
+      } else if (ch === "9" || ch === "a" || ch === "*") {
+        tokens.push({ token: ch });
+      } else {
+        tokens.push({ literal: ch });
+      }
+    }
+
+    return tokens;
+  }
+
+  function getTokens(input) {
+    let tokens = patterns.get(input);
+    if (!tokens) {
+      tokens = parsePattern(input.getAttribute("data-pui-mask-pattern") || "");
+      patterns.set(input, tokens);
+    }
+
+    return tokens;
+  }
+
+  function accepts(token, ch) {
+    if (token === "9") return /[0-9]/.test(ch);
+    if (token === "a") return /\p{L}/u.test(ch);
+    return /[\p{L}\p{Nd}]/u.test(ch);
+  }
+
+  // Drop characters the pattern does not accept and insert its literals
+  function formatPattern(value, tokens) {
+    const chars = Array.from(value);
+    let out = "";
+    let pending = "";
+    let i = 0;
+
+    for (const t of tokens) {
+      if (t.literal !== undefined) {
+        if (chars[i] === t.literal) i++;
+        pending += t.literal;
+        continue;
+      }
+
+      while (i < chars.length && !accepts(t.token, chars[i])) i++;
+      if (i >= chars.length) break;
+
+      out += pending + chars[i];
+      pending = "";
+      i++;
+    }
+
+    return out;
+  }
+
+  function numberOptions(input) {
+    return {
+      group: input.getAttribute("data-pui-mask-group") || "",
+      decimal: input.getAttribute("data-pui-mask-decimal") || ".",
+      decimals: parseInt(input.getAttribute("data-pui-mask-decimals")) || 0,
+      prefix: input.getAttribute("data-pui-mask-prefix") || "",
+      suffix: input.getAttribute("data-pui-mask-suffix") || "",
+      negative: input.getAttribute("data-pui-mask-negative") === "true",
+    };
+  }
+
+  function formatNumber(value, o, pad) {
+    const negative = o.negative && value.includes("-");
+    let int = "";
+    let frac = "";
+    let seenDecimal = false;
+
+    for (let i = 0; i < value.length; ) {
+      if (!seenDecimal && value.startsWith(o.decimal, i)) {
+        // Whole numbers drop a fraction rather than merge its digits into the integer
+        if (o.decimals === 0) break;
+
+        seenDecimal = true;
+        i += o.decimal.length;
+        continue;
+      }
+
+      const ch = value[i];
+      if (/[0-9]/.test(ch)) {
+        if (!seenDecimal) {
+          int += ch;
+        } else if (frac.length < o.decimals) {
+          frac += ch;
+        }
+      }
+      i++;
+    }
+
+    if (!int && !seenDecimal) return negative ? "-" : "";
+
+    int = int.replace(/^0+(?=\d)/, "") || "0";
+    if (pad && o.decimals > 0) {
+      frac = (frac + "0".repeat(o.decimals)).slice(0, o.decimals);
+      seenDecimal = true;
+    }
+
+    return (
+      (negative ? "-" : "") +
+      o.prefix +
+      int.replace(/\B(?=(\d{3})+(?!\d))/g, o.group) +
+      (seenDecimal ? o.decimal + frac : "") +
+      o.suffix
+    );
+  }
+
+  function format(input, value, pad) {
+    if (input.getAttribute("data-pui-mask-uppercase") === "true") {
+      value = value.toUpperCase();
+    }
+
+    if (input.hasAttribute("data-pui-mask-number")) {
+      return formatNumber(value, numberOptions(input), pad && value !== "");
+    }
+
+    if (input.hasAttribute("data-pui-mask-pattern")) {
+      return formatPattern(value, getTokens(input));
+    }
+
+    return value;
+  }
+
+  // Characters the user typed, as opposed to inserted literals and separators
+  function significance(input) {
+    if (input.hasAttribute("data-pui-mask-number")) {
+      const decimal = input.getAttribute("data-pui-mask-decimal") || ".";
+      return (ch) => /[0-9-]/.test(ch) || ch === decimal;
+    }
+
+    if (input.hasAttribute("data-pui-mask-pattern")) {
+      return (ch) => /[\p{L}\p{Nd}]/u.test(ch);
+    }
+
+    return () => true;
+  }
+
+  function countSignificant(text, end, isSignificant) {
+    let count = 0;
+    for (const ch of text.slice(0, end)) {
+      if (isSignificant(ch)) count++;
+    }
+
+    return count;
+  }
+
+  function caretFor(text, count, isSignificant) {
+    if (count === 0) return 0;
+
+    let seen = 0;
+    let pos = 0;
+    for (const ch of text) {
+      pos += ch.length;
+      if (isSignificant(ch) && ++seen === count) return pos;
+    }
+
+    return text.length;
+  }
+
+  function apply(input, pad) {
+    const value = input.value;
+    const next = format(input, value, pad);
+    if (next === value) return;
+
+    const isSignificant = significance(input);
+    const focused = document.activeElement === input;
+    const count = focused
+      ? countSignificant(value, input.selectionStart ?? value.length, isSignificant)
+      : 0;
+
+    input.value = next;
+
+    if (focused) {
+      const pos = caretFor(next, count, isSignificant);
+      input.setSelectionRange(pos, pos);
+    }
+  }
+
+  document.addEventListener("input", (e) => {
+    const input = e.target.closest?.("[data-pui-mask]");
+    if (input) apply(input, false);
+  });
+
+  document.addEventListener("focusout", (e) => {
+    const input = e.target.closest?.("[data-pui-mask-pad]");
+    if (input) apply(input, true);
+  });
+
+  // Whole numbers refuse the decimal separator, which the server would reject
+  document.addEventListener("beforeinput", (e) => {
+    if (!e.data) return;
+
+    const input = e.target.closest?.("[data-pui-mask-number]");
+    if (!input || numberOptions(input).decimals > 0) return;
+
+    if (e.data.includes(numberOptions(input).decimal)) e.preventDefault();
+  });
+
+  // Backspace over a literal deletes the typed character before it
+  document.addEventListener("keydown", (e) => {
+    if (e.key !== "Backspace") return;
+
+    const input = e.target.closest?.("[data-pui-mask]");
+    if (!input || input.readOnly) return;
+
+    const pos = input.selectionStart;
+    if (pos === null || pos !== input.selectionEnd || pos === 0) return;
+
+    const isSignificant = significance(input);
+    const value = input.value;
+    if (isSignificant(value[pos - 1])) return;
+
+    let target = pos - 1;
+    while (target >= 0 && !isSignificant(value[target])) target--;
+    if (target < 0) return;
+
+    e.preventDefault();
+    input.value = value.slice(0, target) + value.slice(target + 1);
+    input.setSelectionRange(target, target);
+    input.dispatchEvent(new Event("input", { bubbles: true }));
+  });
+
+  function init() {
+    document.querySelectorAll("[data-pui-mask]").forEach((input) => {
+      apply(input, input.hasAttribute("data-pui-mask-pad"));
+    });
+  }
+
+  if (document.readyState === "loading") {
+    document.addEventListener("DOMContentLoaded", init);
+  } else {
+    init();
+  }
+
+  // Expose public API
+  window.tui = window.tui || {};
+  window.tui.mask = {
+    init: init,
+    format: (input) => apply(input, false),
+  };
+})();
@@ -0,0 +1,145 @@
+package input
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNumberNormalize(t *testing.T) {
+	tests := []struct {
+		name   string
+		format NumberFormat
+		value  string
+		want   string
+		err    error
+	}{
+		{name: "whole number", format: NumberFormat{}, value: "1,234", want: "1234"},
+		{name: "whole number rejects a fraction", format: NumberFormat{}, value: "1.5", err: ErrMaskMismatch},
+		{name: "whole number rejects a merged fraction", format: NumberFormat{}, value: "1.05", err: ErrMaskMismatch},
+		{name: "fraction within precision", format: NumberFormat{Decimals: 2}, value: "1,234.50", want: "1234.50"},
+		{name: "fraction beyond precision", format: NumberFormat{Decimals: 2}, value: "1.505", err: ErrMaskMismatch},
+		{name: "repeated decimal separator", format: NumberFormat{Decimals: 2}, value: "1.2.3", err: ErrMaskMismatch},
+		{name: "locale separators", format: NumberFormat{Locale: "de-DE", Decimals: 2}, value: "1.234,5", want: "1234.5"},
+		{name: "currency precision", format: NumberFormat{Currency: "USD"}, value: "$1,234.56", want: "1234.56"},
+		{name: "zero decimal currency", format: NumberFormat{Currency: "JPY"}, value: "¥1,234.5", err: ErrMaskMismatch},
+		{name: "negative allowed", format: NumberFormat{Negative: true}, value: "-42", want: "-42"},
+		{name: "negative refused", format: NumberFormat{}, value: "-42", err: ErrMaskMismatch},
+		{name: "letters", format: NumberFormat{}, value: "12a", err: ErrMaskMismatch},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := Mask{Number: &tt.format}
+
+			got, err := m.Normalize(tt.value)
+			if tt.err != nil {
+				if !errors.Is(err, tt.err) {
+					t.Fatalf("Normalize(%q) error = %v, want %v", tt.value, err, tt.err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Normalize(%q) error = %v", tt.value, err)
+			}
+
+			if got != tt.want {
+				t.Errorf("Normalize(%q) = %q, want %q", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPatternNormalize(t *testing.T) {
+	tests := []struct {
+		name  string
+		mask  Mask
+		value string
+		want  string
+		err   error
+	}{
+		{name: "formatted phone", mask: MaskPhoneUS, value: "(555) 123-4567", want: "5551234567"},
+		{name: "bare phone", mask: MaskPhoneUS, value: "5551234567", want: "5551234567"},
+		{name: "other separators", mask: MaskPhoneUS, value: "555.123.4567", want: "5551234567"},
+		{name: "incomplete phone", mask: MaskPhoneUS, value: "555-123-456", err: ErrMaskIncomplete},
+		{name: "letter for a digit", mask: MaskPhoneUS, value: "555-123-456a", err: ErrMaskMismatch},
+		{name: "too long", mask: MaskPhoneUS, value: "55512345678", err: ErrMaskMismatch},
+		{name: "uppercase", mask: MaskPostalCA, value: "k1a 0b1", want: "K1A0B1"},
+		{name: "digit for a letter", mask: MaskPostalCA, value: "11a 0b1", err: ErrMaskMismatch},
+		{name: "escaped token is a literal", mask: Mask{Pattern: `\9-999`}, value: "9-123", want: "123"},
+		{name: "card", mask: MaskCreditCard, value: "4111 1111 1111 1111", want: "4111111111111111"},
+		{name: "card at minimum length", mask: MaskCreditCard, value: "4222222222222", want: "4222222222222"},
+		{name: "card below minimum length", mask: MaskCreditCard, value: "4111 1111 1111", err: ErrMaskIncomplete},
+		{name: "card failing Luhn", mask: MaskCreditCard, value: "4111 1111 1111 1112", err: ErrMaskMismatch},
+		{name: "IBAN", mask: MaskIBAN, value: "GB82 WEST 1234 5698 7654 32", want: "GB82WEST12345698765432"},
+		{name: "lowercase IBAN", mask: MaskIBAN, value: "de89370400440532013000", want: "DE89370400440532013000"},
+		{name: "IBAN failing the checksum", mask: MaskIBAN, value: "GB82 WEST 1234 5698 7654 33", err: ErrMaskMismatch},
+		{name: "IBAN with a non-ASCII letter", mask: MaskIBAN, value: "GB82 WÉST 1234 5698 7654 32", err: ErrMaskMismatch},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.mask.Normalize(tt.value)
+			if tt.err != nil {
+				if !errors.Is(err, tt.err) {
+					t.Fatalf("Normalize(%q) error = %v, want %v", tt.value, err, tt.err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Normalize(%q) error = %v", tt.value, err)
+			}
+
+			if got != tt.want {
+				t.Errorf("Normalize(%q) = %q, want %q", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPatternFormat(t *testing.T) {
+	tests := []struct {
+		name string
+		mask Mask
+		raw  string
+		want string
+	}{
+		{name: "phone", mask: MaskPhoneUS, raw: "5551234567", want: "(555) 123-4567"},
+		{name: "partial phone", mask: MaskPhoneUS, raw: "5551", want: "(555) 1"},
+		{name: "uppercase", mask: MaskPostalCA, raw: "k1a0b1", want: "K1A 0B1"},
+		{name: "escaped token", mask: Mask{Pattern: `\9-999`}, raw: "123", want: "9-123"},
+		{name: "IBAN", mask: MaskIBAN, raw: "GB82WEST12345698765432", want: "GB82 WEST 1234 5698 7654 32"},
+		{name: "empty", mask: MaskPhoneUS, raw: "", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.mask.Format(tt.raw); got != tt.want {
+				t.Errorf("Format(%q) = %q, want %q", tt.raw, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckIBAN(t *testing.T) {
+	tests := []struct {
+		raw  string
+		want error
+	}{
+		{raw: "GB82WEST12345698765432"},
+		{raw: "NL91ABNA0417164300"},
+		{raw: "NL91ABNA0417164301", want: ErrMaskMismatch},
+		{raw: "GB8", want: ErrMaskMismatch},
+		{raw: "ÉÉ82WEST12345698765432", want: ErrMaskMismatch},
+		{raw: "gb82west12345698765432", want: ErrMaskMismatch},
+	}
+
+	for _, tt := range tests {
+		if err := checkIBAN(tt.raw); !errors.Is(err, tt.want) {
+			t.Errorf("checkIBAN(%q) = %v, want %v", tt.raw, err, tt.want)
+		}
+	}
+}
@@ -0,0 +1,411 @@
+package input
+
+import (
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/plainkit/html"
+	"github.com/plainkit/icons/lucide"
+	"github.com/plainkit/ui/internal/counter"
+	"github.com/plainkit/ui/progress"
+)
+
+// Rule names a single password requirement.
+type Rule string
+
+const (
+	RuleLength   Rule = "length"
+	RuleLower    Rule = "lower"
+	RuleUpper    Rule = "upper"
+	RuleDigit    Rule = "digit"
+	RuleSymbol   Rule = "symbol"
+	RuleUsername Rule = "username"
+	RuleStrength Rule = "strength"
+)
+
+// Strength buckets the estimated entropy of a password.
+type Strength int
+
+const (
+	StrengthVeryWeak Strength = iota
+	StrengthWeak
+	StrengthFair
+	StrengthGood
+	StrengthStrong
+)
+
+// strengthBits are the entropy thresholds, in bits, of StrengthWeak and up.
+var strengthBits = [...]float64{28, 36, 60, 80}
+
+var strengthLabels = [...]string{"Very weak", "Weak", "Fair", "Good", "Strong"}
+
+var strengthVariants = [...]progress.Variant{
+	progress.VariantDanger,
+	progress.VariantDanger,
+	progress.VariantWarning,
+	progress.VariantDefault,
+	progress.VariantSuccess,
+}
+
+func (s Strength) String() string {
+	return strengthLabels[min(max(int(s), 0), len(strengthLabels)-1)]
+}
+
+// minUsername is the shortest username checked by RuleUsername; shorter ones would
+// reject too many unrelated passwords.
+const minUsername = 3
+
+// commonPasswords score zero entropy whatever their character classes. The list is sent
+// to the browser so both sides agree; keep it short.
+var commonPasswords = []string{
+	"123456", "12345678", "123456789", "1234567890", "password", "password1", "password123",
+	"qwerty", "qwerty123", "qwertyuiop", "abc123", "111111", "123123", "iloveyou", "admin",
+	"welcome", "letmein", "monkey", "dragon", "football", "baseball", "sunshine", "princess",
+	"passw0rd", "p@ssw0rd", "p@ssword", "trustno1", "changeme", "secret",
+}
+
+// ErrPassword is returned by Policy.Validate; the wrapped message lists the unmet rules.
+var ErrPassword = errors.New("input: password does not meet the requirements")
+
+// Policy lists the password requirements shown as a checklist under the field and
+// checked on the server with Policy.Validate. Zero fields are not enforced.
+type Policy struct {
+	MinLength int  // Counted like the browser's minlength
+	Lower     bool // Requires a lowercase letter
+	Upper     bool // Requires an uppercase letter
+	Digit     bool
+	Symbol    bool // Requires a character that is neither a letter, a digit nor a space
+	// Username may not appear in the password, ignoring case. Set it on the server from
+	// the submitted or stored username before validating.
+	Username string
+	// UsernameField is the ID of a username input on the same page; the checklist
+	// follows its value while the user types, as on a signup form.
+	UsernameField string
+	MinStrength   Strength
+}
+
+// Result is the outcome of a single rule.
+type Result struct {
+	Rule  Rule
+	Label string
+	Met   bool
+}
+
+// Enabled reports whether the policy has any rule.
+func (p Policy) Enabled() bool {
+	return len(p.Rules()) > 0
+}
+
+// Rules returns the enforced rules in checklist order.
+func (p Policy) Rules() []Rule {
+	var rules []Rule
+
+	if p.MinLength > 0 {
+		rules = append(rules, RuleLength)
+	}
+
+	for _, r := range []struct {
+		on   bool
+		rule Rule
+	}{{p.Lower, RuleLower}, {p.Upper, RuleUpper}, {p.Digit, RuleDigit}, {p.Symbol, RuleSymbol}} {
+		if r.on {
+			rules = append(rules, r.rule)
+		}
+	}
+
+	if p.Username != "" || p.UsernameField != "" {
+		rules = append(rules, RuleUsername)
+	}
+
+	if p.MinStrength > StrengthVeryWeak {
+		rules = append(rules, RuleStrength)
+	}
+
+	return rules
+}
+
+// Label is the checklist text of rule.
+func (p Policy) Label(rule Rule) string {
+	switch rule {
+	case RuleLength:
+		return "At least " + strconv.Itoa(p.MinLength) + " characters"
+	case RuleLower:
+		return "A lowercase letter"
+	case RuleUpper:
+		return "An uppercase letter"
+	case RuleDigit:
+		return "A number"
+	case RuleSymbol:
+		return "A symbol"
+	case RuleUsername:
+		return "Does not contain your username"
+	case RuleStrength:
+		return "Hard to guess"
+	default:
+		return string(rule)
+	}
+}
+
+// Check evaluates every rule of the policy against password.
+func (p Policy) Check(password string) []Result {
+	rules := p.Rules()
+	results := make([]Result, 0, len(rules))
+
+	for _, rule := range rules {
+		results = append(results, Result{Rule: rule, Label: p.Label(rule), Met: p.met(rule, password)})
+	}
+
+	return results
+}
+
+func (p Policy) met(rule Rule, password string) bool {
+	switch rule {
+	case RuleLength:
+		return counter.Len(password) >= p.MinLength
+	case RuleLower:
+		return strings.IndexFunc(password, isLower) >= 0
+	case RuleUpper:
+		return strings.IndexFunc(password, isUpper) >= 0
+	case RuleDigit:
+		return strings.IndexFunc(password, isDigit) >= 0
+	case RuleSymbol:
+		return strings.IndexFunc(password, isSymbol) >= 0
+	case RuleUsername:
+		name := strings.ToLower(strings.TrimSpace(p.Username))
+		return len([]rune(name)) < minUsername || !strings.Contains(strings.ToLower(password), name)
+	case RuleStrength:
+		return Score(password) >= p.MinStrength
+	default:
+		return true
+	}
+}
+
+// Validate returns nil when password meets every rule, or ErrPassword naming the rules
+// it misses.
+func (p Policy) Validate(password string) error {
+	var missing []string
+
+	for _, r := range p.Check(password) {
+		if !r.Met {
+			missing = append(missing, strings.ToLower(r.Label))
+		}
+	}
+
+	if len(missing) > 0 {
+		return fmt.Errorf("%w: %s", ErrPassword, strings.Join(missing, ", "))
+	}
+
+	return nil
+}
+
+func isLower(r rune) bool { return unicode.Is(unicode.Ll, r) }
+func isUpper(r rune) bool { return unicode.Is(unicode.Lu, r) }
+func isDigit(r rune) bool { return unicode.Is(unicode.Nd, r) }
+
+func isSymbol(r rune) bool {
+	return !unicode.IsLetter(r) && !unicode.Is(unicode.Nd, r) && !unicode.IsSpace(r)
+}
+
+// Entropy estimates the bits of entropy of password from the size of the character
+// pool it draws on. Characters repeating or continuing a sequence from the previous
+// one ("aaa", "abc", "321") count half, and common passwords count zero. It is an
+// estimate for feedback, not a guarantee; password.js mirrors it exactly.
+func Entropy(password string) float64 {
+	if password == "" || isCommon(password) {
+		return 0
+	}
+
+	pool := 0
+
+	for _, class := range []struct {
+		has  func(rune) bool
+		size int
+	}{{isLower, 26}, {isUpper, 26}, {isDigit, 10}, {isSymbol, 33}} {
+		if strings.IndexFunc(password, class.has) >= 0 {
+			pool += class.size
+		}
+	}
+
+	if strings.IndexFunc(password, func(r rune) bool { return r > unicode.MaxASCII }) >= 0 {
+		pool += 100
+	}
+
+	length := 0.0
+	prev := rune(-1)
+
+	for _, r := range password {
+		if prev >= 0 && (r == prev || r == prev+1 || r == prev-1) {
+			length += 0.5
+		} else {
+			length++
+		}
+
+		prev = r
+	}
+
+	return length * math.Log2(float64(max(pool, 2)))
+}
+
+// Score buckets the entropy of password into a Strength.
+func Score(password string) Strength {
+	bits := Entropy(password)
+	s := StrengthVeryWeak
+
+	for i, threshold := range strengthBits {
+		if bits >= threshold {
+			s = Strength(i + 1)
+		}
+	}
+
+	return s
+}
+
+func isCommon(password string) bool {
+	lower := strings.ToLower(password)
+
+	for _, c := range commonPasswords {
+		if lower == c {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (p Props) hasPasswordFeedback() bool {
+	return p.Type == TypePassword && (p.ShowStrength || p.Policy.Enabled())
+}
+
+// passwordID returns the id of the strength meter and checklist wrapper of a field.
+func passwordID(fieldID string) string {
+	return fieldID + "-password"
+}
+
+type passwordConfig struct {
+	MinLength     int          `json:"minLength,omitempty"`
+	Username      string       `json:"username,omitempty"`
+	UsernameField string       `json:"usernameField,omitempty"`
+	MinStrength   int          `json:"minStrength,omitempty"`
+	Thresholds    []float64    `json:"thresholds"`
+	Levels        []levelStyle `json:"levels"`
+	Common        []string     `json:"common"`
+}
+
+type levelStyle struct {
+	Label string `json:"label"`
+	Class string `json:"class"`
+}
+
+// passwordFeedback renders the strength meter and the policy checklist for p, computed
+// from p.Value so the server-rendered state matches what the script shows.
+func passwordFeedback(p Props) html.Node {
+	config := passwordConfig{
+		MinLength:     p.Policy.MinLength,
+		Username:      p.Policy.Username,
+		UsernameField: p.Policy.UsernameField,
+		MinStrength:   int(p.Policy.MinStrength),
+		Thresholds:    strengthBits[:],
+		Common:        commonPasswords,
+	}
+
+	for i, label := range strengthLabels {
+		config.Levels = append(config.Levels, levelStyle{Label: label, Class: progress.VariantClass(strengthVariants[i])})
+	}
+
+	data, _ := json.Marshal(config)
+
+	args := []html.DivArg{
+		html.AId(passwordID(p.ID)),
+		html.AClass("space-y-2"),
+		html.AData("pui-password", p.ID),
+		html.AData("pui-password-config", string(data)),
+	}
+
+	if p.ShowStrength {
+		args = append(args, html.Child(strengthMeter(p.ID, p.Value)))
+	}
+
+	if p.Policy.Enabled() {
+		args = append(args, html.Child(checklist(p.ID, p.Policy.Check(p.Value))))
+	}
+
+	return html.Div(args...).WithAssets("", passwordJS, "ui-input-password")
+}
+
+func strengthMeter(fieldID, value string) html.Node {
+	s := Score(value)
+	label := s.String()
+	level := int(s) + 1
+
+	if value == "" {
+		label, level = "", 0
+	}
+
+	return html.Div(
+		html.AClass("space-y-1"),
+		progress.Progress(
+			progress.Props{
+				ID:      fieldID + "-strength",
+				Class:   "space-y-0 rounded-full border-0 bg-transparent p-0 shadow-none",
+				Max:     len(strengthLabels),
+				Value:   level,
+				Size:    progress.SizeSm,
+				Variant: strengthVariants[s],
+				Attrs: []html.Global{
+					html.AAria("label", "Password strength"),
+					html.AAria("valuetext", label),
+					html.AData("pui-password-meter", ""),
+				},
+			},
+		),
+		html.P(
+			html.AClass("text-xs text-muted-foreground"),
+			html.AAria("live", "polite"),
+			html.AData("pui-password-strength", ""),
+			html.Text(label),
+		),
+	)
+}
+
+func checklist(fieldID string, results []Result) html.Node {
+	args := []html.UlArg{
+		html.AId(fieldID + "-rules"),
+		html.AClass("grid gap-1 text-xs sm:grid-cols-2"),
+		html.AAria("label", "Password requirements"),
+		html.AData("pui-password-rules", ""),
+	}
+
+	for _, r := range results {
+		args = append(args, html.Li(
+			html.AClass(html.ClassMerge(
+				"group flex items-center gap-1.5 text-muted-foreground transition-colors",
+				"data-[pui-password-met=true]:text-emerald-600 dark:data-[pui-password-met=true]:text-emerald-400",
+			)),
+			html.AData("pui-password-rule", string(r.Rule)),
+			html.AData("pui-password-met", strconv.FormatBool(r.Met)),
+			lucide.Check(html.AClass("hidden size-3.5 shrink-0 group-data-[pui-password-met=true]:block"), html.AAria("hidden", "true")),
+			lucide.X(html.AClass("size-3.5 shrink-0 group-data-[pui-password-met=true]:hidden"), html.AAria("hidden", "true")),
+			html.Span(html.Text(r.Label)),
+			html.Span(html.AClass("sr-only"), html.AData("pui-password-status", ""), html.Text(metText(r.Met))),
+		))
+	}
+
+	return html.Ul(args...)
+}
+
+func metText(met bool) string {
+	if met {
+		return "met"
+	}
+
+	return "not met"
+}
+
+//go:embed password.js
+var passwordJS string
@@ -0,0 +1,184 @@
+(function () {
+  "use strict";
+
+  const MIN_USERNAME = 3; // Mirrors minUsername in password.go
+
+  const configs = new WeakMap();
+
+  // Character classes mirror isLower, isUpper, isDigit and isSymbol in password.go
+  const classes = {
+    lower: /\p{Ll}/u,
+    upper: /\p{Lu}/u,
+    digit: /\p{Nd}/u,
+    symbol: /[^\p{L}\p{Nd}\s]/u,
+  };
+
+  const pools = [
+    [classes.lower, 26],
+    [classes.upper, 26],
+    [classes.digit, 10],
+    [classes.symbol, 33],
+    [/[^\x00-\x7f]/, 100],
+  ];
+
+  function config(wrapper) {
+    let c = configs.get(wrapper);
+    if (!c) {
+      try {
+        c = JSON.parse(wrapper.getAttribute("data-pui-password-config") || "{}");
+      } catch {
+        c = {};
+      }
+      c.thresholds = c.thresholds || [];
+      c.levels = c.levels || [];
+      c.common = new Set(c.common || []);
+      configs.set(wrapper, c);
+    }
+    return c;
+  }
+
+  // Mirrors Entropy in password.go
+  function entropy(value, c) {
+    if (!value || c.common.has(value.toLowerCase())) return 0;
+
+    let pool = 0;
+    for (const [re, size] of pools) {
+      if (re.test(value)) pool += size;
+    }
+
+    let length = 0;
+    let prev = -1;
+    for (const ch of value) {
+      const r = ch.codePointAt(0);
+      length += prev >= 0 && (r === prev || r === prev + 1 || r === prev - 1) ? 0.5 : 1;
+      prev = r;
+    }
+
+    return length * Math.log2(Math.max(pool, 2));
+  }
+
+  // Mirrors Score in password.go
+  function score(value, c) {
+    const bits = entropy(value, c);
+    let s = 0;
+    c.thresholds.forEach((threshold, i) => {
+      if (bits >= threshold) s = i + 1;
+    });
+    return s;
+  }
+
+  function username(c) {
+    if (c.usernameField) {
+      const field = document.getElementById(c.usernameField);
+      if (field) return field.value;
+    }
+    return c.username || "";
+  }
+
+  // Mirrors Policy.met in password.go
+  function met(rule, value, c) {
+    switch (rule) {
+      case "length":
+        return value.length >= (c.minLength || 0);
+      case "lower":
+      case "upper":
+      case "digit":
+      case "symbol":
+        return classes[rule].test(value);
+      case "username": {
+        const name = username(c).trim().toLowerCase();
+        return Array.from(name).length < MIN_USERNAME || !value.toLowerCase().includes(name);
+      }
+      case "strength":
+        return score(value, c) >= (c.minStrength || 0);
+      default:
+        return true;
+    }
+  }
+
+  function wrapperFor(field) {
+    if (!field.id) return null;
+    return document.querySelector('[data-pui-password="' + CSS.escape(field.id) + '"]');
+  }
+
+  function updateMeter(wrapper, value, c) {
+    const meter = wrapper.querySelector("[data-pui-password-meter]");
+    if (!meter) return;
+
+    const s = score(value, c);
+    const level = value ? s + 1 : 0;
+    const label = value ? (c.levels[s] || {}).label || "" : "";
+
+    meter.setAttribute("aria-valuenow", String(level));
+    meter.setAttribute("aria-valuetext", label);
+
+    const bar = meter.querySelector("[data-pui-progress-indicator]");
+    if (bar) {
+      bar.style.width = (level * 100) / Math.max(c.levels.length, 1) + "%";
+      for (const l of c.levels) bar.classList.remove(...l.class.split(/\s+/).filter(Boolean));
+      const current = c.levels[s];
+      if (current) bar.classList.add(...current.class.split(/\s+/).filter(Boolean));
+    }
+
+    // Only touch the live region when the level changes, not on every keystroke
+    const text = wrapper.querySelector("[data-pui-password-strength]");
+    if (text && text.textContent !== label) text.textContent = label;
+  }
+
+  function updateRules(wrapper, value, c) {
+    wrapper.querySelectorAll("[data-pui-password-rule]").forEach((item) => {
+      const ok = met(item.getAttribute("data-pui-password-rule"), value, c);
+      item.setAttribute("data-pui-password-met", String(ok));
+      const status = item.querySelector("[data-pui-password-status]");
+      if (status) status.textContent = ok ? "met" : "not met";
+    });
+  }
+
+  function update(field) {
+    const wrapper = wrapperFor(field);
+    if (!wrapper) return;
+
+    const c = config(wrapper);
+    updateMeter(wrapper, field.value, c);
+    updateRules(wrapper, field.value, c);
+  }
+
+  // Re-check the username rule of every password field following this username input
+  function updateFollowers(field) {
+    if (!field.id) return;
+    document.querySelectorAll("[data-pui-password]").forEach((wrapper) => {
+      if (config(wrapper).usernameField !== field.id) return;
+      const password = document.getElementById(wrapper.getAttribute("data-pui-password"));
+      if (password) update(password);
+    });
+  }
+
+  document.addEventListener("input", (e) => {
+    const field = e.target;
+    if (!(field instanceof HTMLInputElement)) return;
+
+    if (wrapperFor(field)) {
+      update(field);
+    } else {
+      updateFollowers(field);
+    }
+  });
+
+  document.addEventListener("reset", (e) => {
+    const form = e.target;
+    if (!(form instanceof HTMLFormElement)) return;
+
+    // Fields hold their default values only after the reset completes
+    setTimeout(() => {
+      for (const field of form.elements) {
+        if (field instanceof HTMLInputElement && wrapperFor(field)) update(field);
+      }
+    });
+  });
+
+  // Expose public API
+  window.tui = window.tui || {};
+  window.tui.password = {
+    update: (field) => update(field),
+  };
+})();
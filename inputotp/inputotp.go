@@ -2,22 +2,54 @@ package inputotp
 
 import (
 	_ "embed"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
 	"strconv"
+	"strings"
+	"unicode"
 
 	"github.com/plainkit/html"
 	"github.com/plainkit/ui/internal/styles"
 )
 
+// Mode restricts the characters a slot accepts.
+type Mode string
+
+const (
+	ModeAny            Mode = ""                // Any character except whitespace
+	ModeNumeric        Mode = "numeric"         // Digits 0-9
+	ModeAlphanumeric   Mode = "alphanumeric"    // ASCII letters and digits
+	ModeUppercaseAlpha Mode = "uppercase-alpha" // ASCII letters, converted to upper case
+	ModePattern        Mode = "pattern"         // Characters matching Props.Pattern
+)
+
+var (
+	// ErrRequired is returned by Clean when a required code is empty.
+	ErrRequired = errors.New("inputotp: code is required")
+	// ErrLength is returned by Clean when the code does not fill every slot of Layout.
+	ErrLength = errors.New("inputotp: code has the wrong length")
+	// ErrInvalid is returned by Clean when a character is not allowed by the mode.
+	ErrInvalid = errors.New("inputotp: code contains an invalid character")
+)
+
 type Props struct {
-	ID        string
-	Class     string
-	Attrs     []html.Global
-	Value     string
-	Required  bool
-	Name      string
-	Form      string
-	HasError  bool
-	Autofocus bool
+	ID          string
+	Class       string
+	Attrs       []html.Global
+	Value       string
+	Required    bool
+	Name        string
+	Form        string
+	HasError    bool
+	Autofocus   bool
+	Mode        Mode
+	Pattern     string // Regular expression a single character must match, for ModePattern, e.g. "[0-9A-F]"
+	Secret      bool   // Masks the entered characters; used by Code
+	AutoSubmit  bool   // Submits the form once every slot is filled
+	Layout      string // Slot groups for Code, e.g. "6", "3-3" or "4 4 4"
+	Placeholder string // Shown in every slot rendered by Code
 }
 
 type GroupProps struct {
@@ -31,7 +63,9 @@ type SlotProps struct {
 	Class       string
 	Attrs       []html.Global
 	Index       int
-	Type        string
+	Type        string // Input type; defaults to "password" when Secret and "text" otherwise
+	Mode        Mode   // Selects the on-screen keyboard; the container's Mode filters input
+	Secret      bool
 	Placeholder string
 	Disabled    bool
 	HasError    bool
@@ -70,6 +104,18 @@ func (p Props) ApplyDiv(attrs *html.DivAttrs, children *[]html.Component) {
 		args = append(args, html.AAutofocus())
 	}
 
+	if mode := p.mode(); mode != ModeAny {
+		args = append(args, html.AData("pui-inputotp-mode", string(mode)))
+	}
+
+	if p.mode() == ModePattern {
+		args = append(args, html.AData("pui-inputotp-pattern", p.Pattern))
+	}
+
+	if p.AutoSubmit {
+		args = append(args, html.AData("pui-inputotp-auto-submit", "true"))
+	}
+
 	// Create hidden input
 	hiddenArgs := []html.InputArg{
 		html.AType("hidden"),
@@ -122,6 +168,20 @@ func (p SlotProps) ApplyDiv(attrs *html.DivAttrs, children *[]html.Component) {
 	inputType := p.Type
 	if inputType == "" {
 		inputType = "text"
+		if p.Secret {
+			inputType = "password"
+		}
+	}
+
+	inputMode := "text"
+	if p.Mode == ModeAny || p.Mode == ModeNumeric {
+		inputMode = "numeric"
+	}
+
+	// Autofill offers the whole code to the first slot, which the script spreads out
+	autocomplete := "off"
+	if p.Index == 0 {
+		autocomplete = "one-time-code"
 	}
 
 	args := []html.DivArg{html.AClass("relative")}
@@ -135,8 +195,9 @@ func (p SlotProps) ApplyDiv(attrs *html.DivAttrs, children *[]html.Component) {
 
 	inputArgs := []html.InputArg{
 		html.AType(inputType),
-		html.AInputmode("numeric"),
-		html.AMaxlength("1"),
+		html.AInputmode(inputMode),
+		html.ACustom("autocomplete", autocomplete),
+		html.AAria("label", "Character "+strconv.Itoa(p.Index+1)),
 		html.AClass(html.ClassMerge(
 			styles.Input("h-12 w-12 appearance-none text-center text-lg md:text-base", "aria-invalid:border-destructive aria-invalid:ring-destructive/30"),
 			func() string {
@@ -155,6 +216,14 @@ func (p SlotProps) ApplyDiv(attrs *html.DivAttrs, children *[]html.Component) {
 		inputArgs = append(inputArgs, html.APlaceholder(p.Placeholder))
 	}
 
+	if p.Mode == ModeUppercaseAlpha {
+		inputArgs = append(inputArgs, html.ACustom("autocapitalize", "characters"))
+	}
+
+	if p.Index > 0 {
+		inputArgs = append(inputArgs, html.AMaxlength("1"))
+	}
+
 	if p.Disabled {
 		inputArgs = append(inputArgs, html.ADisabled())
 	}
@@ -181,11 +250,6 @@ func (p SeparatorProps) ApplyDiv(attrs *html.DivAttrs, children *[]html.Componen
 		args = append(args, a)
 	}
 
-	// Add default separator if no children
-	if len(*children) == 0 {
-		*children = append(*children, html.Span(html.Text("-")))
-	}
-
 	for _, a := range args {
 		a.ApplyDiv(attrs, children)
 	}
@@ -264,5 +328,169 @@ func Separator(args ...html.DivArg) html.Node {
 	return html.Div(append([]html.DivArg{props}, rest...)...)
 }
 
+// Code renders a complete InputOTP with the slots and separators described by
+// p.Layout: runs of digits give the slots per group and any other character becomes
+// the separator between them, so "3-3" renders two groups of three split by a dash.
+// An empty Layout renders six slots.
+func Code(p Props) html.Node {
+	children := []html.DivArg{p}
+	index := 0
+
+	for i, group := range parseLayout(p.Layout) {
+		if i > 0 && group.separator != " " {
+			children = append(children, Separator(html.Span(html.AAria("hidden", "true"), html.Text(group.separator))))
+		}
+
+		slots := make([]html.DivArg, 0, group.size)
+		for j := 0; j < group.size; j++ {
+			slots = append(slots, Slot(SlotProps{
+				Index:       index,
+				Mode:        p.mode(),
+				Secret:      p.Secret,
+				Placeholder: p.Placeholder,
+				HasError:    p.HasError,
+			}))
+			index++
+		}
+
+		children = append(children, Group(slots...))
+	}
+
+	return InputOTP(children...)
+}
+
+type layoutGroup struct {
+	separator string // Text before the group
+	size      int
+}
+
+func parseLayout(layout string) []layoutGroup {
+	var (
+		groups []layoutGroup
+		sep    strings.Builder
+		size   int
+	)
+
+	flush := func() {
+		if size > 0 {
+			groups = append(groups, layoutGroup{separator: sep.String(), size: size})
+			sep.Reset()
+			size = 0
+		}
+	}
+
+	for _, r := range layout {
+		if r >= '0' && r <= '9' {
+			size = size*10 + int(r-'0')
+			continue
+		}
+
+		flush()
+		sep.WriteRune(r)
+	}
+
+	flush()
+
+	if len(groups) == 0 {
+		return []layoutGroup{{size: 6}}
+	}
+
+	return groups
+}
+
+// Length returns the number of slots Code renders for p.
+func (p Props) Length() int {
+	n := 0
+	for _, g := range parseLayout(p.Layout) {
+		n += g.size
+	}
+
+	return n
+}
+
+func (p Props) mode() Mode {
+	if p.Mode == ModeAny && p.Pattern != "" {
+		return ModePattern
+	}
+
+	return p.Mode
+}
+
+// Clean normalizes a submitted code the way the client does: whitespace and dashes are
+// dropped and ModeUppercaseAlpha converts to upper case. It then checks every character
+// against the mode and, when Layout is set, the length.
+func Clean(p Props, value string) (string, error) {
+	value = strings.Map(func(r rune) rune {
+		if unicode.IsSpace(r) || r == '-' {
+			return -1
+		}
+
+		return r
+	}, value)
+
+	mode := p.mode()
+	if mode == ModeUppercaseAlpha {
+		value = strings.ToUpper(value)
+	}
+
+	if value == "" {
+		if p.Required {
+			return "", ErrRequired
+		}
+
+		return "", nil
+	}
+
+	var pattern *regexp.Regexp
+
+	if mode == ModePattern {
+		re, err := regexp.Compile("^(?:" + p.Pattern + ")$")
+		if err != nil {
+			return "", err
+		}
+
+		pattern = re
+	}
+
+	for _, r := range value {
+		if !allowed(mode, pattern, r) {
+			return "", fmt.Errorf("%w: %q", ErrInvalid, r)
+		}
+	}
+
+	if p.Layout != "" && len([]rune(value)) != p.Length() {
+		return "", fmt.Errorf("%w: want %d characters", ErrLength, p.Length())
+	}
+
+	return value, nil
+}
+
+func allowed(mode Mode, pattern *regexp.Regexp, r rune) bool {
+	isDigit := r >= '0' && r <= '9'
+	isLetter := (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z')
+
+	switch mode {
+	case ModeNumeric:
+		return isDigit
+	case ModeAlphanumeric:
+		return isDigit || isLetter
+	case ModeUppercaseAlpha:
+		return r >= 'A' && r <= 'Z'
+	case ModePattern:
+		return pattern.MatchString(string(r))
+	default:
+		return true
+	}
+}
+
+// Parse reads and cleans the code submitted under p.Name.
+func Parse(r *http.Request, p Props) (string, error) {
+	if err := r.ParseForm(); err != nil {
+		return "", err
+	}
+
+	return Clean(p, r.Form.Get(p.Name))
+}
+
 //go:embed inputotp.js
 var inputOTPJS string
@@ -30,6 +30,78 @@
         .join("");
     }
   }
+  var modes = {
+    numeric: /^[0-9]$/,
+    alphanumeric: /^[A-Za-z0-9]$/,
+    "uppercase-alpha": /^[A-Z]$/,
+  };
+  function normalizeChar(container, ch) {
+    return container.getAttribute("data-pui-inputotp-mode") === "uppercase-alpha"
+      ? ch.toUpperCase()
+      : ch;
+  }
+  function isAllowed(container, ch) {
+    if (/\s/.test(ch)) return false;
+    var mode = container.getAttribute("data-pui-inputotp-mode");
+    if (mode === "pattern") {
+      try {
+        var pattern = container.getAttribute("data-pui-inputotp-pattern") || "";
+        return new RegExp("^(?:" + pattern + ")$").test(ch);
+      } catch (err) {
+        return true;
+      }
+    }
+    return modes[mode] ? modes[mode].test(ch) : true;
+  }
+  // Mirrors Clean in inputotp.go
+  function filterChars(container, text) {
+    var chars = [];
+    Array.from(text).forEach(function (ch) {
+      ch = normalizeChar(container, ch);
+      if (ch !== "-" && isAllowed(container, ch)) chars.push(ch);
+    });
+    return chars;
+  }
+  // Spreads chars over the slots from start; a full code always starts at the first slot
+  function distribute(container, start, chars) {
+    var slots = getSlots(container);
+    if (chars.length >= slots.length) start = 0;
+    for (var i = 0; i < chars.length && start + i < slots.length; i++) {
+      slots[start + i].value = chars[i];
+    }
+    changed(container);
+    var nextEmpty = findFirstEmptySlot(container);
+    focusSlot(
+      nextEmpty || slots[Math.min(start + chars.length, slots.length - 1)],
+    );
+  }
+  function changed(container) {
+    updateHiddenValue(container);
+    var hiddenInput = container.querySelector(
+      "[data-pui-inputotp-value-target]",
+    );
+    var complete = !findFirstEmptySlot(container);
+    var value = hiddenInput ? hiddenInput.value : "";
+    if (!complete || container.getAttribute("data-pui-inputotp-completed") === value) {
+      if (!complete) container.removeAttribute("data-pui-inputotp-completed");
+      return;
+    }
+    container.setAttribute("data-pui-inputotp-completed", value);
+    container.dispatchEvent(
+      new CustomEvent("pui-inputotp:complete", {
+        bubbles: true,
+        detail: { value: value },
+      }),
+    );
+    var form = hiddenInput && hiddenInput.form;
+    if (
+      form &&
+      container.getAttribute("data-pui-inputotp-auto-submit") === "true"
+    ) {
+      if (form.requestSubmit) form.requestSubmit();
+      else form.submit();
+    }
+  }
   function findFirstEmptySlot(container) {
     var slots = getSlots(container);
     for (var i = 0; i < slots.length; i++) {
@@ -47,23 +119,32 @@
     var index = slots.indexOf(currentSlot);
     return index > 0 ? slots[index - 1] : null;
   }
+  document.addEventListener("beforeinput", function (e) {
+    if (!e.target.matches("[data-pui-inputotp-slot]")) return;
+    if (e.inputType !== "insertText" || !e.data) return;
+    var container = e.target.closest("[data-pui-inputotp]");
+    if (container && filterChars(container, e.data).length === 0) {
+      e.preventDefault();
+    }
+  });
   document.addEventListener("input", function (e) {
     if (!e.target.matches("[data-pui-inputotp-slot]")) return;
     var slot = e.target;
     var container = slot.closest("[data-pui-inputotp]");
     if (!container) return;
-    if (slot.value === " ") {
+    var chars = filterChars(container, slot.value);
+    // Autofilled one-time codes and IME commits arrive as several characters at once
+    if (chars.length > 1 && e.inputType !== "insertText") {
       slot.value = "";
+      distribute(container, getSlots(container).indexOf(slot), chars);
       return;
     }
-    if (slot.value.length > 1) {
-      slot.value = slot.value.slice(-1);
-    }
+    slot.value = chars.length ? chars[chars.length - 1] : "";
     if (slot.value) {
       var nextSlot = getNextSlot(container, slot);
       if (nextSlot) focusSlot(nextSlot);
     }
-    updateHiddenValue(container);
+    changed(container);
   });
   document.addEventListener("keydown", function (e) {
     if (!e.target.matches("[data-pui-inputotp-slot]")) return;
@@ -74,12 +155,12 @@
       e.preventDefault();
       if (slot.value) {
         slot.value = "";
-        updateHiddenValue(container);
+        changed(container);
       } else {
         var prevSlot = getPrevSlot(container, slot);
         if (prevSlot) {
           prevSlot.value = "";
-          updateHiddenValue(container);
+          changed(container);
           focusSlot(prevSlot);
         }
       }
@@ -118,17 +199,9 @@
     var container = slot.closest("[data-pui-inputotp]");
     if (!container) return;
     var pasted = (e.clipboardData || window.clipboardData).getData("text");
-    var chars = pasted.replace(/\s/g, "").split("");
-    var slots = getSlots(container);
-    var startIndex = slots.indexOf(slot);
-    for (var i = 0; i < chars.length && startIndex + i < slots.length; i++) {
-      slots[startIndex + i].value = chars[i];
-    }
-    updateHiddenValue(container);
-    var nextEmpty = findFirstEmptySlot(container);
-    focusSlot(
-      nextEmpty || slots[Math.min(startIndex + chars.length, slots.length - 1)],
-    );
+    var chars = filterChars(container, pasted);
+    if (!chars.length) return;
+    distribute(container, getSlots(container).indexOf(slot), chars);
   });
   document.addEventListener("click", function (e) {
     if (!e.target.matches("label[for]")) return;
@@ -153,6 +226,7 @@
         getSlots(container).forEach(function (slot) {
           slot.value = "";
         });
+        container.removeAttribute("data-pui-inputotp-completed");
         updateHiddenValue(container);
       });
   });
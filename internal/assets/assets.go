@@ -0,0 +1,16 @@
+// Package assets attaches component scripts to the nodes that need them.
+//
+// html.Assets collects a named node, children included, the first time it meets that
+// name on a page only; later nodes of the same name are skipped whole. A script named on
+// a component's root is therefore loaded by the first instance alone, and any script
+// nested inside a later instance is never seen. Components instead hang each script they
+// need off an unnamed node as its own hook, through Script or a package's Assets, so
+// every instance offers all of them and the first one on the page loads each.
+package assets
+
+import "github.com/plainkit/html"
+
+// Script returns a child that loads js once per page under name, without rendering output.
+func Script(name, js string) html.ChildOpt {
+	return html.Child(html.AssetHook(name, "", js))
+}
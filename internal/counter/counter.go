@@ -0,0 +1,155 @@
+// Package counter renders the live length counter shared by input and textarea.
+package counter
+
+import (
+	_ "embed"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf16"
+
+	"github.com/plainkit/html"
+)
+
+// Near is the share of the maximum at which the counter starts warning.
+const Near = 0.9
+
+type Props struct {
+	For   string // ID of the field being counted
+	Value string // Initial value
+	Min   int
+	Max   int
+	Words bool // Show the word count next to the character count
+	Soft  bool // Max may be exceeded; the field is flagged instead of truncated
+}
+
+// ID returns the id of the counter describing the field with the given id.
+func ID(fieldID string) string {
+	return fieldID + "-counter"
+}
+
+// Len counts s the way the browser applies maxlength: UTF-16 code units with line
+// breaks normalized to a single character.
+func Len(s string) int {
+	s = strings.ReplaceAll(s, "\r\n", "\n")
+
+	n := 0
+	for _, r := range s {
+		n += utf16.RuneLen(r)
+	}
+
+	return n
+}
+
+// Words counts whitespace separated words in s.
+func Words(s string) int {
+	return len(strings.FieldsFunc(s, unicode.IsSpace))
+}
+
+// Over reports whether value is longer than max; a zero max never overflows.
+func Over(value string, max int) bool {
+	return max > 0 && Len(value) > max
+}
+
+// CheckLength validates value against min and max the way the browser counts it, so a
+// submitted CRLF counts as one character. Each field package passes its own sentinel
+// errors, wrapped with the counts.
+func CheckLength(value string, min, max int, tooShort, tooLong error) error {
+	n := Len(value)
+
+	if min > 0 && n > 0 && n < min {
+		return fmt.Errorf("%w: %d of at least %d characters", tooShort, n, min)
+	}
+
+	if Over(value, max) {
+		return fmt.Errorf("%w: %d of at most %d characters", tooLong, n, max)
+	}
+
+	return nil
+}
+
+// State classifies n against the limits as under, ok, near or over.
+func State(n, min, max int) string {
+	switch {
+	case max > 0 && n > max:
+		return "over"
+	case max > 0 && float64(n) >= float64(max)*Near:
+		return "near"
+	case min > 0 && n > 0 && n < min:
+		return "under"
+	default:
+		return "ok"
+	}
+}
+
+// Text is the visible counter label, such as "120 / 500" or "18 words · 120 / 500".
+func Text(value string, max int, words bool) string {
+	text := strconv.Itoa(Len(value))
+	if max > 0 {
+		text += " / " + strconv.Itoa(max)
+	}
+
+	if words {
+		w := Words(value)
+
+		unit := " words"
+		if w == 1 {
+			unit = " word"
+		}
+
+		text = strconv.Itoa(w) + unit + " · " + text
+	}
+
+	return text
+}
+
+// Counter renders the visible count and a polite live region that the script updates
+// once typing pauses, so screen readers are not interrupted on every keystroke.
+func Counter(p Props) html.Node {
+	n := Len(p.Value)
+
+	args := []html.DivArg{
+		html.AId(ID(p.For)),
+		html.AClass(html.ClassMerge(
+			"flex justify-end text-xs tabular-nums text-muted-foreground transition-colors",
+			"data-[pui-counter-state=near]:text-amber-600 dark:data-[pui-counter-state=near]:text-amber-400",
+			"data-[pui-counter-state=over]:font-medium data-[pui-counter-state=over]:text-destructive",
+		)),
+		html.AData("pui-counter", p.For),
+		html.AData("pui-counter-state", State(n, p.Min, p.Max)),
+	}
+
+	if p.Min > 0 {
+		args = append(args, html.AData("pui-counter-min", strconv.Itoa(p.Min)))
+	}
+
+	if p.Max > 0 {
+		args = append(args, html.AData("pui-counter-max", strconv.Itoa(p.Max)))
+	}
+
+	if p.Words {
+		args = append(args, html.AData("pui-counter-words", "true"))
+	}
+
+	if p.Soft {
+		args = append(args, html.AData("pui-counter-soft", "true"))
+	}
+
+	args = append(args,
+		html.Span(
+			html.AData("pui-counter-text", ""),
+			html.Text(Text(p.Value, p.Max, p.Words)),
+		),
+		html.Span(
+			html.AClass("sr-only"),
+			html.AAria("live", "polite"),
+			html.AData("pui-counter-status", ""),
+		),
+	)
+
+	return html.Div(args...).WithAssets("", counterJS, "ui-counter")
+}
+
+//go:embed counter.js
+var counterJS string
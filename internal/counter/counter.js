@@ -0,0 +1,132 @@
+(function () {
+  "use strict";
+
+  const NEAR = 0.9; // Mirrors Near in counter.go
+  const ANNOUNCE_DELAY = 800;
+
+  const timers = new WeakMap();
+
+  function counterFor(field) {
+    if (!field.id) return null;
+    return document.querySelector(
+      '[data-pui-counter="' + CSS.escape(field.id) + '"]',
+    );
+  }
+
+  function intAttr(el, name) {
+    const n = parseInt(el.getAttribute(name) || "", 10);
+    return Number.isFinite(n) && n > 0 ? n : 0;
+  }
+
+  // The value property already normalizes line breaks, matching maxlength
+  function length(value) {
+    return value.length;
+  }
+
+  // Mirrors Words in counter.go
+  function words(value) {
+    return value.split(/\s+/).filter(Boolean).length;
+  }
+
+  function state(n, min, max) {
+    if (max > 0 && n > max) return "over";
+    if (max > 0 && n >= max * NEAR) return "near";
+    if (min > 0 && n > 0 && n < min) return "under";
+    return "ok";
+  }
+
+  function plural(n, unit) {
+    return n + " " + unit + (n === 1 ? "" : "s");
+  }
+
+  function message(n, min, max, current) {
+    switch (current) {
+      case "over":
+        return plural(n - max, "character") + " over the limit";
+      case "near":
+        return plural(max - n, "character") + " remaining";
+      case "under":
+        return plural(min - n, "more character") + " needed";
+      default:
+        return max > 0
+          ? n + " of " + plural(max, "character") + " used"
+          : plural(n, "character");
+    }
+  }
+
+  function update(field) {
+    const counter = counterFor(field);
+    if (!counter) return;
+
+    const min = intAttr(counter, "data-pui-counter-min");
+    const max = intAttr(counter, "data-pui-counter-max");
+    const n = length(field.value);
+    const previous = counter.getAttribute("data-pui-counter-state");
+    const current = state(n, min, max);
+
+    let text = max > 0 ? n + " / " + max : String(n);
+    if (counter.hasAttribute("data-pui-counter-words")) {
+      const w = words(field.value);
+      text = plural(w, "word") + " · " + text;
+    }
+
+    const label = counter.querySelector("[data-pui-counter-text]");
+    if (label) label.textContent = text;
+    counter.setAttribute("data-pui-counter-state", current);
+
+    // Soft limits let the value overflow and flag the field instead
+    if (counter.hasAttribute("data-pui-counter-soft")) {
+      if (current === "over") {
+        if (field.getAttribute("aria-invalid") !== "true") {
+          field.setAttribute("aria-invalid", "true");
+          field.setAttribute("data-pui-counter-invalid", "true");
+        }
+      } else if (field.hasAttribute("data-pui-counter-invalid")) {
+        field.removeAttribute("aria-invalid");
+        field.removeAttribute("data-pui-counter-invalid");
+      }
+    }
+
+    const status = counter.querySelector("[data-pui-counter-status]");
+    if (!status) return;
+
+    clearTimeout(timers.get(counter));
+    const announce = () => {
+      status.textContent = message(n, min, max, current);
+    };
+
+    // Crossing into a warning is announced at once, everything else once typing pauses
+    if (current !== previous && (current === "over" || current === "near")) {
+      announce();
+    } else {
+      timers.set(counter, setTimeout(announce, ANNOUNCE_DELAY));
+    }
+  }
+
+  document.addEventListener("input", (e) => {
+    const field = e.target;
+    if (field instanceof HTMLInputElement || field instanceof HTMLTextAreaElement) {
+      update(field);
+    }
+  });
+
+  document.addEventListener("reset", (e) => {
+    const form = e.target;
+    if (!(form instanceof HTMLFormElement)) return;
+
+    // Fields hold their default values only after the reset completes
+    setTimeout(() => {
+      for (const field of form.elements) {
+        if (field instanceof HTMLInputElement || field instanceof HTMLTextAreaElement) {
+          if (counterFor(field)) update(field);
+        }
+      }
+    });
+  });
+
+  // Expose public API
+  window.tui = window.tui || {};
+  window.tui.counter = {
+    update: (field) => update(field),
+  };
+})();
@@ -0,0 +1,18 @@
+// Package randomid generates element ids for components rendered without one.
+package randomid
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+)
+
+// New returns prefix followed by eight random hex digits, or prefix + "-id" when the
+// system has no randomness to offer.
+func New(prefix string) string {
+	buf := make([]byte, 4)
+	if _, err := rand.Read(buf); err != nil {
+		return prefix + "-id"
+	}
+
+	return prefix + "-" + hex.EncodeToString(buf)
+}
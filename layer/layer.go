@@ -0,0 +1,30 @@
+// Package layer provides the overlay layer manager shared by dialogs, sheets, popovers and
+// toasts, including the tooltips, dropdowns and selectboxes built on popovers and the
+// suggestion lists of tagsinput and textarea mentions.
+//
+// Open overlays form a single stack in the order they opened. ESC and clicks outside go
+// to the topmost layer only, so closing a popover opened inside a dialog leaves the dialog
+// open, and each layer gets a z-index above the ones below it. Components load the runtime
+// themselves; Assets is only needed for custom overlays using window.tui.layers.
+package layer
+
+import (
+	_ "embed"
+
+	"github.com/plainkit/html"
+)
+
+// Z-index given to the first open layer and added per layer stacked above it. A layer
+// spans Step values, so parts such as a dialog's backdrop and panel stay in order.
+const (
+	BaseZ = 50
+	Step  = 10
+)
+
+// Assets returns a component that loads the client runtime without rendering output.
+func Assets() html.Component {
+	return html.AssetHook("ui-layer", "", layerJS)
+}
+
+//go:embed layer.js
+var layerJS string
@@ -0,0 +1,127 @@
+(function () {
+  "use strict";
+
+  // Mirror BaseZ and Step in layer.go
+  const BASE_Z = 50;
+  const STEP = 10;
+
+  // Open layers in the order they opened, topmost last
+  const layers = [];
+
+  function indexOf(el) {
+    return layers.findIndex((layer) => layer.el === el);
+  }
+
+  // Each layer takes the next band of z-index values; its parts count up within it, so
+  // a backdrop listed before its panel stays beneath it
+  function restack() {
+    layers.forEach((layer, i) => {
+      layer.parts.forEach((part, j) => {
+        part.style.zIndex = String(BASE_Z + i * STEP + j);
+      });
+    });
+  }
+
+  // Register el as the topmost layer. Options:
+  //   parts      elements to stack, bottom first; defaults to [el]
+  //   anchors    elements counting as inside besides el, such as its triggers
+  //   transient  outside clicks close it and still reach the layer below, as for tooltips
+  //   passive    only stacked, as for toasts: it never counts as the top and ESC skips it
+  //   escape     called on ESC while the layer is topmost
+  //   outside    called with the click event on a click outside while it is topmost
+  // Opening an open layer again moves it to the top.
+  function open(el, options) {
+    const o = options || {};
+    close(el);
+    layers.push({
+      el: el,
+      parts: o.parts || [el],
+      anchors: o.anchors || [],
+      transient: !!o.transient || !!o.passive,
+      passive: !!o.passive,
+      escape: o.escape || null,
+      outside: o.outside || null,
+    });
+    restack();
+  }
+
+  // Remove el from the stack. Its parts keep their z-index, so closing animations play
+  // at the same depth.
+  function close(el) {
+    const i = indexOf(el);
+    if (i < 0) return;
+
+    layers.splice(i, 1);
+    restack();
+  }
+
+  // The topmost layer taking ESC and outside clicks
+  function active() {
+    for (let i = layers.length - 1; i >= 0; i--) {
+      if (!layers[i].passive) return layers[i];
+    }
+
+    return null;
+  }
+
+  function top() {
+    const layer = active();
+    return layer ? layer.el : null;
+  }
+
+  function isTop(el) {
+    return !!el && top() === el;
+  }
+
+  function isOpen(el) {
+    return indexOf(el) >= 0;
+  }
+
+  function inside(layer, node) {
+    return layer.el.contains(node) || layer.anchors.some((a) => a.contains(node));
+  }
+
+  // Whether node lies in a layer stacked above el, e.g. a popover opened from a dialog
+  function above(el, node) {
+    return layers.slice(indexOf(el) + 1).some((layer) => inside(layer, node));
+  }
+
+  // ESC goes to the topmost layer that is not passive. A layer with ESC disabled still
+  // keeps it from the layers below. Components handling ESC themselves prevent the
+  // default to keep their layer open.
+  document.addEventListener("keydown", (e) => {
+    if (e.key !== "Escape" || e.defaultPrevented || e.isComposing) return;
+
+    const layer = active();
+    if (!layer) return;
+
+    e.preventDefault();
+    if (layer.escape) layer.escape(e);
+  });
+
+  // Capture runs before the components' own click handlers, so a click that dismisses a
+  // layer and lands on a trigger can still open the next one
+  document.addEventListener(
+    "click",
+    (e) => {
+      for (let i = layers.length - 1; i >= 0; i--) {
+        const layer = layers[i];
+        if (inside(layer, e.target)) return;
+        if (layer.outside) layer.outside(e);
+        if (!layer.transient) return;
+      }
+    },
+    true,
+  );
+
+  // Expose public API
+  window.tui = window.tui || {};
+  window.tui.layers = {
+    open: open,
+    close: close,
+    top: top,
+    isTop: isTop,
+    isOpen: isOpen,
+    above: above,
+  };
+})();
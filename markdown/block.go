@@ -0,0 +1,539 @@
+package markdown
+
+import (
+	"strconv"
+	"strings"
+)
+
+type blockKind int
+
+const (
+	blockParagraph blockKind = iota
+	blockHeading
+	blockThematicBreak
+	blockCode
+	blockQuote
+	blockList
+)
+
+type block struct {
+	kind     blockKind
+	level    int      // Heading level
+	text     string   // Inline source of paragraphs and headings, content of code blocks
+	info     string   // Fenced code info string
+	children []*block // Blockquote content
+	items    [][]*block
+	ordered  bool
+	start    int
+	tight    bool
+}
+
+// maxNesting bounds how deep block quotes and lists nest. Markers past it are read as
+// paragraph text, so hostile input cannot make parsing quadratic.
+const maxNesting = 32
+
+// parseBlocks splits lines into block structure; containers recurse on their stripped lines.
+func parseBlocks(lines []string, depth int) []*block {
+	var blocks []*block
+
+	for i := 0; i < len(lines); {
+		line := lines[i]
+		if isBlank(line) {
+			i++
+			continue
+		}
+
+		indent := leadingSpaces(line)
+		if indent >= 4 {
+			b, next := parseIndentedCode(lines, i)
+			blocks = append(blocks, b)
+			i = next
+
+			continue
+		}
+
+		rest := line[indent:]
+
+		if ch, n, info, ok := openFence(rest); ok {
+			b, next := parseFencedCode(lines, i, indent, ch, n, info)
+			blocks = append(blocks, b)
+			i = next
+
+			continue
+		}
+
+		if level, text, ok := atxHeading(rest); ok {
+			blocks = append(blocks, &block{kind: blockHeading, level: level, text: text})
+			i++
+
+			continue
+		}
+
+		if isThematicBreak(rest) {
+			blocks = append(blocks, &block{kind: blockThematicBreak})
+			i++
+
+			continue
+		}
+
+		if depth < maxNesting && strings.HasPrefix(rest, ">") {
+			b, next := parseQuote(lines, i, depth)
+			blocks = append(blocks, b)
+			i = next
+
+			continue
+		}
+
+		if m, ok := parseListMarker(line); ok && depth < maxNesting {
+			b, next := parseList(lines, i, m, depth)
+			blocks = append(blocks, b)
+			i = next
+
+			continue
+		}
+
+		b, next := parseParagraph(lines, i)
+		blocks = append(blocks, b)
+		i = next
+	}
+
+	return blocks
+}
+
+func parseIndentedCode(lines []string, i int) (*block, int) {
+	var code []string
+
+	for ; i < len(lines); i++ {
+		line := lines[i]
+		if !isBlank(line) && leadingSpaces(line) < 4 {
+			break
+		}
+
+		code = append(code, stripSpaces(line, 4))
+	}
+
+	for len(code) > 0 && isBlank(code[len(code)-1]) {
+		code = code[:len(code)-1]
+	}
+
+	return &block{kind: blockCode, text: strings.Join(code, "\n") + "\n"}, i
+}
+
+func openFence(rest string) (ch byte, n int, info string, ok bool) {
+	if rest == "" || (rest[0] != '`' && rest[0] != '~') {
+		return 0, 0, "", false
+	}
+
+	ch = rest[0]
+	for n < len(rest) && rest[n] == ch {
+		n++
+	}
+
+	if n < 3 {
+		return 0, 0, "", false
+	}
+
+	info = strings.TrimSpace(rest[n:])
+	if ch == '`' && strings.Contains(info, "`") {
+		return 0, 0, "", false
+	}
+
+	return ch, n, info, true
+}
+
+func parseFencedCode(lines []string, i, indent int, ch byte, n int, info string) (*block, int) {
+	var code []string
+
+	for i++; i < len(lines); i++ {
+		line := lines[i]
+		rest := strings.TrimLeft(line, " ")
+
+		if leadingSpaces(line) < 4 && strings.HasPrefix(rest, strings.Repeat(string(ch), n)) &&
+			strings.Trim(rest, string(ch)+" ") == "" {
+			i++
+			break
+		}
+
+		code = append(code, stripSpaces(line, indent))
+	}
+
+	text := strings.Join(code, "\n")
+	if len(code) > 0 {
+		text += "\n"
+	}
+
+	if f := strings.Fields(info); len(f) > 0 {
+		info = unescapeText(f[0])
+	}
+
+	return &block{kind: blockCode, text: text, info: info}, i
+}
+
+func atxHeading(rest string) (int, string, bool) {
+	level := 0
+	for level < len(rest) && rest[level] == '#' {
+		level++
+	}
+
+	if level == 0 || level > 6 || (level < len(rest) && rest[level] != ' ') {
+		return 0, "", false
+	}
+
+	text := strings.TrimSpace(rest[level:])
+
+	// An optional closing sequence of #s is dropped when preceded by a space
+	if trimmed := strings.TrimRight(text, "#"); trimmed == "" {
+		text = ""
+	} else if trimmed != text && strings.HasSuffix(trimmed, " ") {
+		text = strings.TrimSpace(trimmed)
+	}
+
+	return level, text, true
+}
+
+func isThematicBreak(rest string) bool {
+	s := strings.ReplaceAll(strings.TrimSpace(rest), " ", "")
+	if len(s) < 3 {
+		return false
+	}
+
+	ch := s[0]
+	if ch != '-' && ch != '*' && ch != '_' {
+		return false
+	}
+
+	return strings.Trim(s, string(ch)) == ""
+}
+
+func parseQuote(lines []string, i, depth int) (*block, int) {
+	var (
+		inner     []string
+		paragraph bool // Whether the last quoted line continues a paragraph, allowing lazy lines
+	)
+
+	for ; i < len(lines); i++ {
+		line := lines[i]
+		indent := leadingSpaces(line)
+
+		if indent < 4 && strings.HasPrefix(line[indent:], ">") {
+			content := line[indent+1:]
+			content = strings.TrimPrefix(content, " ")
+			inner = append(inner, content)
+			paragraph = !isBlank(content) && (paragraph || leadingSpaces(content) < 4) && !startsLeaf(strings.TrimLeft(content, " "))
+
+			continue
+		}
+
+		if paragraph && !isBlank(line) && !interrupts(line) {
+			inner = append(inner, line)
+			continue
+		}
+
+		break
+	}
+
+	return &block{kind: blockQuote, children: parseBlocks(inner, depth+1)}, i
+}
+
+type listMarker struct {
+	ordered bool
+	bullet  byte // Bullet character, or the delimiter after the number
+	start   int
+	width   int // Columns up to the item content
+	empty   bool
+}
+
+func parseListMarker(line string) (listMarker, bool) {
+	indent := leadingSpaces(line)
+	if indent >= 4 {
+		return listMarker{}, false
+	}
+
+	rest := line[indent:]
+
+	var m listMarker
+
+	switch {
+	case rest != "" && (rest[0] == '-' || rest[0] == '+' || rest[0] == '*'):
+		m.bullet = rest[0]
+		m.width = 1
+	default:
+		digits := 0
+		for digits < len(rest) && digits < 9 && rest[digits] >= '0' && rest[digits] <= '9' {
+			digits++
+		}
+
+		if digits == 0 || digits >= len(rest) || (rest[digits] != '.' && rest[digits] != ')') {
+			return listMarker{}, false
+		}
+
+		m.ordered = true
+		m.start, _ = strconv.Atoi(rest[:digits])
+		m.bullet = rest[digits]
+		m.width = digits + 1
+	}
+
+	after := rest[m.width:]
+	if after != "" && after[0] != ' ' {
+		return listMarker{}, false
+	}
+
+	spaces := leadingSpaces(after)
+	m.empty = isBlank(after)
+
+	switch {
+	case m.empty:
+		spaces = 1
+	case spaces > 4:
+		// The content is indented code; the item itself starts one space after the marker
+		spaces = 1
+	}
+
+	m.width += indent + spaces
+
+	return m, true
+}
+
+func (m listMarker) sameList(o listMarker) bool {
+	return m.ordered == o.ordered && m.bullet == o.bullet
+}
+
+func parseList(lines []string, i int, first listMarker, depth int) (*block, int) {
+	list := &block{kind: blockList, ordered: first.ordered, start: first.start, tight: true}
+
+	m := first
+
+	for i < len(lines) {
+		var (
+			item        []string
+			trailing    int // Blank lines at the end of item
+			lastContent = true
+		)
+
+		line := lines[i]
+		if m.width <= len(line) {
+			item = append(item, line[m.width:])
+		} else {
+			item = append(item, "")
+			lastContent = false
+		}
+
+		i++
+
+		for ; i < len(lines); i++ {
+			line := lines[i]
+
+			if isBlank(line) {
+				// An item may begin with at most one blank line
+				if len(item) == 1 && m.empty {
+					break
+				}
+
+				item = append(item, "")
+				trailing++
+				lastContent = false
+
+				continue
+			}
+
+			if leadingSpaces(line) >= m.width {
+				item = append(item, line[m.width:])
+				trailing = 0
+				lastContent = true
+
+				continue
+			}
+
+			if lastContent && trailing == 0 && !interrupts(line) {
+				if _, ok := parseListMarker(line); !ok {
+					item = append(item, strings.TrimLeft(line, " "))
+					continue
+				}
+			}
+
+			break
+		}
+
+		content := item[:len(item)-trailing]
+		if looseItem(content) {
+			list.tight = false
+		}
+
+		list.items = append(list.items, parseBlocks(content, depth+1))
+
+		if i >= len(lines) || isThematicBreak(strings.TrimLeft(lines[i], " ")) {
+			break
+		}
+
+		next, ok := parseListMarker(lines[i])
+		if !ok || !next.sameList(first) {
+			break
+		}
+
+		if trailing > 0 {
+			list.tight = false
+		}
+
+		m = next
+	}
+
+	return list, i
+}
+
+// looseItem reports a blank line between two blocks directly inside an item.
+func looseItem(lines []string) bool {
+	var (
+		fenced bool
+		gap    bool // Whether a blank line outside a fence precedes the current one
+	)
+
+	for k, line := range lines {
+		if isBlank(line) {
+			gap = gap || (!fenced && k > 0)
+			continue
+		}
+
+		if gap && leadingSpaces(line) == 0 {
+			return true
+		}
+
+		gap = false
+
+		if _, _, _, ok := openFence(strings.TrimLeft(line, " ")); ok && leadingSpaces(line) < 4 {
+			fenced = !fenced
+		}
+	}
+
+	return false
+}
+
+func parseParagraph(lines []string, i int) (*block, int) {
+	text := []string{strings.TrimLeft(lines[i], " ")}
+
+	for i++; i < len(lines); i++ {
+		line := lines[i]
+		if isBlank(line) {
+			break
+		}
+
+		// Setext underline turns the paragraph into a heading
+		if leadingSpaces(line) < 4 {
+			underline := strings.TrimSpace(line)
+			if underline != "" && strings.Trim(underline, "=") == "" {
+				return &block{kind: blockHeading, level: 1, text: strings.Join(text, "\n")}, i + 1
+			}
+
+			if underline != "" && strings.Trim(underline, "-") == "" {
+				return &block{kind: blockHeading, level: 2, text: strings.Join(text, "\n")}, i + 1
+			}
+		}
+
+		if interrupts(line) {
+			break
+		}
+
+		text = append(text, strings.TrimLeft(line, " "))
+	}
+
+	return &block{kind: blockParagraph, text: strings.TrimRight(strings.Join(text, "\n"), " ")}, i
+}
+
+// interrupts reports whether line starts a block that may interrupt a paragraph.
+func interrupts(line string) bool {
+	indent := leadingSpaces(line)
+	if indent >= 4 {
+		return false
+	}
+
+	if startsBlock(line[indent:]) {
+		return true
+	}
+
+	m, ok := parseListMarker(line)
+
+	return ok && !m.empty && (!m.ordered || m.start == 1)
+}
+
+// startsBlock reports the non-list blocks recognised at the start of rest.
+func startsBlock(rest string) bool {
+	if _, _, _, ok := openFence(rest); ok {
+		return true
+	}
+
+	if _, _, ok := atxHeading(rest); ok {
+		return true
+	}
+
+	return isThematicBreak(rest) || strings.HasPrefix(rest, ">")
+}
+
+// startsLeaf reports fences, headings and thematic breaks, which never continue a paragraph.
+func startsLeaf(rest string) bool {
+	return startsBlock(rest) && !strings.HasPrefix(rest, ">")
+}
+
+func isBlank(line string) bool {
+	return strings.TrimSpace(line) == ""
+}
+
+func leadingSpaces(line string) int {
+	n := 0
+	for n < len(line) && line[n] == ' ' {
+		n++
+	}
+
+	return n
+}
+
+func stripSpaces(line string, n int) string {
+	i := 0
+	for i < n && i < len(line) && line[i] == ' ' {
+		i++
+	}
+
+	return line[i:]
+}
+
+// splitLines normalizes line endings and expands tabs in the indentation.
+func splitLines(src string) []string {
+	src = strings.ReplaceAll(src, "\r\n", "\n")
+	src = strings.ReplaceAll(src, "\r", "\n")
+	src = strings.ReplaceAll(src, "\x00", "\uFFFD")
+
+	lines := strings.Split(src, "\n")
+	for i, line := range lines {
+		lines[i] = expandTabs(line)
+	}
+
+	return lines
+}
+
+func expandTabs(line string) string {
+	if !strings.Contains(line, "\t") {
+		return line
+	}
+
+	var sb strings.Builder
+
+	col := 0
+	leading := true
+
+	for _, r := range line {
+		if r == '\t' && leading {
+			n := 4 - col%4
+			sb.WriteString(strings.Repeat(" ", n))
+			col += n
+
+			continue
+		}
+
+		if r != ' ' && r != '>' {
+			leading = false
+		}
+
+		sb.WriteRune(r)
+		col++
+	}
+
+	return sb.String()
+}
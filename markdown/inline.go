@@ -0,0 +1,767 @@
+package markdown
+
+import (
+	"fmt"
+	stdhtml "html"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+type inlineKind int
+
+const (
+	inlineText  inlineKind = iota // Literal text, escaped when rendered
+	inlineHTML                    // Markup produced by the renderer itself
+	inlineDelim                   // Run of *, _ or ~ that may become emphasis
+)
+
+type inline struct {
+	kind  inlineKind
+	s     string
+	ch    byte // Delimiter character
+	count int  // Delimiter characters not yet used by emphasis
+	next  int  // Following node, or -1
+	prev  int
+}
+
+// delimiter is an entry of the stack of runs that may still open or close emphasis.
+type delimiter struct {
+	node     int
+	ch       byte
+	orig     int // Length of the run before any was used
+	canOpen  bool
+	canClose bool
+	prev     int // Delimiter below, or -1
+	next     int // Delimiter above, or -1
+}
+
+// bracket is an entry of the stack of [ and ![ that may still open a link or image.
+type bracket struct {
+	node   int
+	image  bool
+	active bool
+	bottom int // Topmost delimiter when the bracket was opened
+}
+
+// Limits keeping the parser linear on hostile input. Unbalanced parentheses deeper than
+// maxParens end a link destination, as CommonMark allows.
+const maxParens = 32
+
+// inlineParser turns inline source into a linked list of nodes. Emphasis and links are
+// resolved with the CommonMark delimiter and bracket stacks, inserting tag nodes around
+// their content instead of rendering it, so no content is walked more than a few times.
+type inlineParser struct {
+	src      string
+	nodes    []inline // nodes[0] is the head of the list
+	last     int
+	text     strings.Builder
+	delims   []delimiter
+	top      int // Topmost delimiter, or -1
+	brackets []bracket
+	floor    int // Link brackets below floor are inactive already
+
+	// Positions from which a scan for a closing code span or title already failed; any
+	// later scan fails as well
+	noCodeEnd  map[int]int
+	noTitleEnd map[byte]int
+}
+
+var (
+	entityRe   = regexp.MustCompile(`^&(?:#[xX][0-9a-fA-F]{1,6}|#[0-9]{1,7}|[a-zA-Z][a-zA-Z0-9]{1,31});`)
+	autolinkRe = regexp.MustCompile(`^<([a-zA-Z][a-zA-Z0-9+.\-]{1,31}:[^<>\x00-\x20]*)>`)
+	emailRe    = regexp.MustCompile(`^<([a-zA-Z0-9.!#$%&'*+/=?^_` + "`" + `{|}~\-]+@[a-zA-Z0-9](?:[a-zA-Z0-9\-]{0,61}[a-zA-Z0-9])?(?:\.[a-zA-Z0-9](?:[a-zA-Z0-9\-]{0,61}[a-zA-Z0-9])?)*)>`)
+)
+
+// renderInline renders the inline content of a paragraph or heading.
+func renderInline(src string) string {
+	p := &inlineParser{
+		src:        src,
+		nodes:      []inline{{kind: inlineText, next: -1, prev: -1}},
+		top:        -1,
+		noCodeEnd:  map[int]int{},
+		noTitleEnd: map[byte]int{},
+	}
+
+	p.parse()
+	p.processEmphasis(-1)
+
+	return p.render(p.nodes[0].next)
+}
+
+// add appends n to the list and returns its index.
+func (p *inlineParser) add(n inline) int {
+	p.flush()
+
+	return p.insertAfter(p.last, n)
+}
+
+func (p *inlineParser) flush() {
+	if p.text.Len() > 0 {
+		s := p.text.String()
+		p.text.Reset()
+		p.insertAfter(p.last, inline{kind: inlineText, s: s})
+	}
+}
+
+func (p *inlineParser) insertAfter(at int, n inline) int {
+	k := len(p.nodes)
+	n.prev, n.next = at, p.nodes[at].next
+	p.nodes = append(p.nodes, n)
+
+	if n.next >= 0 {
+		p.nodes[n.next].prev = k
+	} else {
+		p.last = k
+	}
+
+	p.nodes[at].next = k
+
+	return k
+}
+
+func (p *inlineParser) insertBefore(at int, n inline) int {
+	return p.insertAfter(p.nodes[at].prev, n)
+}
+
+func (p *inlineParser) parse() {
+	src := p.src
+
+	for i := 0; i < len(src); {
+		c := src[i]
+
+		switch c {
+		case '\\':
+			if i+1 < len(src) && src[i+1] == '\n' {
+				p.add(inline{kind: inlineHTML, s: "<br />\n"})
+				i += 2
+				i += leadingSpaces(src[i:])
+
+				continue
+			}
+
+			if i+1 < len(src) && isASCIIPunct(src[i+1]) {
+				p.text.WriteByte(src[i+1])
+				i += 2
+
+				continue
+			}
+
+			p.text.WriteByte('\\')
+			i++
+		case '`':
+			n := runLength(src, i, '`')
+			if content, end, ok := p.codeSpan(i, n); ok {
+				p.add(inline{kind: inlineHTML, s: "<code>" + escape(content) + "</code>"})
+				i = end
+
+				continue
+			}
+
+			p.text.WriteString(src[i : i+n])
+			i += n
+		case '*', '_', '~':
+			n := runLength(src, i, c)
+			if c == '~' && n != 2 {
+				p.text.WriteString(src[i : i+n])
+				i += n
+
+				continue
+			}
+
+			canOpen, canClose := flanking(src, i, i+n, c)
+			node := p.add(inline{kind: inlineDelim, s: src[i : i+n], ch: c, count: n})
+			p.push(delimiter{node: node, ch: c, orig: n, canOpen: canOpen, canClose: canClose})
+			i += n
+		case '!':
+			if i+1 < len(src) && src[i+1] == '[' {
+				node := p.add(inline{kind: inlineText, s: "!["})
+				p.brackets = append(p.brackets, bracket{node: node, image: true, active: true, bottom: p.top})
+				i += 2
+
+				continue
+			}
+
+			p.text.WriteByte('!')
+			i++
+		case '[':
+			node := p.add(inline{kind: inlineText, s: "["})
+			p.brackets = append(p.brackets, bracket{node: node, active: true, bottom: p.top})
+			i++
+		case ']':
+			p.flush()
+
+			next, ok := p.closeBracket(i)
+			if !ok {
+				p.text.WriteByte(']')
+				i++
+
+				continue
+			}
+
+			i = next
+		case '<':
+			if m := autolinkRe.FindStringSubmatch(src[i:]); m != nil {
+				p.add(autolink(m[1], m[1]))
+				i += len(m[0])
+
+				continue
+			}
+
+			if m := emailRe.FindStringSubmatch(src[i:]); m != nil {
+				p.add(autolink("mailto:"+m[1], m[1]))
+				i += len(m[0])
+
+				continue
+			}
+
+			p.text.WriteByte('<')
+			i++
+		case '&':
+			if m := entityRe.FindString(src[i:]); m != "" {
+				p.text.WriteString(stdhtml.UnescapeString(m))
+				i += len(m)
+
+				continue
+			}
+
+			p.text.WriteByte('&')
+			i++
+		case '\n':
+			// Two trailing spaces make a hard break, anything else a soft one
+			s := p.text.String()
+			trimmed := strings.TrimRight(s, " ")
+			hard := len(s)-len(trimmed) >= 2
+
+			// Text without trailing spaces stays in the builder, so long runs of lines
+			// are not copied once per line
+			if len(trimmed) < len(s) {
+				p.text.Reset()
+				p.text.WriteString(trimmed)
+				p.flush()
+			}
+
+			if hard {
+				p.add(inline{kind: inlineHTML, s: "<br />\n"})
+			} else {
+				p.text.WriteByte('\n')
+			}
+
+			i++
+			i += leadingSpaces(src[i:])
+		default:
+			p.text.WriteByte(c)
+			i++
+		}
+	}
+
+	p.flush()
+}
+
+func (p *inlineParser) push(d delimiter) {
+	d.prev, d.next = p.top, -1
+	p.delims = append(p.delims, d)
+
+	k := len(p.delims) - 1
+	if p.top >= 0 {
+		p.delims[p.top].next = k
+	}
+
+	p.top = k
+}
+
+func (p *inlineParser) remove(d int) {
+	prev, next := p.delims[d].prev, p.delims[d].next
+	if prev >= 0 {
+		p.delims[prev].next = next
+	}
+
+	if next >= 0 {
+		p.delims[next].prev = prev
+	} else {
+		p.top = prev
+	}
+}
+
+func (p *inlineParser) popBracket() bracket {
+	b := p.brackets[len(p.brackets)-1]
+	p.brackets = p.brackets[:len(p.brackets)-1]
+	p.floor = min(p.floor, len(p.brackets))
+
+	return b
+}
+
+// closeBracket turns the nodes after the topmost open bracket into a link or image when
+// the source continues with an inline destination after the ] at i.
+func (p *inlineParser) closeBracket(i int) (int, bool) {
+	if len(p.brackets) == 0 {
+		return 0, false
+	}
+
+	b := p.brackets[len(p.brackets)-1]
+	if !b.active {
+		p.popBracket()
+		return 0, false
+	}
+
+	dest, title, end, ok := p.linkDestination(i + 1)
+	if !ok {
+		p.popBracket()
+		return 0, false
+	}
+
+	p.popBracket()
+	p.processEmphasis(b.bottom)
+
+	if b.image {
+		alt := escape(plainText(p.render(p.nodes[b.node].next)))
+
+		// The image replaces its content
+		p.nodes[b.node].next = -1
+		p.last = b.node
+
+		if url, ok := safeURL(dest, true); ok {
+			p.nodes[b.node] = inline{kind: inlineHTML, s: `<img src="` + escape(url) + `" alt="` + alt + `"` + titleAttr(title) + ` />`, prev: p.nodes[b.node].prev, next: -1}
+		} else {
+			p.nodes[b.node] = inline{kind: inlineHTML, s: alt, prev: p.nodes[b.node].prev, next: -1}
+		}
+
+		return end, true
+	}
+
+	p.nodes[b.node].kind = inlineHTML
+	p.nodes[b.node].s = ""
+
+	if url, ok := safeURL(dest, false); ok {
+		p.nodes[b.node].s = `<a href="` + escape(url) + `"` + titleAttr(title) + ` rel="nofollow noopener">`
+		p.add(inline{kind: inlineHTML, s: "</a>"})
+	}
+
+	// Links may not contain other links. Brackets below floor were deactivated by an
+	// earlier link already.
+	for k := p.floor; k < len(p.brackets); k++ {
+		if !p.brackets[k].image {
+			p.brackets[k].active = false
+		}
+	}
+
+	p.floor = len(p.brackets)
+
+	return end, true
+}
+
+// linkDestination parses `(destination "title")` starting at i.
+func (p *inlineParser) linkDestination(i int) (dest, title string, end int, ok bool) {
+	src := p.src
+	if i >= len(src) || src[i] != '(' {
+		return "", "", 0, false
+	}
+
+	i = skipSpace(src, i+1)
+
+	switch {
+	case i < len(src) && src[i] == '<':
+		j := i + 1
+		for j < len(src) && src[j] != '>' && src[j] != '\n' && src[j] != '<' {
+			if src[j] == '\\' && j+1 < len(src) {
+				j++
+			}
+			j++
+		}
+
+		if j >= len(src) || src[j] != '>' {
+			return "", "", 0, false
+		}
+
+		dest = src[i+1 : j]
+		i = j + 1
+	default:
+		j, depth := i, 0
+
+	scan:
+		for j < len(src) {
+			switch c := src[j]; {
+			case c == '\\' && j+1 < len(src) && isASCIIPunct(src[j+1]):
+				j += 2
+				continue
+			case c == '(':
+				depth++
+				if depth > maxParens {
+					return "", "", 0, false
+				}
+			case c == ')':
+				if depth == 0 {
+					break scan
+				}
+				depth--
+			case c <= ' ':
+				break scan
+			}
+			j++
+		}
+
+		dest = src[i:j]
+		i = j
+	}
+
+	start := i
+	i = skipSpace(src, i)
+
+	if i < len(src) && i > start && (src[i] == '"' || src[i] == '\'' || src[i] == '(') {
+		closer := src[i]
+		if closer == '(' {
+			closer = ')'
+		}
+
+		from := i + 1
+		if failed, ok := p.noTitleEnd[closer]; ok && from >= failed {
+			return "", "", 0, false
+		}
+
+		j := from
+		for j < len(src) && src[j] != closer {
+			if src[j] == '\\' && j+1 < len(src) {
+				j++
+			}
+			j++
+		}
+
+		if j >= len(src) {
+			p.noTitleEnd[closer] = from
+			return "", "", 0, false
+		}
+
+		title = src[i+1 : j]
+		i = skipSpace(src, j+1)
+	}
+
+	if i >= len(src) || src[i] != ')' {
+		return "", "", 0, false
+	}
+
+	return unescapeText(dest), unescapeText(title), i + 1, true
+}
+
+// codeSpan finds the run of n backticks closing the one at i.
+func (p *inlineParser) codeSpan(i, n int) (string, int, bool) {
+	src := p.src
+	from := i + n
+
+	if failed, ok := p.noCodeEnd[n]; ok && from >= failed {
+		return "", 0, false
+	}
+
+	for j := from; j < len(src); {
+		if src[j] != '`' {
+			j++
+			continue
+		}
+
+		m := runLength(src, j, '`')
+		if m == n {
+			content := strings.ReplaceAll(src[from:j], "\n", " ")
+			if len(content) > 2 && content[0] == ' ' && content[len(content)-1] == ' ' && strings.Trim(content, " ") != "" {
+				content = content[1 : len(content)-1]
+			}
+
+			return content, j + m, true
+		}
+
+		j += m
+	}
+
+	p.noCodeEnd[n] = from
+
+	return "", 0, false
+}
+
+// flanking applies the CommonMark left- and right-flanking rules to the run src[i:j].
+func flanking(src string, i, j int, c byte) (canOpen, canClose bool) {
+	before, after := ' ', ' '
+	if i > 0 {
+		before, _ = utf8.DecodeLastRuneInString(src[:i])
+	}
+
+	if j < len(src) {
+		after, _ = utf8.DecodeRuneInString(src[j:])
+	}
+
+	left := !unicode.IsSpace(after) && (!isPunct(after) || unicode.IsSpace(before) || isPunct(before))
+	right := !unicode.IsSpace(before) && (!isPunct(before) || unicode.IsSpace(after) || isPunct(after))
+
+	if c == '_' {
+		return left && (!right || isPunct(before)), right && (!left || isPunct(after))
+	}
+
+	return left, right
+}
+
+// processEmphasis pairs the delimiters above bottom following the CommonMark algorithm,
+// inserting em, strong or del tags after each opener and before its closer. Once no
+// opener is found for a kind of closer, later closers of that kind do not look below it
+// again, which keeps the search linear.
+func (p *inlineParser) processEmphasis(bottom int) {
+	var openersBottom [3][2][3]int
+	for a := range openersBottom {
+		for b := range openersBottom[a] {
+			for c := range openersBottom[a][b] {
+				openersBottom[a][b][c] = bottom
+			}
+		}
+	}
+
+	// The first delimiter above bottom
+	c := -1
+	if bottom >= 0 {
+		c = p.delims[bottom].next
+	} else if p.top >= 0 {
+		for c = p.top; p.delims[c].prev >= 0; c = p.delims[c].prev {
+		}
+	}
+
+	for c >= 0 {
+		closer := p.delims[c]
+		if !closer.canClose {
+			c = closer.next
+			continue
+		}
+
+		kind, canOpen, length := delimKind(closer.ch), 0, closer.orig%3
+		if closer.canOpen {
+			canOpen = 1
+		}
+
+		if closer.ch == '~' {
+			canOpen, length = 0, 0
+		}
+
+		o := closer.prev
+		for o != bottom && o != openersBottom[kind][canOpen][length] && o >= 0 {
+			if p.pairs(o, c) {
+				break
+			}
+
+			o = p.delims[o].prev
+		}
+
+		if o == bottom || o == openersBottom[kind][canOpen][length] || o < 0 {
+			openersBottom[kind][canOpen][length] = closer.prev
+			if !closer.canOpen {
+				p.remove(c)
+			}
+
+			c = closer.next
+
+			continue
+		}
+
+		opener := p.nodes[p.delims[o].node].count
+		use := 1
+		if opener >= 2 && p.nodes[closer.node].count >= 2 {
+			use = 2
+		}
+
+		tag := "em"
+
+		switch {
+		case closer.ch == '~':
+			tag = "del"
+		case use == 2:
+			tag = "strong"
+		}
+
+		p.nodes[p.delims[o].node].count -= use
+		p.nodes[closer.node].count -= use
+		p.insertAfter(p.delims[o].node, inline{kind: inlineHTML, s: "<" + tag + ">"})
+		p.insertBefore(closer.node, inline{kind: inlineHTML, s: "</" + tag + ">"})
+
+		// Delimiters between the pair can no longer match
+		p.delims[o].next = c
+		p.delims[c].prev = o
+
+		if p.nodes[p.delims[o].node].count == 0 {
+			p.remove(o)
+		}
+
+		if p.nodes[closer.node].count == 0 {
+			next := p.delims[c].next
+			p.remove(c)
+			c = next
+		}
+	}
+
+	// Unmatched delimiters stay as text
+	p.top = bottom
+	if bottom >= 0 {
+		p.delims[bottom].next = -1
+	}
+}
+
+// pairs reports whether the delimiter o can open emphasis closed by c.
+func (p *inlineParser) pairs(o, c int) bool {
+	opener, closer := p.delims[o], p.delims[c]
+	if opener.ch != closer.ch || !opener.canOpen {
+		return false
+	}
+
+	if closer.ch == '~' {
+		return p.nodes[opener.node].count == 2 && p.nodes[closer.node].count == 2
+	}
+
+	// The rule of three keeps runs like *foo**bar* from pairing up wrongly
+	return !((opener.canClose || closer.canOpen) && (opener.orig+closer.orig)%3 == 0 && (opener.orig%3 != 0 || closer.orig%3 != 0))
+}
+
+func delimKind(ch byte) int {
+	switch ch {
+	case '_':
+		return 1
+	case '~':
+		return 2
+	}
+
+	return 0
+}
+
+// render writes the list from node k to its end.
+func (p *inlineParser) render(k int) string {
+	var sb strings.Builder
+
+	for ; k >= 0; k = p.nodes[k].next {
+		n := p.nodes[k]
+
+		switch n.kind {
+		case inlineHTML:
+			sb.WriteString(n.s)
+		case inlineDelim:
+			sb.WriteString(escape(strings.Repeat(string(n.ch), n.count)))
+		default:
+			sb.WriteString(escape(n.s))
+		}
+	}
+
+	return sb.String()
+}
+
+// plainText flattens rendered inline content for image alt text.
+func plainText(rendered string) string {
+	return stdhtml.UnescapeString(tagRe.ReplaceAllString(rendered, ""))
+}
+
+var tagRe = regexp.MustCompile(`<[^>]*>`)
+
+func autolink(dest, label string) inline {
+	url, ok := safeURL(dest, false)
+	if !ok {
+		return inline{kind: inlineText, s: "<" + label + ">"}
+	}
+
+	return inline{kind: inlineHTML, s: `<a href="` + escape(url) + `" rel="nofollow noopener">` + escape(label) + `</a>`}
+}
+
+func titleAttr(title string) string {
+	if title == "" {
+		return ""
+	}
+
+	return ` title="` + escape(title) + `"`
+}
+
+// safeURL allows relative URLs, fragments and http, https and mailto links (images only
+// http and https) and percent-encodes characters that may not appear in an attribute.
+func safeURL(raw string, image bool) (string, bool) {
+	raw = strings.TrimSpace(raw)
+
+	if colon := strings.IndexByte(raw, ':'); colon >= 0 && !strings.ContainsAny(raw[:colon], "/?#") {
+		switch strings.ToLower(raw[:colon]) {
+		case "http", "https":
+		case "mailto":
+			if image {
+				return "", false
+			}
+		default:
+			return "", false
+		}
+	}
+
+	var sb strings.Builder
+
+	for i := 0; i < len(raw); i++ {
+		c := raw[i]
+
+		switch {
+		case c == '%' && i+2 < len(raw) && isHex(raw[i+1]) && isHex(raw[i+2]):
+			sb.WriteByte(c)
+		case c <= ' ' || c >= 0x7f || strings.IndexByte("\"<>\\^`{|}", c) >= 0 || c == '%':
+			fmt.Fprintf(&sb, "%%%02X", c)
+		default:
+			sb.WriteByte(c)
+		}
+	}
+
+	return sb.String(), true
+}
+
+var escaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", `"`, "&quot;")
+
+func escape(s string) string {
+	return escaper.Replace(s)
+}
+
+// unescapeText resolves backslash escapes and entities in link destinations, titles and info strings.
+func unescapeText(s string) string {
+	var sb strings.Builder
+
+	for i := 0; i < len(s); {
+		switch {
+		case s[i] == '\\' && i+1 < len(s) && isASCIIPunct(s[i+1]):
+			sb.WriteByte(s[i+1])
+			i += 2
+		case s[i] == '&':
+			if m := entityRe.FindString(s[i:]); m != "" {
+				sb.WriteString(stdhtml.UnescapeString(m))
+				i += len(m)
+
+				continue
+			}
+
+			sb.WriteByte('&')
+			i++
+		default:
+			sb.WriteByte(s[i])
+			i++
+		}
+	}
+
+	return sb.String()
+}
+
+func runLength(s string, i int, c byte) int {
+	n := 0
+	for i+n < len(s) && s[i+n] == c {
+		n++
+	}
+
+	return n
+}
+
+func skipSpace(s string, i int) int {
+	for i < len(s) && (s[i] == ' ' || s[i] == '\n') {
+		i++
+	}
+
+	return i
+}
+
+func isASCIIPunct(c byte) bool {
+	return c < 0x80 && c > ' ' && !('a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9') && c != 0x7f
+}
+
+func isPunct(r rune) bool {
+	return unicode.IsPunct(r) || unicode.IsSymbol(r)
+}
+
+func isHex(c byte) bool {
+	return '0' <= c && c <= '9' || 'a' <= c && c <= 'f' || 'A' <= c && c <= 'F'
+}
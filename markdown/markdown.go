@@ -0,0 +1,173 @@
+package markdown
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/plainkit/html"
+)
+
+// PreviewParam is the form field carrying the source posted to Handler.
+const PreviewParam = "text"
+
+// MaxPreviewBytes limits the source Handler accepts, well above a comment or description.
+const MaxPreviewBytes = 128 << 10
+
+// ProseClass styles rendered markdown without a typography plugin.
+const ProseClass = "space-y-3 text-sm leading-relaxed text-foreground break-words " +
+	"[&_h1]:text-2xl [&_h1]:font-semibold [&_h1]:tracking-tight [&_h2]:text-xl [&_h2]:font-semibold " +
+	"[&_h3]:text-lg [&_h3]:font-semibold [&_h4]:font-semibold [&_h5]:font-semibold [&_h6]:font-semibold " +
+	"[&_a]:text-primary [&_a]:underline [&_a]:underline-offset-4 " +
+	"[&_ul]:list-disc [&_ul]:space-y-1 [&_ul]:pl-6 [&_ol]:list-decimal [&_ol]:space-y-1 [&_ol]:pl-6 " +
+	"[&_blockquote]:border-l-2 [&_blockquote]:border-border [&_blockquote]:pl-4 [&_blockquote]:text-muted-foreground " +
+	"[&_code]:rounded [&_code]:bg-muted/70 [&_code]:px-1 [&_code]:py-0.5 [&_code]:font-mono [&_code]:text-[0.85em] " +
+	"[&_pre]:overflow-x-auto [&_pre]:rounded-lg [&_pre]:bg-muted/70 [&_pre]:p-3 [&_pre_code]:bg-transparent [&_pre_code]:p-0 " +
+	"[&_hr]:border-border [&_img]:max-w-full [&_img]:rounded-lg"
+
+type Props struct {
+	ID     string
+	Class  string
+	Attrs  []html.Global
+	Source string
+}
+
+func divArgsFromProps(baseClass string, extra ...string) func(p Props) []html.DivArg {
+	return func(p Props) []html.DivArg {
+		args := []html.DivArg{html.AClass(html.ClassMerge(append([]string{baseClass}, append(extra, p.Class)...)...))}
+		if p.ID != "" {
+			args = append(args, html.AId(p.ID))
+		}
+
+		for _, a := range p.Attrs {
+			args = append(args, a)
+		}
+
+		return args
+	}
+}
+
+func (p Props) ApplyDiv(attrs *html.DivAttrs, children *[]html.Component) {
+	args := divArgsFromProps(ProseClass)(p)
+	args = append(args, html.AData("pui-markdown-view", ""), html.UnsafeText(Render(p.Source)))
+
+	for _, a := range args {
+		a.ApplyDiv(attrs, children)
+	}
+}
+
+// View renders markdown source as styled, sanitized HTML.
+func View(args ...html.DivArg) html.Node {
+	var (
+		props Props
+		rest  []html.DivArg
+	)
+
+	for _, a := range args {
+		if v, ok := a.(Props); ok {
+			props = v
+		} else {
+			rest = append(rest, a)
+		}
+	}
+
+	return html.Div(append([]html.DivArg{props}, rest...)...)
+}
+
+// Render converts markdown to HTML. It covers the CommonMark blocks (headings, paragraphs,
+// block quotes, lists, code blocks, thematic breaks) and inlines (emphasis, code spans,
+// links, images, autolinks, hard breaks) plus ~~strikethrough~~. The output is safe to
+// embed: raw HTML is escaped, link reference definitions are left as text, and only
+// relative, http(s) and mailto URLs are linked.
+func Render(src string) string {
+	var sb strings.Builder
+
+	renderBlocks(&sb, parseBlocks(splitLines(src), 0), false)
+
+	return sb.String()
+}
+
+func renderBlocks(sb *strings.Builder, blocks []*block, tight bool) {
+	for i, b := range blocks {
+		switch b.kind {
+		case blockParagraph:
+			// Tight list items hold their text without paragraph tags
+			if tight {
+				sb.WriteString(renderInline(b.text))
+
+				if i < len(blocks)-1 {
+					sb.WriteString("\n")
+				}
+			} else {
+				sb.WriteString("<p>" + renderInline(b.text) + "</p>\n")
+			}
+		case blockHeading:
+			tag := "h" + strconv.Itoa(b.level)
+			sb.WriteString("<" + tag + ">" + renderInline(b.text) + "</" + tag + ">\n")
+		case blockThematicBreak:
+			sb.WriteString("<hr />\n")
+		case blockCode:
+			sb.WriteString("<pre><code")
+
+			if b.info != "" {
+				sb.WriteString(` class="language-` + escape(b.info) + `"`)
+			}
+
+			sb.WriteString(">" + escape(b.text) + "</code></pre>\n")
+		case blockQuote:
+			sb.WriteString("<blockquote>\n")
+			renderBlocks(sb, b.children, false)
+			sb.WriteString("</blockquote>\n")
+		case blockList:
+			tag := "ul"
+			if b.ordered {
+				tag = "ol"
+			}
+
+			sb.WriteString("<" + tag)
+
+			if b.ordered && b.start != 1 {
+				sb.WriteString(` start="` + strconv.Itoa(b.start) + `"`)
+			}
+
+			sb.WriteString(">\n")
+
+			for _, item := range b.items {
+				sb.WriteString("<li>")
+
+				if len(item) > 0 && !(b.tight && item[0].kind == blockParagraph) {
+					sb.WriteString("\n")
+				}
+
+				renderBlocks(sb, item, b.tight)
+				sb.WriteString("</li>\n")
+			}
+
+			sb.WriteString("</" + tag + ">\n")
+		}
+	}
+}
+
+// Handler renders the source posted in PreviewParam, serving the preview tab of
+// textarea.Props.Markdown with the same output the server stores.
+func Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+
+			return
+		}
+
+		r.Body = http.MaxBytesReader(w, r.Body, MaxPreviewBytes)
+		if err := r.ParseForm(); err != nil {
+			http.Error(w, "could not read the markdown source", http.StatusRequestEntityTooLarge)
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Header().Set("Cache-Control", "no-store")
+
+		_, _ = w.Write([]byte(Render(r.PostForm.Get(PreviewParam))))
+	})
+}
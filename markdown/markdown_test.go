@@ -0,0 +1,79 @@
+package markdown
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRender(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want string
+	}{
+		{name: "emphasis", src: "*a* **b** ~~c~~", want: "<p><em>a</em> <strong>b</strong> <del>c</del></p>\n"},
+		{name: "nested emphasis", src: "***a** b*", want: "<p><em><strong>a</strong> b</em></p>\n"},
+		{name: "rule of three", src: "*a**b**c*", want: "<p><em>a<strong>b</strong>c</em></p>\n"},
+		{name: "emphasis inside a link", src: "[*a*](b)", want: `<p><a href="b" rel="nofollow noopener"><em>a</em></a></p>` + "\n"},
+		{name: "emphasis does not cross a link", src: "*[a*](b)", want: `<p>*<a href="b" rel="nofollow noopener">a*</a></p>` + "\n"},
+		{name: "no links inside links", src: "[a [b](c) d](e)", want: `<p>[a <a href="c" rel="nofollow noopener">b</a> d](e)</p>` + "\n"},
+		{name: "image alt text", src: "![a *b* [c](d)](e)", want: `<p><img src="e" alt="a b c" /></p>` + "\n"},
+		{name: "unsafe link", src: "[a](javascript:alert(1))", want: "<p>a</p>\n"},
+		{name: "unclosed title", src: `[a](b "t) [c](d)`, want: `<p>[a](b &quot;t) <a href="d" rel="nofollow noopener">c</a></p>` + "\n"},
+		{name: "code span", src: "`a`` `b`", want: "<p><code>a`` </code>b`</p>\n"},
+		{name: "nesting past the limit", src: strings.Repeat("> ", maxNesting+1) + "a", want: strings.Repeat("<blockquote>\n", maxNesting) + "<p>&gt; a</p>\n" + strings.Repeat("</blockquote>\n", maxNesting)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Render(tt.src); got != tt.want {
+				t.Errorf("Render(%q) = %q, want %q", tt.src, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestRenderLinear renders input that made earlier parsers quadratic. Each case fits the
+// preview limit and must render well within the budget even on a slow machine.
+func TestRenderLinear(t *testing.T) {
+	const budget = 2 * time.Second
+
+	var nestedList strings.Builder
+	for i := range 2000 {
+		nestedList.WriteString(strings.Repeat(" ", 2*i%64) + "- a\n")
+	}
+
+	tests := []struct {
+		name string
+		src  string
+	}{
+		{name: "unmatched emphasis", src: strings.Repeat("*a", 20000)},
+		{name: "mixed delimiters", src: strings.Repeat("*_", 20000)},
+		{name: "unmatched strikethrough", src: strings.Repeat("~~a", 20000)},
+		{name: "unclosed destinations", src: strings.Repeat("[a](", 20000)},
+		{name: "unclosed brackets", src: strings.Repeat("[", 20000) + strings.Repeat("](b)", 20000)},
+		{name: "unclosed titles", src: strings.Repeat(`[a](b "`, 15000)},
+		{name: "unmatched code spans", src: strings.Repeat("`a``", 20000)},
+		{name: "nested parentheses", src: "[a](" + strings.Repeat("(", 60000)},
+		{name: "nested lists", src: strings.Repeat("- ", 2000) + "a"},
+		{name: "nested quotes", src: strings.Repeat("> ", 2000) + "a"},
+		{name: "indented lists", src: nestedList.String()},
+		{name: "soft breaks", src: strings.Repeat("a \n", 40000)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if len(tt.src) > MaxPreviewBytes {
+				t.Fatalf("input is %d bytes, over MaxPreviewBytes", len(tt.src))
+			}
+
+			start := time.Now()
+			Render(tt.src)
+
+			if d := time.Since(start); d > budget {
+				t.Errorf("Render took %v, budget %v", d, budget)
+			}
+		})
+	}
+}
@@ -0,0 +1,301 @@
+package numberinput
+
+import (
+	_ "embed"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/plainkit/html"
+	"github.com/plainkit/icons/lucide"
+	"github.com/plainkit/ui/button"
+	"github.com/plainkit/ui/input"
+	"github.com/plainkit/ui/internal/randomid"
+)
+
+var (
+	// ErrInvalid is returned by Clean when the submitted value is not a number.
+	ErrInvalid = errors.New("numberinput: value is not a number")
+	// ErrRequired is returned by Clean when a required value is empty.
+	ErrRequired = errors.New("numberinput: value is required")
+	// ErrRange is returned by Clean when the value lies outside Min and Max.
+	ErrRange = errors.New("numberinput: value is out of range")
+	// ErrStep is returned by Clean when the value lies between two steps.
+	ErrStep = errors.New("numberinput: value is not a whole number of steps")
+)
+
+type Props struct {
+	ID          string
+	Class       string
+	Attrs       []html.Global
+	Name        string
+	Form        string
+	Placeholder string
+	Value       *float64           // nil renders an empty field
+	Min         *float64           // nil for no lower bound
+	Max         *float64           // nil for no upper bound
+	Step        float64            // Arrow keys, wheel and buttons (default 1); Clean checks values against it when set
+	LargeStep   float64            // Page Up and Page Down (default 10 steps)
+	Precision   int                // Fraction digits (default: those of Step, or the currency precision)
+	Format      input.NumberFormat // Locale, currency and separators of the displayed value
+	Disabled    bool
+	Readonly    bool
+	Required    bool
+	HasError    bool
+}
+
+// Float returns a pointer to v for the optional Value, Min and Max fields.
+func Float(v float64) *float64 {
+	return &v
+}
+
+func divArgsFromProps(baseClass string, extra ...string) func(p Props) []html.DivArg {
+	return func(p Props) []html.DivArg {
+		args := []html.DivArg{html.AClass(html.ClassMerge(append([]string{baseClass}, append(extra, p.Class)...)...))}
+		if p.ID != "" {
+			args = append(args, html.AId(p.ID+"-container"))
+		}
+
+		for _, a := range p.Attrs {
+			args = append(args, a)
+		}
+
+		return args
+	}
+}
+
+func (p Props) ApplyDiv(attrs *html.DivAttrs, children *[]html.Component) {
+	id := p.ID
+	if id == "" {
+		id = randomid.New("numberinput")
+	}
+
+	format := p.format()
+	precision := format.Precision()
+
+	args := divArgsFromProps("relative w-full")(p)
+	args = append(args,
+		html.AData("pui-numberinput", ""),
+		html.AData("pui-numberinput-step", formatFloat(p.step(), -1)),
+		html.AData("pui-numberinput-large-step", formatFloat(p.largeStep(), -1)),
+		html.AData("pui-numberinput-precision", strconv.Itoa(precision)),
+	)
+
+	spinAttrs := []html.Global{
+		html.ACustom("role", "spinbutton"),
+		html.ACustom("autocomplete", "off"),
+		html.AData("pui-numberinput-display", ""),
+	}
+
+	if p.Min != nil {
+		args = append(args, html.AData("pui-numberinput-min", formatFloat(*p.Min, -1)))
+		spinAttrs = append(spinAttrs, html.AAria("valuemin", formatFloat(*p.Min, -1)))
+	}
+
+	if p.Max != nil {
+		args = append(args, html.AData("pui-numberinput-max", formatFloat(*p.Max, -1)))
+		spinAttrs = append(spinAttrs, html.AAria("valuemax", formatFloat(*p.Max, -1)))
+	}
+
+	var raw, display string
+
+	if p.Value != nil {
+		raw = formatFloat(p.clamp(*p.Value), precision)
+		display = input.Mask{Number: &format}.Format(raw)
+		spinAttrs = append(spinAttrs,
+			html.AAria("valuenow", raw),
+			html.AAria("valuetext", display),
+		)
+	}
+
+	field := input.Input(input.Props{
+		ID:          id,
+		Class:       "px-12 text-center tabular-nums",
+		Form:        p.Form,
+		Placeholder: p.Placeholder,
+		Value:       display,
+		Disabled:    p.Disabled,
+		Readonly:    p.Readonly,
+		Required:    p.Required,
+		HasError:    p.HasError,
+		Mask:        input.Mask{Number: &format},
+		Attrs:       spinAttrs,
+	})
+
+	hiddenArgs := []html.InputArg{
+		html.AType("hidden"),
+		html.AValue(raw),
+		html.AData("pui-numberinput-value", ""),
+	}
+
+	if p.Name != "" {
+		hiddenArgs = append(hiddenArgs, html.AName(p.Name))
+	}
+
+	if p.Form != "" {
+		hiddenArgs = append(hiddenArgs, html.AForm(p.Form))
+	}
+
+	atMin := p.Value != nil && p.Min != nil && p.clamp(*p.Value) <= *p.Min
+	atMax := p.Value != nil && p.Max != nil && p.clamp(*p.Value) >= *p.Max
+
+	*children = append(*children,
+		stepButton(id, "decrement", "Decrease", "left-1", p.Disabled || p.Readonly || atMin, lucide.Minus(html.AClass("size-4"))),
+		field,
+		stepButton(id, "increment", "Increase", "right-1", p.Disabled || p.Readonly || atMax, lucide.Plus(html.AClass("size-4"))),
+		html.Input(hiddenArgs...),
+	)
+
+	for _, a := range args {
+		a.ApplyDiv(attrs, children)
+	}
+}
+
+// NumberInput renders a numeric field with increment and decrement buttons.
+// The visible field is formatted for Format's locale while a hidden input named
+// Name carries the plain decimal value, e.g. "1234.50".
+func NumberInput(args ...html.DivArg) html.Node {
+	var (
+		props Props
+		rest  []html.DivArg
+	)
+
+	for _, a := range args {
+		if v, ok := a.(Props); ok {
+			props = v
+		} else {
+			rest = append(rest, a)
+		}
+	}
+
+	return html.Div(append([]html.DivArg{props}, rest...)...).WithAssets("", numberinputJS, "ui-numberinput")
+}
+
+// Clean parses a submitted value, rounds it to the precision and checks Min, Max and Required.
+// With Step set it also checks the value is a whole number of steps from Min, or from 0
+// without Min, the grid the buttons and arrow keys move along. It returns nil for an empty
+// optional value.
+func Clean(p Props, raw string) (*float64, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		if p.Required {
+			return nil, ErrRequired
+		}
+
+		return nil, nil
+	}
+
+	v, err := strconv.ParseFloat(raw, 64)
+	if err != nil || math.IsNaN(v) || math.IsInf(v, 0) {
+		return nil, fmt.Errorf("%w: %q", ErrInvalid, raw)
+	}
+
+	scale := math.Pow10(p.format().Precision())
+	v = math.Round(v*scale) / scale
+
+	if p.Min != nil && v < *p.Min {
+		return nil, fmt.Errorf("%w: must be at least %s", ErrRange, formatFloat(*p.Min, -1))
+	}
+
+	if p.Max != nil && v > *p.Max {
+		return nil, fmt.Errorf("%w: must be at most %s", ErrRange, formatFloat(*p.Max, -1))
+	}
+
+	if p.Step > 0 {
+		base := 0.0
+		if p.Min != nil {
+			base = *p.Min
+		}
+
+		// Allow for float error, like numberinput.js does when stepping
+		n := (v - base) / p.Step
+		if math.Abs(n-math.Round(n)) > 1e-9*math.Max(1, math.Abs(n)) {
+			if base == 0 {
+				return nil, fmt.Errorf("%w: must be a multiple of %s", ErrStep, formatFloat(p.Step, -1))
+			}
+
+			return nil, fmt.Errorf("%w: must be %s plus a multiple of %s", ErrStep, formatFloat(base, -1), formatFloat(p.Step, -1))
+		}
+	}
+
+	return &v, nil
+}
+
+// Parse reads the value submitted under p.Name and cleans it with Clean.
+func Parse(r *http.Request, p Props) (*float64, error) {
+	if err := r.ParseForm(); err != nil {
+		return nil, err
+	}
+
+	return Clean(p, r.Form.Get(p.Name))
+}
+
+func stepButton(id, action, label, side string, disabled bool, icon html.Node) html.Node {
+	return button.Button(button.Props{
+		Variant:  button.VariantGhost,
+		Size:     button.SizeIcon,
+		Class:    "absolute top-1/2 z-10 size-8 -translate-y-1/2 rounded-lg text-muted-foreground " + side,
+		Disabled: disabled,
+		Attrs: []html.Global{
+			html.ATabindex(-1),
+			html.AAria("label", label),
+			html.AAria("controls", id),
+			html.AData("pui-numberinput-"+action, ""),
+		},
+	}, icon)
+}
+
+func (p Props) step() float64 {
+	if p.Step <= 0 {
+		return 1
+	}
+
+	return p.Step
+}
+
+func (p Props) largeStep() float64 {
+	if p.LargeStep <= 0 {
+		return p.step() * 10
+	}
+
+	return p.LargeStep
+}
+
+// format derives the display format, allowing a sign only when Min does.
+func (p Props) format() input.NumberFormat {
+	f := p.Format
+
+	switch {
+	case p.Precision > 0:
+		f.Decimals = p.Precision
+	case f.Decimals == 0:
+		_, frac, _ := strings.Cut(formatFloat(p.step(), -1), ".")
+		f.Decimals = len(frac)
+	}
+
+	f.Negative = p.Min == nil || *p.Min < 0
+
+	return f
+}
+
+func (p Props) clamp(v float64) float64 {
+	if p.Min != nil && v < *p.Min {
+		v = *p.Min
+	}
+
+	if p.Max != nil && v > *p.Max {
+		v = *p.Max
+	}
+
+	return v
+}
+
+func formatFloat(v float64, precision int) string {
+	return strconv.FormatFloat(v, 'f', precision, 64)
+}
+
+//go:embed numberinput.js
+var numberinputJS string
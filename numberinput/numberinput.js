@@ -0,0 +1,313 @@
+(function () {
+  "use strict";
+
+  const REPEAT_DELAY = 400;
+  const REPEAT_INTERVAL = 60;
+
+  let repeatTimer = null;
+
+  function rootOf(el) {
+    if (typeof el === "string") el = document.getElementById(el);
+    return el?.closest?.("[data-pui-numberinput]") || null;
+  }
+
+  function parts(root) {
+    return {
+      display: root.querySelector("[data-pui-numberinput-display]"),
+      hidden: root.querySelector("[data-pui-numberinput-value]"),
+      decrement: root.querySelector("[data-pui-numberinput-decrement]"),
+      increment: root.querySelector("[data-pui-numberinput-increment]"),
+    };
+  }
+
+  function numberAttr(root, name) {
+    const value = root.getAttribute("data-pui-numberinput-" + name);
+    if (value === null || value === "") return null;
+
+    const n = parseFloat(value);
+    return isNaN(n) ? null : n;
+  }
+
+  function options(root) {
+    return {
+      min: numberAttr(root, "min"),
+      max: numberAttr(root, "max"),
+      step: numberAttr(root, "step") || 1,
+      largeStep: numberAttr(root, "large-step") || 10,
+      precision: numberAttr(root, "precision") || 0,
+    };
+  }
+
+  function isLocked(display) {
+    return !display || display.disabled || display.readOnly;
+  }
+
+  // Read the locale formatted text back into a number
+  function parse(display) {
+    const decimal = display.getAttribute("data-pui-mask-decimal") || ".";
+    const text = display.value;
+    let out = "";
+
+    for (let i = 0; i < text.length; ) {
+      if (text.startsWith(decimal, i)) {
+        out += ".";
+        i += decimal.length;
+        continue;
+      }
+
+      if (/[0-9]/.test(text[i])) out += text[i];
+      i++;
+    }
+
+    if (!/[0-9]/.test(out)) return null;
+
+    const n = parseFloat(out);
+    if (isNaN(n)) return null;
+
+    return text.includes("-") ? -n : n;
+  }
+
+  function round(value, precision) {
+    const n = Number(value.toFixed(precision));
+    return n === 0 ? 0 : n;
+  }
+
+  function clamp(value, o) {
+    if (o.min !== null && value < o.min) value = o.min;
+    if (o.max !== null && value > o.max) value = o.max;
+    return value;
+  }
+
+  function show(display, value, o) {
+    const decimal = display.getAttribute("data-pui-mask-decimal") || ".";
+    display.value = value === null ? "" : value.toFixed(o.precision).replace(".", decimal);
+    window.tui?.mask?.format(display);
+  }
+
+  // Mirror the parsed value into the hidden input, ARIA state and buttons
+  function sync(root, value, notify) {
+    const o = options(root);
+    const p = parts(root);
+    const raw = value === null ? "" : value.toFixed(o.precision);
+    const changed = p.hidden && p.hidden.value !== raw;
+
+    if (p.hidden) p.hidden.value = raw;
+
+    if (p.display) {
+      if (value === null) {
+        p.display.removeAttribute("aria-valuenow");
+        p.display.removeAttribute("aria-valuetext");
+      } else {
+        p.display.setAttribute("aria-valuenow", raw);
+        p.display.setAttribute("aria-valuetext", p.display.value);
+      }
+    }
+
+    const locked = isLocked(p.display);
+    if (p.decrement) {
+      p.decrement.disabled = locked || (value !== null && o.min !== null && value <= o.min);
+    }
+    if (p.increment) {
+      p.increment.disabled = locked || (value !== null && o.max !== null && value >= o.max);
+    }
+
+    if (notify && changed) {
+      p.hidden.dispatchEvent(new Event("change", { bubbles: true }));
+      root.dispatchEvent(
+        new CustomEvent("pui-numberinput:change", {
+          bubbles: true,
+          detail: { value: value },
+        }),
+      );
+    }
+  }
+
+  function set(root, value) {
+    const o = options(root);
+    const display = parts(root).display;
+    if (!display) return;
+
+    if (value !== null) value = round(clamp(value, o), o.precision);
+
+    show(display, value, o);
+    sync(root, value, true);
+  }
+
+  // Move to the next multiple of size counted from min, like native number inputs
+  function step(root, direction, size) {
+    const o = options(root);
+    const display = parts(root).display;
+    if (isLocked(display)) return;
+
+    size = size || o.step;
+
+    const current = parse(display);
+    if (current === null) {
+      set(root, clamp(0, o));
+      return;
+    }
+
+    const base = o.min ?? 0;
+    const n = (current - base) / size;
+    const next = direction > 0 ? Math.floor(n + 1e-9) + 1 : Math.ceil(n - 1e-9) - 1;
+
+    set(root, base + next * size);
+  }
+
+  function commit(root) {
+    const display = parts(root).display;
+    if (!display) return;
+
+    set(root, parse(display));
+  }
+
+  function stopRepeat() {
+    clearTimeout(repeatTimer);
+    clearInterval(repeatTimer);
+    repeatTimer = null;
+  }
+
+  document.addEventListener("input", (e) => {
+    const display = e.target.closest?.("[data-pui-numberinput-display]");
+    if (!display) return;
+
+    const root = rootOf(display);
+    if (root) sync(root, parse(display), false);
+  });
+
+  document.addEventListener("focusout", (e) => {
+    const display = e.target.closest?.("[data-pui-numberinput-display]");
+    if (!display || isLocked(display)) return;
+
+    const root = rootOf(display);
+    if (root) commit(root);
+  });
+
+  document.addEventListener("keydown", (e) => {
+    const display = e.target.closest?.("[data-pui-numberinput-display]");
+    if (!display || isLocked(display)) return;
+
+    const root = rootOf(display);
+    if (!root) return;
+
+    const o = options(root);
+
+    switch (e.key) {
+      case "ArrowUp":
+        e.preventDefault();
+        step(root, 1);
+        break;
+      case "ArrowDown":
+        e.preventDefault();
+        step(root, -1);
+        break;
+      case "PageUp":
+        e.preventDefault();
+        step(root, 1, o.largeStep);
+        break;
+      case "PageDown":
+        e.preventDefault();
+        step(root, -1, o.largeStep);
+        break;
+      case "Home":
+        if (o.min === null) return;
+        e.preventDefault();
+        set(root, o.min);
+        break;
+      case "End":
+        if (o.max === null) return;
+        e.preventDefault();
+        set(root, o.max);
+        break;
+      case "Enter":
+        commit(root);
+        break;
+    }
+  });
+
+  // Only a focused field reacts to the wheel so scrolling the page is never hijacked
+  document.addEventListener(
+    "wheel",
+    (e) => {
+      const display = e.target.closest?.("[data-pui-numberinput-display]");
+      if (!display || display !== document.activeElement || isLocked(display)) return;
+      if (e.deltaY === 0) return;
+
+      const root = rootOf(display);
+      if (!root) return;
+
+      e.preventDefault();
+      step(root, e.deltaY < 0 ? 1 : -1);
+    },
+    { passive: false },
+  );
+
+  // Holding a button repeats the step until released or a bound is reached
+  document.addEventListener("pointerdown", (e) => {
+    if (e.button !== 0) return;
+
+    const btn = e.target.closest?.(
+      "[data-pui-numberinput-increment], [data-pui-numberinput-decrement]",
+    );
+    if (!btn || btn.disabled) return;
+
+    const root = rootOf(btn);
+    if (!root) return;
+
+    e.preventDefault();
+
+    const direction = btn.hasAttribute("data-pui-numberinput-increment") ? 1 : -1;
+    stopRepeat();
+    step(root, direction);
+
+    repeatTimer = setTimeout(() => {
+      repeatTimer = setInterval(() => {
+        if (btn.disabled) {
+          stopRepeat();
+          return;
+        }
+        step(root, direction);
+      }, REPEAT_INTERVAL);
+    }, REPEAT_DELAY);
+
+    btn.addEventListener("pointerleave", stopRepeat, { once: true });
+  });
+
+  document.addEventListener("pointerup", stopRepeat);
+  document.addEventListener("pointercancel", stopRepeat);
+
+  // Keyboard activation of the buttons (pointer presses are handled above)
+  document.addEventListener("click", (e) => {
+    if (e.detail !== 0) return;
+
+    const btn = e.target.closest?.(
+      "[data-pui-numberinput-increment], [data-pui-numberinput-decrement]",
+    );
+    if (!btn || btn.disabled) return;
+
+    const root = rootOf(btn);
+    if (root) step(root, btn.hasAttribute("data-pui-numberinput-increment") ? 1 : -1);
+  });
+
+  // Expose public API
+  window.tui = window.tui || {};
+  window.tui.numberinput = {
+    value: (el) => {
+      const root = rootOf(el);
+      const display = root && parts(root).display;
+      return display ? parse(display) : null;
+    },
+    set: (el, value) => {
+      const root = rootOf(el);
+      if (root) set(root, value);
+    },
+    stepUp: (el) => {
+      const root = rootOf(el);
+      if (root) step(root, 1);
+    },
+    stepDown: (el) => {
+      const root = rootOf(el);
+      if (root) step(root, -1);
+    },
+  };
+})();
@@ -0,0 +1,201 @@
+package option
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/plainkit/html"
+)
+
+// ErrNotAllowed is returned when a submitted value does not match any option.
+var ErrNotAllowed = errors.New("option: value is not one of the allowed options")
+
+// ErrMissing is returned when a required field was not submitted.
+var ErrMissing = errors.New("option: value is missing")
+
+// Codec converts option values to and from their form representation.
+type Codec[T any] interface {
+	Encode(value T) string
+	Decode(raw string) (T, error)
+}
+
+type funcCodec[T any] struct {
+	encode func(T) string
+	decode func(string) (T, error)
+}
+
+func (c funcCodec[T]) Encode(value T) string        { return c.encode(value) }
+func (c funcCodec[T]) Decode(raw string) (T, error) { return c.decode(raw) }
+
+// Func builds a Codec from an encode and a decode function.
+func Func[T any](encode func(T) string, decode func(string) (T, error)) Codec[T] {
+	return funcCodec[T]{encode: encode, decode: decode}
+}
+
+var (
+	// String passes values through unchanged.
+	String = Func(func(v string) string { return v }, func(s string) (string, error) { return s, nil })
+	// Int encodes values in base 10.
+	Int = Func(strconv.Itoa, strconv.Atoi)
+	// Int64 encodes values in base 10.
+	Int64 = Func(
+		func(v int64) string { return strconv.FormatInt(v, 10) },
+		func(s string) (int64, error) { return strconv.ParseInt(s, 10, 64) },
+	)
+	// Bool encodes values as "true" or "false".
+	Bool = Func(strconv.FormatBool, strconv.ParseBool)
+)
+
+// Option describes a single choice of a select-like component.
+type Option[T any] struct {
+	Value       T
+	Label       string
+	Description string
+	Icon        html.Component
+	Disabled    bool
+	Group       string // Options sharing a group are rendered together under this heading
+}
+
+// Group holds the options sharing the same Option.Group heading.
+type Group[T any] struct {
+	Name    string
+	Options []Option[T]
+}
+
+// List pairs a set of options with the codec used to render and parse their values.
+type List[T any] struct {
+	Codec   Codec[T]
+	Options []Option[T]
+}
+
+// New creates a List from a codec and its options.
+func New[T any](codec Codec[T], options ...Option[T]) List[T] {
+	return List[T]{Codec: codec, Options: options}
+}
+
+// Encode returns the form representation of value.
+func (l List[T]) Encode(value T) string {
+	return l.Codec.Encode(value)
+}
+
+// Values encodes several values, e.g. for tagsinput.Props.Value.
+func (l List[T]) Values(values ...T) []string {
+	out := make([]string, 0, len(values))
+	for _, v := range values {
+		out = append(out, l.Codec.Encode(v))
+	}
+
+	return out
+}
+
+// Selected reports which encoded values are among values.
+func (l List[T]) Selected(values ...T) map[string]bool {
+	set := make(map[string]bool, len(values))
+	for _, v := range values {
+		set[l.Codec.Encode(v)] = true
+	}
+
+	return set
+}
+
+// Label returns the label of an option, falling back to its encoded value.
+func (l List[T]) Label(o Option[T]) string {
+	if o.Label != "" {
+		return o.Label
+	}
+
+	return l.Codec.Encode(o.Value)
+}
+
+// Lookup finds the option whose encoded value equals raw.
+func (l List[T]) Lookup(raw string) (Option[T], bool) {
+	for _, o := range l.Options {
+		if l.Codec.Encode(o.Value) == raw {
+			return o, true
+		}
+	}
+
+	return Option[T]{}, false
+}
+
+// Groups gathers options sharing a name into one group, even when others come between
+// them. Groups appear in the order their names first do, and options keep their order
+// within a group.
+func (l List[T]) Groups() []Group[T] {
+	var (
+		groups []Group[T]
+		index  = map[string]int{}
+	)
+
+	for _, o := range l.Options {
+		i, ok := index[o.Group]
+		if !ok {
+			i = len(groups)
+			index[o.Group] = i
+			groups = append(groups, Group[T]{Name: o.Group})
+		}
+
+		groups[i].Options = append(groups[i].Options, o)
+	}
+
+	return groups
+}
+
+// ParseValue decodes raw and checks it matches an enabled option.
+func (l List[T]) ParseValue(raw string) (T, error) {
+	var zero T
+
+	o, ok := l.Lookup(raw)
+	if !ok || o.Disabled {
+		return zero, fmt.Errorf("%w: %q", ErrNotAllowed, raw)
+	}
+
+	v, err := l.Codec.Decode(raw)
+	if err != nil {
+		return zero, fmt.Errorf("option: decode %q: %w", raw, err)
+	}
+
+	return v, nil
+}
+
+// Parse reads the single value submitted under name.
+func (l List[T]) Parse(r *http.Request, name string) (T, error) {
+	var zero T
+
+	if err := r.ParseForm(); err != nil {
+		return zero, err
+	}
+
+	raw := r.Form.Get(name)
+	if raw == "" {
+		return zero, ErrMissing
+	}
+
+	return l.ParseValue(raw)
+}
+
+// ParseAll reads every value submitted under name, skipping empty entries.
+func (l List[T]) ParseAll(r *http.Request, name string) ([]T, error) {
+	if err := r.ParseForm(); err != nil {
+		return nil, err
+	}
+
+	var values []T
+
+	for _, raw := range r.Form[name] {
+		if raw == "" {
+			continue
+		}
+
+		v, err := l.ParseValue(raw)
+		if err != nil {
+			return nil, err
+		}
+
+		values = append(values, v)
+	}
+
+	return values, nil
+}
@@ -0,0 +1,31 @@
+package option
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGroups(t *testing.T) {
+	list := New(String,
+		Option[string]{Value: "a", Group: "Fruit"},
+		Option[string]{Value: "b", Group: "Vegetables"},
+		Option[string]{Value: "c", Group: "Fruit"},
+		Option[string]{Value: "d"},
+		Option[string]{Value: "e", Group: "Vegetables"},
+	)
+
+	var got [][]string
+	for _, g := range list.Groups() {
+		values := []string{g.Name}
+		for _, o := range g.Options {
+			values = append(values, o.Value)
+		}
+
+		got = append(got, values)
+	}
+
+	want := [][]string{{"Fruit", "a", "c"}, {"Vegetables", "b", "e"}, {"", "d"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Groups() = %v, want %v", got, want)
+	}
+}
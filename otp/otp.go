@@ -0,0 +1,390 @@
+// Package otp implements HOTP (RFC 4226) and TOTP (RFC 6238) one-time passwords and
+// the otpauth:// URIs authenticator apps enroll from.
+package otp
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Algorithm is the HMAC hash of the generator.
+type Algorithm string
+
+const (
+	AlgorithmSHA1   Algorithm = "SHA1" // Supported by every authenticator app
+	AlgorithmSHA256 Algorithm = "SHA256"
+	AlgorithmSHA512 Algorithm = "SHA512"
+)
+
+// Type selects counter or time based codes.
+type Type string
+
+const (
+	TypeTOTP Type = "totp"
+	TypeHOTP Type = "hotp"
+)
+
+const (
+	MinDigits     = 6
+	MaxDigits     = 8
+	DefaultDigits = 6
+	DefaultPeriod = 30 // Seconds
+	DefaultSkew   = 1  // Steps accepted on either side of the current one
+	SecretSize    = 20 // Bytes, the length of a SHA1 block as RFC 4226 recommends
+)
+
+var (
+	// ErrInvalidCode is returned when a code does not match.
+	ErrInvalidCode = errors.New("otp: invalid code")
+	// ErrSecret is returned when a secret is missing or not valid base32.
+	ErrSecret = errors.New("otp: invalid secret")
+	// ErrURI is returned by ParseURI for malformed otpauth URIs.
+	ErrURI = errors.New("otp: invalid otpauth URI")
+	// ErrKey is returned for keys with unsupported digits, period or algorithm.
+	ErrKey = errors.New("otp: invalid key")
+)
+
+var encoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// Key holds the shared secret and the parameters both sides generate codes with.
+// Zero fields take the defaults most authenticator apps assume.
+type Key struct {
+	Type      Type   // Defaults to TypeTOTP
+	Secret    []byte // Shared secret; never shown except during enrollment
+	Issuer    string // Service name shown in the authenticator app
+	Account   string // Account name, usually the user's email
+	Algorithm Algorithm
+	Digits    int    // MinDigits to MaxDigits
+	Period    int    // TOTP step in seconds, positive
+	Counter   uint64 // Initial HOTP counter
+}
+
+// NewKey returns a TOTP key with a random secret of SecretSize bytes.
+func NewKey(issuer, account string) (Key, error) {
+	secret := make([]byte, SecretSize)
+	if _, err := rand.Read(secret); err != nil {
+		return Key{}, err
+	}
+
+	return Key{Type: TypeTOTP, Secret: secret, Issuer: issuer, Account: account}, nil
+}
+
+// Validate reports keys whose codes authenticator apps would not generate: a missing
+// secret, digits outside MinDigits to MaxDigits, a negative period or an unknown type
+// or algorithm. Zero fields are valid and take the defaults.
+func (k Key) Validate() error {
+	switch {
+	case len(k.Secret) == 0:
+		return ErrSecret
+	case k.Type != "" && k.Type != TypeTOTP && k.Type != TypeHOTP:
+		return fmt.Errorf("%w: unknown type %q", ErrKey, k.Type)
+	case k.Algorithm != "" && k.Algorithm != AlgorithmSHA1 && k.Algorithm != AlgorithmSHA256 && k.Algorithm != AlgorithmSHA512:
+		return fmt.Errorf("%w: unknown algorithm %q", ErrKey, k.Algorithm)
+	case k.Digits != 0 && (k.Digits < MinDigits || k.Digits > MaxDigits):
+		return fmt.Errorf("%w: %d digits, want %d to %d", ErrKey, k.Digits, MinDigits, MaxDigits)
+	case k.Period < 0:
+		return fmt.Errorf("%w: period %d is not positive", ErrKey, k.Period)
+	}
+
+	return nil
+}
+
+// EncodeSecret returns the unpadded base32 form of a secret used in URIs and for manual entry.
+func EncodeSecret(secret []byte) string {
+	return encoding.EncodeToString(secret)
+}
+
+// DecodeSecret parses a base32 secret, ignoring case, spaces, dashes and padding.
+func DecodeSecret(s string) ([]byte, error) {
+	s = strings.ToUpper(strings.NewReplacer(" ", "", "-", "", "=", "").Replace(s))
+
+	secret, err := encoding.DecodeString(s)
+	if err != nil || len(secret) == 0 {
+		return nil, ErrSecret
+	}
+
+	return secret, nil
+}
+
+// FormatSecret groups the encoded secret in blocks of four for manual entry.
+func FormatSecret(secret []byte) string {
+	s := EncodeSecret(secret)
+
+	var sb strings.Builder
+
+	for i := 0; i < len(s); i += 4 {
+		if i > 0 {
+			sb.WriteByte(' ')
+		}
+
+		sb.WriteString(s[i:min(i+4, len(s))])
+	}
+
+	return sb.String()
+}
+
+func (k Key) typ() Type {
+	if k.Type == "" {
+		return TypeTOTP
+	}
+
+	return k.Type
+}
+
+func (k Key) digits() int {
+	if k.Digits <= 0 {
+		return DefaultDigits
+	}
+
+	return k.Digits
+}
+
+func (k Key) period() int {
+	if k.Period <= 0 {
+		return DefaultPeriod
+	}
+
+	return k.Period
+}
+
+func (k Key) algorithm() Algorithm {
+	if k.Algorithm == "" {
+		return AlgorithmSHA1
+	}
+
+	return k.Algorithm
+}
+
+func (k Key) hash() func() hash.Hash {
+	switch k.algorithm() {
+	case AlgorithmSHA256:
+		return sha256.New
+	case AlgorithmSHA512:
+		return sha512.New
+	default:
+		return sha1.New
+	}
+}
+
+// Step returns the TOTP time step containing t.
+func (k Key) Step(t time.Time) int64 {
+	return t.Unix() / int64(k.period())
+}
+
+// HOTP returns the code for counter. The key should pass Validate; the verifiers check it.
+func HOTP(k Key, counter uint64) string {
+	var msg [8]byte
+
+	binary.BigEndian.PutUint64(msg[:], counter)
+
+	mac := hmac.New(k.hash(), k.Secret)
+	mac.Write(msg[:])
+	sum := mac.Sum(nil)
+
+	// Dynamic truncation, RFC 4226 section 5.3
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	mod := uint64(1)
+	for i := 0; i < k.digits(); i++ {
+		mod *= 10
+	}
+
+	code := strconv.FormatUint(uint64(value)%mod, 10)
+
+	return strings.Repeat("0", k.digits()-len(code)) + code
+}
+
+// TOTP returns the code for the time step containing t.
+func TOTP(k Key, t time.Time) string {
+	return HOTP(k, uint64(k.Step(t)))
+}
+
+// VerifyTOTP checks code against the steps within skew of t and returns the matched
+// step. Callers should store it and reject codes for that step or earlier ones, so a
+// code cannot be replayed while it is still valid.
+func VerifyTOTP(k Key, code string, t time.Time, skew int) (int64, error) {
+	if err := k.Validate(); err != nil {
+		return 0, err
+	}
+
+	code = normalize(code)
+	step := k.Step(t)
+
+	// Check every step so the time taken does not reveal which one matched
+	matched, found := int64(0), false
+
+	for d := -skew; d <= skew; d++ {
+		s := step + int64(d)
+		if s < 0 {
+			continue
+		}
+
+		if equal(HOTP(k, uint64(s)), code) && !found {
+			matched, found = s, true
+		}
+	}
+
+	if !found {
+		return 0, ErrInvalidCode
+	}
+
+	return matched, nil
+}
+
+// VerifyHOTP checks code against counter and the window counters after it, which
+// covers codes generated on the device without being used. It returns the counter to
+// store for the next verification.
+func VerifyHOTP(k Key, code string, counter uint64, window int) (uint64, error) {
+	if err := k.Validate(); err != nil {
+		return counter, err
+	}
+
+	code = normalize(code)
+
+	for i := 0; i <= window; i++ {
+		if equal(HOTP(k, counter+uint64(i)), code) {
+			return counter + uint64(i) + 1, nil
+		}
+	}
+
+	return counter, ErrInvalidCode
+}
+
+func normalize(code string) string {
+	return strings.NewReplacer(" ", "", "-", "").Replace(strings.TrimSpace(code))
+}
+
+func equal(a, b string) bool {
+	return subtle.ConstantTimeCompare([]byte(a), []byte(b)) == 1
+}
+
+// URI returns the otpauth:// URI authenticator apps scan from a QR code. Defaults are
+// left out, as some apps reject parameters they do not support.
+func (k Key) URI() string {
+	label := escape(k.Account)
+	if k.Issuer != "" {
+		label = escape(k.Issuer) + ":" + label
+	}
+
+	params := []string{"secret=" + EncodeSecret(k.Secret)}
+
+	if k.Issuer != "" {
+		params = append(params, "issuer="+escape(k.Issuer))
+	}
+
+	if k.algorithm() != AlgorithmSHA1 {
+		params = append(params, "algorithm="+string(k.algorithm()))
+	}
+
+	if k.digits() != DefaultDigits {
+		params = append(params, "digits="+strconv.Itoa(k.digits()))
+	}
+
+	if k.typ() == TypeHOTP {
+		params = append(params, "counter="+strconv.FormatUint(k.Counter, 10))
+	} else if k.period() != DefaultPeriod {
+		params = append(params, "period="+strconv.Itoa(k.period()))
+	}
+
+	return "otpauth://" + string(k.typ()) + "/" + label + "?" + strings.Join(params, "&")
+}
+
+// ParseURI reads a key from an otpauth:// URI.
+func ParseURI(uri string) (Key, error) {
+	rest, ok := strings.CutPrefix(uri, "otpauth://")
+	if !ok {
+		return Key{}, fmt.Errorf("%w: missing otpauth scheme", ErrURI)
+	}
+
+	typ, rest, _ := strings.Cut(rest, "/")
+	label, query, _ := strings.Cut(rest, "?")
+
+	k := Key{Type: Type(strings.ToLower(typ))}
+	if k.Type != TypeTOTP && k.Type != TypeHOTP {
+		return Key{}, fmt.Errorf("%w: unknown type %q", ErrURI, typ)
+	}
+
+	if l, err := url.PathUnescape(label); err == nil {
+		label = l
+	}
+	if issuer, account, found := strings.Cut(label, ":"); found {
+		k.Issuer, k.Account = strings.TrimSpace(issuer), strings.TrimSpace(account)
+	} else {
+		k.Account = label
+	}
+
+	for _, pair := range strings.Split(query, "&") {
+		name, value, _ := strings.Cut(pair, "=")
+		if v, err := url.QueryUnescape(value); err == nil {
+			value = v
+		}
+
+		var err error
+
+		switch strings.ToLower(name) {
+		case "secret":
+			k.Secret, err = DecodeSecret(value)
+		case "issuer":
+			k.Issuer = value
+		case "algorithm":
+			k.Algorithm = Algorithm(strings.ToUpper(value))
+			if k.Algorithm != AlgorithmSHA1 && k.Algorithm != AlgorithmSHA256 && k.Algorithm != AlgorithmSHA512 {
+				err = fmt.Errorf("unknown algorithm %q", value)
+			}
+		case "digits":
+			// Present parameters must be valid; only absent ones take the defaults
+			k.Digits, err = strconv.Atoi(value)
+			if err == nil && (k.Digits < MinDigits || k.Digits > MaxDigits) {
+				err = fmt.Errorf("%d digits, want %d to %d", k.Digits, MinDigits, MaxDigits)
+			}
+		case "period":
+			k.Period, err = strconv.Atoi(value)
+			if err == nil && k.Period <= 0 {
+				err = fmt.Errorf("period %d is not positive", k.Period)
+			}
+		case "counter":
+			k.Counter, err = strconv.ParseUint(value, 10, 64)
+		}
+
+		if err != nil {
+			return Key{}, fmt.Errorf("%w: %s: %w", ErrURI, name, err)
+		}
+	}
+
+	if err := k.Validate(); err != nil {
+		return Key{}, err
+	}
+
+	return k, nil
+}
+
+// escape percent-encodes everything but unreserved characters and "@"; spaces become
+// %20, as authenticator apps do not decode "+".
+func escape(s string) string {
+	var sb strings.Builder
+
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || strings.IndexByte("-._~@", c) >= 0 {
+			sb.WriteByte(c)
+		} else {
+			fmt.Fprintf(&sb, "%%%02X", c)
+		}
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,141 @@
+package otp
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+// RFC 4226 appendix D.
+func TestHOTP(t *testing.T) {
+	k := Key{Type: TypeHOTP, Secret: []byte("12345678901234567890")}
+	want := []string{"755224", "287082", "359152", "969429", "338314", "254676", "287922", "162583", "399871", "520489"}
+
+	for counter, code := range want {
+		if got := HOTP(k, uint64(counter)); got != code {
+			t.Errorf("HOTP(%d) = %s, want %s", counter, got, code)
+		}
+	}
+}
+
+// RFC 6238 appendix B.
+func TestTOTP(t *testing.T) {
+	secrets := map[Algorithm]string{
+		AlgorithmSHA1:   "12345678901234567890",
+		AlgorithmSHA256: "12345678901234567890123456789012",
+		AlgorithmSHA512: "1234567890123456789012345678901234567890123456789012345678901234",
+	}
+
+	tests := []struct {
+		unix int64
+		want map[Algorithm]string
+	}{
+		{59, map[Algorithm]string{AlgorithmSHA1: "94287082", AlgorithmSHA256: "46119246", AlgorithmSHA512: "90693936"}},
+		{1111111109, map[Algorithm]string{AlgorithmSHA1: "07081804", AlgorithmSHA256: "68084774", AlgorithmSHA512: "25091201"}},
+		{1111111111, map[Algorithm]string{AlgorithmSHA1: "14050471", AlgorithmSHA256: "67062674", AlgorithmSHA512: "99943326"}},
+		{1234567890, map[Algorithm]string{AlgorithmSHA1: "89005924", AlgorithmSHA256: "91819424", AlgorithmSHA512: "93441116"}},
+		{2000000000, map[Algorithm]string{AlgorithmSHA1: "69279037", AlgorithmSHA256: "90698825", AlgorithmSHA512: "38618901"}},
+		{20000000000, map[Algorithm]string{AlgorithmSHA1: "65353130", AlgorithmSHA256: "77737706", AlgorithmSHA512: "47863826"}},
+	}
+
+	for _, tt := range tests {
+		for alg, code := range tt.want {
+			k := Key{Secret: []byte(secrets[alg]), Algorithm: alg, Digits: 8}
+			now := time.Unix(tt.unix, 0)
+
+			if got := TOTP(k, now); got != code {
+				t.Errorf("TOTP(%s, %d) = %s, want %s", alg, tt.unix, got, code)
+			}
+
+			if step, err := VerifyTOTP(k, code, now, 0); err != nil || step != k.Step(now) {
+				t.Errorf("VerifyTOTP(%s, %d) = %d, %v", alg, tt.unix, step, err)
+			}
+		}
+	}
+}
+
+func TestParseURI(t *testing.T) {
+	k, err := ParseURI("otpauth://totp/ACME:jane@example.com?secret=GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ&issuer=ACME&algorithm=SHA256&digits=8&period=60")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if k.Issuer != "ACME" || k.Account != "jane@example.com" || k.Algorithm != AlgorithmSHA256 || k.Digits != 8 || k.Period != 60 {
+		t.Errorf("ParseURI = %+v", k)
+	}
+
+	if again, err := ParseURI(k.URI()); err != nil || again.URI() != k.URI() {
+		t.Errorf("ParseURI(URI()) = %+v, %v", again, err)
+	}
+}
+
+func TestParseURIRejects(t *testing.T) {
+	const secret = "secret=GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+	tests := []struct {
+		name string
+		uri  string
+		err  error
+	}{
+		{name: "scheme", uri: "https://totp/a?" + secret, err: ErrURI},
+		{name: "type", uri: "otpauth://motp/a?" + secret, err: ErrURI},
+		{name: "algorithm", uri: "otpauth://totp/a?" + secret + "&algorithm=MD5", err: ErrURI},
+		{name: "too few digits", uri: "otpauth://totp/a?" + secret + "&digits=5", err: ErrURI},
+		{name: "too many digits", uri: "otpauth://totp/a?" + secret + "&digits=12", err: ErrURI},
+		{name: "zero period", uri: "otpauth://totp/a?" + secret + "&period=0", err: ErrURI},
+		{name: "negative period", uri: "otpauth://totp/a?" + secret + "&period=-5", err: ErrURI},
+		{name: "missing secret", uri: "otpauth://totp/a?issuer=ACME", err: ErrSecret},
+		{name: "bad secret", uri: "otpauth://totp/a?secret=1!", err: ErrURI},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseURI(tt.uri); !errors.Is(err, tt.err) {
+				t.Errorf("ParseURI(%q) error = %v, want %v", tt.uri, err, tt.err)
+			}
+		})
+	}
+}
+
+func TestVerifyRejectsInvalidKeys(t *testing.T) {
+	secret := []byte("12345678901234567890")
+
+	tests := []struct {
+		name string
+		key  Key
+		err  error
+	}{
+		{name: "missing secret", key: Key{}, err: ErrSecret},
+		{name: "too many digits", key: Key{Secret: secret, Digits: 10}, err: ErrKey},
+		{name: "too few digits", key: Key{Secret: secret, Digits: 4}, err: ErrKey},
+		{name: "negative period", key: Key{Secret: secret, Period: -5}, err: ErrKey},
+		{name: "algorithm", key: Key{Secret: secret, Algorithm: "MD5"}, err: ErrKey},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := VerifyTOTP(tt.key, "123456", time.Now(), DefaultSkew); !errors.Is(err, tt.err) {
+				t.Errorf("VerifyTOTP error = %v, want %v", err, tt.err)
+			}
+
+			if _, err := VerifyHOTP(tt.key, "123456", 0, 1); !errors.Is(err, tt.err) {
+				t.Errorf("VerifyHOTP error = %v, want %v", err, tt.err)
+			}
+		})
+	}
+}
+
+func TestVerifyAcceptsFormattedCodes(t *testing.T) {
+	k := Key{Secret: []byte("12345678901234567890")}
+	now := time.Unix(1111111111, 0)
+	code := TOTP(k, now)
+
+	if _, err := VerifyTOTP(k, " "+code[:3]+"-"+code[3:]+" ", now, 0); err != nil {
+		t.Errorf("VerifyTOTP(formatted) = %v", err)
+	}
+
+	if _, err := VerifyTOTP(k, strings.Repeat("0", 6), now, 0); !errors.Is(err, ErrInvalidCode) && code != "000000" {
+		t.Errorf("VerifyTOTP(wrong code) = %v", err)
+	}
+}
@@ -0,0 +1,1565 @@
+(() => {
+  var Vt = Object.create;
+  var It = Object.defineProperty;
+  var Nt = Object.getOwnPropertyDescriptor;
+  var jt = Object.getOwnPropertyNames;
+  var Xt = Object.getPrototypeOf,
+    Yt = Object.prototype.hasOwnProperty;
+  var qt = (g, R) => () => (
+    R || g((R = { exports: {} }).exports, R),
+    R.exports
+  );
+  var _t = (g, R, at, lt) => {
+    if ((R && typeof R == "object") || typeof R == "function")
+      for (let K of jt(R))
+        !Yt.call(g, K) &&
+          K !== at &&
+          It(g, K, {
+            get: () => R[K],
+            enumerable: !(lt = Nt(R, K)) || lt.enumerable,
+          });
+    return g;
+  };
+  var Wt = (g, R, at) => (
+    (at = g != null ? Vt(Xt(g)) : {}),
+    _t(
+      R || !g || !g.__esModule
+        ? It(at, "default", { value: g, enumerable: !0 })
+        : at,
+      g,
+    )
+  );
+  var Et = qt((Tt, zt) => {
+    (function (g, R) {
+      typeof Tt == "object" && typeof zt < "u"
+        ? R(Tt)
+        : typeof define == "function" && define.amd
+          ? define(["exports"], R)
+          : R(
+              ((g =
+                typeof globalThis < "u"
+                  ? globalThis
+                  : g || self).FloatingUICore = {}),
+            );
+    })(Tt, function (g) {
+      "use strict";
+      let R = ["top", "right", "bottom", "left"],
+        at = ["start", "end"],
+        lt = R.reduce(
+          (n, i) => n.concat(i, i + "-" + at[0], i + "-" + at[1]),
+          [],
+        ),
+        K = Math.min,
+        _ = Math.max,
+        st = { left: "right", right: "left", bottom: "top", top: "bottom" },
+        gt = { start: "end", end: "start" };
+      function mt(n, i, h) {
+        return _(n, K(i, h));
+      }
+      function V(n, i) {
+        return typeof n == "function" ? n(i) : n;
+      }
+      function N(n) {
+        return n.split("-")[0];
+      }
+      function it(n) {
+        return n.split("-")[1];
+      }
+      function l(n) {
+        return n === "x" ? "y" : "x";
+      }
+      function c(n) {
+        return n === "y" ? "height" : "width";
+      }
+      function A(n) {
+        return ["top", "bottom"].includes(N(n)) ? "y" : "x";
+      }
+      function S(n) {
+        return l(A(n));
+      }
+      function q(n, i, h) {
+        h === void 0 && (h = !1);
+        let s = it(n),
+          y = S(n),
+          f = c(y),
+          w =
+            y === "x"
+              ? s === (h ? "end" : "start")
+                ? "right"
+                : "left"
+              : s === "start"
+                ? "bottom"
+                : "top";
+        return (i.reference[f] > i.floating[f] && (w = Q(w)), [w, Q(w)]);
+      }
+      function G(n) {
+        return n.replace(/start|end/g, (i) => gt[i]);
+      }
+      function Q(n) {
+        return n.replace(/left|right|bottom|top/g, (i) => st[i]);
+      }
+      function nt(n) {
+        return typeof n != "number"
+          ? (function (i) {
+              return { top: 0, right: 0, bottom: 0, left: 0, ...i };
+            })(n)
+          : { top: n, right: n, bottom: n, left: n };
+      }
+      function dt(n) {
+        let { x: i, y: h, width: s, height: y } = n;
+        return {
+          width: s,
+          height: y,
+          top: h,
+          left: i,
+          right: i + s,
+          bottom: h + y,
+          x: i,
+          y: h,
+        };
+      }
+      function rt(n, i, h) {
+        let { reference: s, floating: y } = n,
+          f = A(i),
+          w = S(i),
+          k = c(w),
+          W = N(i),
+          M = f === "y",
+          L = s.x + s.width / 2 - y.width / 2,
+          p = s.y + s.height / 2 - y.height / 2,
+          E = s[k] / 2 - y[k] / 2,
+          m;
+        switch (W) {
+          case "top":
+            m = { x: L, y: s.y - y.height };
+            break;
+          case "bottom":
+            m = { x: L, y: s.y + s.height };
+            break;
+          case "right":
+            m = { x: s.x + s.width, y: p };
+            break;
+          case "left":
+            m = { x: s.x - y.width, y: p };
+            break;
+          default:
+            m = { x: s.x, y: s.y };
+        }
+        switch (it(i)) {
+          case "start":
+            m[w] -= E * (h && M ? -1 : 1);
+            break;
+          case "end":
+            m[w] += E * (h && M ? -1 : 1);
+        }
+        return m;
+      }
+      async function ct(n, i) {
+        var h;
+        i === void 0 && (i = {});
+        let { x: s, y, platform: f, rects: w, elements: k, strategy: W } = n,
+          {
+            boundary: M = "clippingAncestors",
+            rootBoundary: L = "viewport",
+            elementContext: p = "floating",
+            altBoundary: E = !1,
+            padding: m = 0,
+          } = V(i, n),
+          T = nt(m),
+          P = k[E ? (p === "floating" ? "reference" : "floating") : p],
+          O = dt(
+            await f.getClippingRect({
+              element:
+                (h = await (f.isElement == null ? void 0 : f.isElement(P))) ==
+                  null || h
+                  ? P
+                  : P.contextElement ||
+                    (await (f.getDocumentElement == null
+                      ? void 0
+                      : f.getDocumentElement(k.floating))),
+              boundary: M,
+              rootBoundary: L,
+              strategy: W,
+            }),
+          ),
+          H =
+            p === "floating"
+              ? { x: s, y, width: w.floating.width, height: w.floating.height }
+              : w.reference,
+          B = await (f.getOffsetParent == null
+            ? void 0
+            : f.getOffsetParent(k.floating)),
+          C = ((await (f.isElement == null ? void 0 : f.isElement(B))) &&
+            (await (f.getScale == null ? void 0 : f.getScale(B)))) || {
+            x: 1,
+            y: 1,
+          },
+          D = dt(
+            f.convertOffsetParentRelativeRectToViewportRelativeRect
+              ? await f.convertOffsetParentRelativeRectToViewportRelativeRect({
+                  elements: k,
+                  rect: H,
+                  offsetParent: B,
+                  strategy: W,
+                })
+              : H,
+          );
+        return {
+          top: (O.top - D.top + T.top) / C.y,
+          bottom: (D.bottom - O.bottom + T.bottom) / C.y,
+          left: (O.left - D.left + T.left) / C.x,
+          right: (D.right - O.right + T.right) / C.x,
+        };
+      }
+      function ft(n, i) {
+        return {
+          top: n.top - i.height,
+          right: n.right - i.width,
+          bottom: n.bottom - i.height,
+          left: n.left - i.width,
+        };
+      }
+      function ht(n) {
+        return R.some((i) => n[i] >= 0);
+      }
+      function pt(n) {
+        let i = K(...n.map((s) => s.left)),
+          h = K(...n.map((s) => s.top));
+        return {
+          x: i,
+          y: h,
+          width: _(...n.map((s) => s.right)) - i,
+          height: _(...n.map((s) => s.bottom)) - h,
+        };
+      }
+      ((g.arrow = (n) => ({
+        name: "arrow",
+        options: n,
+        async fn(i) {
+          let {
+              x: h,
+              y: s,
+              placement: y,
+              rects: f,
+              platform: w,
+              elements: k,
+              middlewareData: W,
+            } = i,
+            { element: M, padding: L = 0 } = V(n, i) || {};
+          if (M == null) return {};
+          let p = nt(L),
+            E = { x: h, y: s },
+            m = S(y),
+            T = c(m),
+            P = await w.getDimensions(M),
+            O = m === "y",
+            H = O ? "top" : "left",
+            B = O ? "bottom" : "right",
+            C = O ? "clientHeight" : "clientWidth",
+            D = f.reference[T] + f.reference[m] - E[m] - f.floating[T],
+            F = E[m] - f.reference[m],
+            Y = await (w.getOffsetParent == null
+              ? void 0
+              : w.getOffsetParent(M)),
+            J = Y ? Y[C] : 0;
+          (J && (await (w.isElement == null ? void 0 : w.isElement(Y)))) ||
+            (J = k.floating[C] || f.floating[T]);
+          let ot = D / 2 - F / 2,
+            z = J / 2 - P[T] / 2 - 1,
+            j = K(p[H], z),
+            t = K(p[B], z),
+            e = j,
+            o = J - P[T] - t,
+            r = J / 2 - P[T] / 2 + ot,
+            a = mt(e, r, o),
+            u =
+              !W.arrow &&
+              it(y) != null &&
+              r !== a &&
+              f.reference[T] / 2 - (r < e ? j : t) - P[T] / 2 < 0,
+            b = u ? (r < e ? r - e : r - o) : 0;
+          return {
+            [m]: E[m] + b,
+            data: {
+              [m]: a,
+              centerOffset: r - a - b,
+              ...(u && { alignmentOffset: b }),
+            },
+            reset: u,
+          };
+        },
+      })),
+        (g.autoPlacement = function (n) {
+          return (
+            n === void 0 && (n = {}),
+            {
+              name: "autoPlacement",
+              options: n,
+              async fn(i) {
+                var h, s, y;
+                let {
+                    rects: f,
+                    middlewareData: w,
+                    placement: k,
+                    platform: W,
+                    elements: M,
+                  } = i,
+                  {
+                    crossAxis: L = !1,
+                    alignment: p,
+                    allowedPlacements: E = lt,
+                    autoAlignment: m = !0,
+                    ...T
+                  } = V(n, i),
+                  P =
+                    p !== void 0 || E === lt
+                      ? (function (z, j, t) {
+                          return (
+                            z
+                              ? [
+                                  ...t.filter((e) => it(e) === z),
+                                  ...t.filter((e) => it(e) !== z),
+                                ]
+                              : t.filter((e) => N(e) === e)
+                          ).filter(
+                            (e) => !z || it(e) === z || (!!j && G(e) !== e),
+                          );
+                        })(p || null, m, E)
+                      : E,
+                  O = await ct(i, T),
+                  H = ((h = w.autoPlacement) == null ? void 0 : h.index) || 0,
+                  B = P[H];
+                if (B == null) return {};
+                let C = q(
+                  B,
+                  f,
+                  await (W.isRTL == null ? void 0 : W.isRTL(M.floating)),
+                );
+                if (k !== B) return { reset: { placement: P[0] } };
+                let D = [O[N(B)], O[C[0]], O[C[1]]],
+                  F = [
+                    ...(((s = w.autoPlacement) == null
+                      ? void 0
+                      : s.overflows) || []),
+                    { placement: B, overflows: D },
+                  ],
+                  Y = P[H + 1];
+                if (Y)
+                  return {
+                    data: { index: H + 1, overflows: F },
+                    reset: { placement: Y },
+                  };
+                let J = F.map((z) => {
+                    let j = it(z.placement);
+                    return [
+                      z.placement,
+                      j && L
+                        ? z.overflows.slice(0, 2).reduce((t, e) => t + e, 0)
+                        : z.overflows[0],
+                      z.overflows,
+                    ];
+                  }).sort((z, j) => z[1] - j[1]),
+                  ot =
+                    ((y = J.filter((z) =>
+                      z[2].slice(0, it(z[0]) ? 2 : 3).every((j) => j <= 0),
+                    )[0]) == null
+                      ? void 0
+                      : y[0]) || J[0][0];
+                return ot !== k
+                  ? {
+                      data: { index: H + 1, overflows: F },
+                      reset: { placement: ot },
+                    }
+                  : {};
+              },
+            }
+          );
+        }),
+        (g.computePosition = async (n, i, h) => {
+          let {
+              placement: s = "bottom",
+              strategy: y = "absolute",
+              middleware: f = [],
+              platform: w,
+            } = h,
+            k = f.filter(Boolean),
+            W = await (w.isRTL == null ? void 0 : w.isRTL(i)),
+            M = await w.getElementRects({
+              reference: n,
+              floating: i,
+              strategy: y,
+            }),
+            { x: L, y: p } = rt(M, s, W),
+            E = s,
+            m = {},
+            T = 0;
+          for (let P = 0; P < k.length; P++) {
+            let { name: O, fn: H } = k[P],
+              {
+                x: B,
+                y: C,
+                data: D,
+                reset: F,
+              } = await H({
+                x: L,
+                y: p,
+                initialPlacement: s,
+                placement: E,
+                strategy: y,
+                middlewareData: m,
+                rects: M,
+                platform: w,
+                elements: { reference: n, floating: i },
+              });
+            ((L = B ?? L),
+              (p = C ?? p),
+              (m = { ...m, [O]: { ...m[O], ...D } }),
+              F &&
+                T <= 50 &&
+                (T++,
+                typeof F == "object" &&
+                  (F.placement && (E = F.placement),
+                  F.rects &&
+                    (M =
+                      F.rects === !0
+                        ? await w.getElementRects({
+                            reference: n,
+                            floating: i,
+                            strategy: y,
+                          })
+                        : F.rects),
+                  ({ x: L, y: p } = rt(M, E, W))),
+                (P = -1)));
+          }
+          return { x: L, y: p, placement: E, strategy: y, middlewareData: m };
+        }),
+        (g.detectOverflow = ct),
+        (g.flip = function (n) {
+          return (
+            n === void 0 && (n = {}),
+            {
+              name: "flip",
+              options: n,
+              async fn(i) {
+                var h, s;
+                let {
+                    placement: y,
+                    middlewareData: f,
+                    rects: w,
+                    initialPlacement: k,
+                    platform: W,
+                    elements: M,
+                  } = i,
+                  {
+                    mainAxis: L = !0,
+                    crossAxis: p = !0,
+                    fallbackPlacements: E,
+                    fallbackStrategy: m = "bestFit",
+                    fallbackAxisSideDirection: T = "none",
+                    flipAlignment: P = !0,
+                    ...O
+                  } = V(n, i);
+                if ((h = f.arrow) != null && h.alignmentOffset) return {};
+                let H = N(y),
+                  B = A(k),
+                  C = N(k) === k,
+                  D = await (W.isRTL == null ? void 0 : W.isRTL(M.floating)),
+                  F =
+                    E ||
+                    (C || !P
+                      ? [Q(k)]
+                      : (function (a) {
+                          let u = Q(a);
+                          return [G(a), u, G(u)];
+                        })(k)),
+                  Y = T !== "none";
+                !E &&
+                  Y &&
+                  F.push(
+                    ...(function (a, u, b, x) {
+                      let d = it(a),
+                        v = (function (I, X, et) {
+                          let Z = ["left", "right"],
+                            $ = ["right", "left"],
+                            tt = ["top", "bottom"],
+                            U = ["bottom", "top"];
+                          switch (I) {
+                            case "top":
+                            case "bottom":
+                              return et ? (X ? $ : Z) : X ? Z : $;
+                            case "left":
+                            case "right":
+                              return X ? tt : U;
+                            default:
+                              return [];
+                          }
+                        })(N(a), b === "start", x);
+                      return (
+                        d &&
+                          ((v = v.map((I) => I + "-" + d)),
+                          u && (v = v.concat(v.map(G)))),
+                        v
+                      );
+                    })(k, P, T, D),
+                  );
+                let J = [k, ...F],
+                  ot = await ct(i, O),
+                  z = [],
+                  j = ((s = f.flip) == null ? void 0 : s.overflows) || [];
+                if ((L && z.push(ot[H]), p)) {
+                  let a = q(y, w, D);
+                  z.push(ot[a[0]], ot[a[1]]);
+                }
+                if (
+                  ((j = [...j, { placement: y, overflows: z }]),
+                  !z.every((a) => a <= 0))
+                ) {
+                  var t, e;
+                  let a = (((t = f.flip) == null ? void 0 : t.index) || 0) + 1,
+                    u = J[a];
+                  if (u) {
+                    var o;
+                    let x = p === "alignment" && B !== A(u),
+                      d = ((o = j[0]) == null ? void 0 : o.overflows[0]) > 0;
+                    if (!x || d)
+                      return {
+                        data: { index: a, overflows: j },
+                        reset: { placement: u },
+                      };
+                  }
+                  let b =
+                    (e = j
+                      .filter((x) => x.overflows[0] <= 0)
+                      .sort((x, d) => x.overflows[1] - d.overflows[1])[0]) ==
+                    null
+                      ? void 0
+                      : e.placement;
+                  if (!b)
+                    switch (m) {
+                      case "bestFit": {
+                        var r;
+                        let x =
+                          (r = j
+                            .filter((d) => {
+                              if (Y) {
+                                let v = A(d.placement);
+                                return v === B || v === "y";
+                              }
+                              return !0;
+                            })
+                            .map((d) => [
+                              d.placement,
+                              d.overflows
+                                .filter((v) => v > 0)
+                                .reduce((v, I) => v + I, 0),
+                            ])
+                            .sort((d, v) => d[1] - v[1])[0]) == null
+                            ? void 0
+                            : r[0];
+                        x && (b = x);
+                        break;
+                      }
+                      case "initialPlacement":
+                        b = k;
+                    }
+                  if (y !== b) return { reset: { placement: b } };
+                }
+                return {};
+              },
+            }
+          );
+        }),
+        (g.hide = function (n) {
+          return (
+            n === void 0 && (n = {}),
+            {
+              name: "hide",
+              options: n,
+              async fn(i) {
+                let { rects: h } = i,
+                  { strategy: s = "referenceHidden", ...y } = V(n, i);
+                switch (s) {
+                  case "referenceHidden": {
+                    let f = ft(
+                      await ct(i, { ...y, elementContext: "reference" }),
+                      h.reference,
+                    );
+                    return {
+                      data: {
+                        referenceHiddenOffsets: f,
+                        referenceHidden: ht(f),
+                      },
+                    };
+                  }
+                  case "escaped": {
+                    let f = ft(
+                      await ct(i, { ...y, altBoundary: !0 }),
+                      h.floating,
+                    );
+                    return { data: { escapedOffsets: f, escaped: ht(f) } };
+                  }
+                  default:
+                    return {};
+                }
+              },
+            }
+          );
+        }),
+        (g.inline = function (n) {
+          return (
+            n === void 0 && (n = {}),
+            {
+              name: "inline",
+              options: n,
+              async fn(i) {
+                let {
+                    placement: h,
+                    elements: s,
+                    rects: y,
+                    platform: f,
+                    strategy: w,
+                  } = i,
+                  { padding: k = 2, x: W, y: M } = V(n, i),
+                  L = Array.from(
+                    (await (f.getClientRects == null
+                      ? void 0
+                      : f.getClientRects(s.reference))) || [],
+                  ),
+                  p = (function (P) {
+                    let O = P.slice().sort((C, D) => C.y - D.y),
+                      H = [],
+                      B = null;
+                    for (let C = 0; C < O.length; C++) {
+                      let D = O[C];
+                      (!B || D.y - B.y > B.height / 2
+                        ? H.push([D])
+                        : H[H.length - 1].push(D),
+                        (B = D));
+                    }
+                    return H.map((C) => dt(pt(C)));
+                  })(L),
+                  E = dt(pt(L)),
+                  m = nt(k),
+                  T = await f.getElementRects({
+                    reference: {
+                      getBoundingClientRect: function () {
+                        if (
+                          p.length === 2 &&
+                          p[0].left > p[1].right &&
+                          W != null &&
+                          M != null
+                        )
+                          return (
+                            p.find(
+                              (P) =>
+                                W > P.left - m.left &&
+                                W < P.right + m.right &&
+                                M > P.top - m.top &&
+                                M < P.bottom + m.bottom,
+                            ) || E
+                          );
+                        if (p.length >= 2) {
+                          if (A(h) === "y") {
+                            let F = p[0],
+                              Y = p[p.length - 1],
+                              J = N(h) === "top",
+                              ot = F.top,
+                              z = Y.bottom,
+                              j = J ? F.left : Y.left,
+                              t = J ? F.right : Y.right;
+                            return {
+                              top: ot,
+                              bottom: z,
+                              left: j,
+                              right: t,
+                              width: t - j,
+                              height: z - ot,
+                              x: j,
+                              y: ot,
+                            };
+                          }
+                          let P = N(h) === "left",
+                            O = _(...p.map((F) => F.right)),
+                            H = K(...p.map((F) => F.left)),
+                            B = p.filter((F) =>
+                              P ? F.left === H : F.right === O,
+                            ),
+                            C = B[0].top,
+                            D = B[B.length - 1].bottom;
+                          return {
+                            top: C,
+                            bottom: D,
+                            left: H,
+                            right: O,
+                            width: O - H,
+                            height: D - C,
+                            x: H,
+                            y: C,
+                          };
+                        }
+                        return E;
+                      },
+                    },
+                    floating: s.floating,
+                    strategy: w,
+                  });
+                return y.reference.x !== T.reference.x ||
+                  y.reference.y !== T.reference.y ||
+                  y.reference.width !== T.reference.width ||
+                  y.reference.height !== T.reference.height
+                  ? { reset: { rects: T } }
+                  : {};
+              },
+            }
+          );
+        }),
+        (g.limitShift = function (n) {
+          return (
+            n === void 0 && (n = {}),
+            {
+              options: n,
+              fn(i) {
+                let {
+                    x: h,
+                    y: s,
+                    placement: y,
+                    rects: f,
+                    middlewareData: w,
+                  } = i,
+                  {
+                    offset: k = 0,
+                    mainAxis: W = !0,
+                    crossAxis: M = !0,
+                  } = V(n, i),
+                  L = { x: h, y: s },
+                  p = A(y),
+                  E = l(p),
+                  m = L[E],
+                  T = L[p],
+                  P = V(k, i),
+                  O =
+                    typeof P == "number"
+                      ? { mainAxis: P, crossAxis: 0 }
+                      : { mainAxis: 0, crossAxis: 0, ...P };
+                if (W) {
+                  let C = E === "y" ? "height" : "width",
+                    D = f.reference[E] - f.floating[C] + O.mainAxis,
+                    F = f.reference[E] + f.reference[C] - O.mainAxis;
+                  m < D ? (m = D) : m > F && (m = F);
+                }
+                if (M) {
+                  var H, B;
+                  let C = E === "y" ? "width" : "height",
+                    D = ["top", "left"].includes(N(y)),
+                    F =
+                      f.reference[p] -
+                      f.floating[C] +
+                      ((D && ((H = w.offset) == null ? void 0 : H[p])) || 0) +
+                      (D ? 0 : O.crossAxis),
+                    Y =
+                      f.reference[p] +
+                      f.reference[C] +
+                      (D ? 0 : ((B = w.offset) == null ? void 0 : B[p]) || 0) -
+                      (D ? O.crossAxis : 0);
+                  T < F ? (T = F) : T > Y && (T = Y);
+                }
+                return { [E]: m, [p]: T };
+              },
+            }
+          );
+        }),
+        (g.offset = function (n) {
+          return (
+            n === void 0 && (n = 0),
+            {
+              name: "offset",
+              options: n,
+              async fn(i) {
+                var h, s;
+                let { x: y, y: f, placement: w, middlewareData: k } = i,
+                  W = await (async function (M, L) {
+                    let { placement: p, platform: E, elements: m } = M,
+                      T = await (E.isRTL == null
+                        ? void 0
+                        : E.isRTL(m.floating)),
+                      P = N(p),
+                      O = it(p),
+                      H = A(p) === "y",
+                      B = ["left", "top"].includes(P) ? -1 : 1,
+                      C = T && H ? -1 : 1,
+                      D = V(L, M),
+                      {
+                        mainAxis: F,
+                        crossAxis: Y,
+                        alignmentAxis: J,
+                      } = typeof D == "number"
+                        ? { mainAxis: D, crossAxis: 0, alignmentAxis: null }
+                        : {
+                            mainAxis: D.mainAxis || 0,
+                            crossAxis: D.crossAxis || 0,
+                            alignmentAxis: D.alignmentAxis,
+                          };
+                    return (
+                      O &&
+                        typeof J == "number" &&
+                        (Y = O === "end" ? -1 * J : J),
+                      H ? { x: Y * C, y: F * B } : { x: F * B, y: Y * C }
+                    );
+                  })(i, n);
+                return w === ((h = k.offset) == null ? void 0 : h.placement) &&
+                  (s = k.arrow) != null &&
+                  s.alignmentOffset
+                  ? {}
+                  : { x: y + W.x, y: f + W.y, data: { ...W, placement: w } };
+              },
+            }
+          );
+        }),
+        (g.rectToClientRect = dt),
+        (g.shift = function (n) {
+          return (
+            n === void 0 && (n = {}),
+            {
+              name: "shift",
+              options: n,
+              async fn(i) {
+                let { x: h, y: s, placement: y } = i,
+                  {
+                    mainAxis: f = !0,
+                    crossAxis: w = !1,
+                    limiter: k = {
+                      fn: (O) => {
+                        let { x: H, y: B } = O;
+                        return { x: H, y: B };
+                      },
+                    },
+                    ...W
+                  } = V(n, i),
+                  M = { x: h, y: s },
+                  L = await ct(i, W),
+                  p = A(N(y)),
+                  E = l(p),
+                  m = M[E],
+                  T = M[p];
+                if (f) {
+                  let O = E === "y" ? "bottom" : "right";
+                  m = mt(m + L[E === "y" ? "top" : "left"], m, m - L[O]);
+                }
+                if (w) {
+                  let O = p === "y" ? "bottom" : "right";
+                  T = mt(T + L[p === "y" ? "top" : "left"], T, T - L[O]);
+                }
+                let P = k.fn({ ...i, [E]: m, [p]: T });
+                return {
+                  ...P,
+                  data: { x: P.x - h, y: P.y - s, enabled: { [E]: f, [p]: w } },
+                };
+              },
+            }
+          );
+        }),
+        (g.size = function (n) {
+          return (
+            n === void 0 && (n = {}),
+            {
+              name: "size",
+              options: n,
+              async fn(i) {
+                var h, s;
+                let { placement: y, rects: f, platform: w, elements: k } = i,
+                  { apply: W = () => {}, ...M } = V(n, i),
+                  L = await ct(i, M),
+                  p = N(y),
+                  E = it(y),
+                  m = A(y) === "y",
+                  { width: T, height: P } = f.floating,
+                  O,
+                  H;
+                p === "top" || p === "bottom"
+                  ? ((O = p),
+                    (H =
+                      E ===
+                      ((await (w.isRTL == null ? void 0 : w.isRTL(k.floating)))
+                        ? "start"
+                        : "end")
+                        ? "left"
+                        : "right"))
+                  : ((H = p), (O = E === "end" ? "top" : "bottom"));
+                let B = P - L.top - L.bottom,
+                  C = T - L.left - L.right,
+                  D = K(P - L[O], B),
+                  F = K(T - L[H], C),
+                  Y = !i.middlewareData.shift,
+                  J = D,
+                  ot = F;
+                if (
+                  ((h = i.middlewareData.shift) != null &&
+                    h.enabled.x &&
+                    (ot = C),
+                  (s = i.middlewareData.shift) != null &&
+                    s.enabled.y &&
+                    (J = B),
+                  Y && !E)
+                ) {
+                  let j = _(L.left, 0),
+                    t = _(L.right, 0),
+                    e = _(L.top, 0),
+                    o = _(L.bottom, 0);
+                  m
+                    ? (ot =
+                        T -
+                        2 * (j !== 0 || t !== 0 ? j + t : _(L.left, L.right)))
+                    : (J =
+                        P -
+                        2 * (e !== 0 || o !== 0 ? e + o : _(L.top, L.bottom)));
+                }
+                await W({ ...i, availableWidth: ot, availableHeight: J });
+                let z = await w.getDimensions(k.floating);
+                return T !== z.width || P !== z.height
+                  ? { reset: { rects: !0 } }
+                  : {};
+              },
+            }
+          );
+        }));
+    });
+  });
+  var Ut = qt((Rt, $t) => {
+    (function (g, R) {
+      typeof Rt == "object" && typeof $t < "u"
+        ? R(Rt, Et())
+        : typeof define == "function" && define.amd
+          ? define(["exports", "./floatingUICore"], R)
+          : R(
+              ((g =
+                typeof globalThis < "u"
+                  ? globalThis
+                  : g || self).FloatingUIDOM = {}),
+              g.FloatingUICore,
+            );
+    })(Rt, function (g, R) {
+      "use strict";
+      let at = Math.min,
+        lt = Math.max,
+        K = Math.round,
+        _ = Math.floor,
+        st = (t) => ({ x: t, y: t });
+      function gt() {
+        return typeof window < "u";
+      }
+      function mt(t) {
+        return it(t) ? (t.nodeName || "").toLowerCase() : "#document";
+      }
+      function V(t) {
+        var e;
+        return (
+          (t == null || (e = t.ownerDocument) == null
+            ? void 0
+            : e.defaultView) || window
+        );
+      }
+      function N(t) {
+        var e;
+        return (e =
+          (it(t) ? t.ownerDocument : t.document) || window.document) == null
+          ? void 0
+          : e.documentElement;
+      }
+      function it(t) {
+        return !!gt() && (t instanceof Node || t instanceof V(t).Node);
+      }
+      function l(t) {
+        return !!gt() && (t instanceof Element || t instanceof V(t).Element);
+      }
+      function c(t) {
+        return (
+          !!gt() && (t instanceof HTMLElement || t instanceof V(t).HTMLElement)
+        );
+      }
+      function A(t) {
+        return (
+          !(!gt() || typeof ShadowRoot > "u") &&
+          (t instanceof ShadowRoot || t instanceof V(t).ShadowRoot)
+        );
+      }
+      function S(t) {
+        let { overflow: e, overflowX: o, overflowY: r, display: a } = rt(t);
+        return (
+          /auto|scroll|overlay|hidden|clip/.test(e + r + o) &&
+          !["inline", "contents"].includes(a)
+        );
+      }
+      function q(t) {
+        return ["table", "td", "th"].includes(mt(t));
+      }
+      function G(t) {
+        return [":popover-open", ":modal"].some((e) => {
+          try {
+            return t.matches(e);
+          } catch {
+            return !1;
+          }
+        });
+      }
+      function Q(t) {
+        let e = nt(),
+          o = l(t) ? rt(t) : t;
+        return (
+          ["transform", "translate", "scale", "rotate", "perspective"].some(
+            (r) => !!o[r] && o[r] !== "none",
+          ) ||
+          (!!o.containerType && o.containerType !== "normal") ||
+          (!e && !!o.backdropFilter && o.backdropFilter !== "none") ||
+          (!e && !!o.filter && o.filter !== "none") ||
+          [
+            "transform",
+            "translate",
+            "scale",
+            "rotate",
+            "perspective",
+            "filter",
+          ].some((r) => (o.willChange || "").includes(r)) ||
+          ["paint", "layout", "strict", "content"].some((r) =>
+            (o.contain || "").includes(r),
+          )
+        );
+      }
+      function nt() {
+        return (
+          !(typeof CSS > "u" || !CSS.supports) &&
+          CSS.supports("-webkit-backdrop-filter", "none")
+        );
+      }
+      function dt(t) {
+        return ["html", "body", "#document"].includes(mt(t));
+      }
+      function rt(t) {
+        return V(t).getComputedStyle(t);
+      }
+      function ct(t) {
+        return l(t)
+          ? { scrollLeft: t.scrollLeft, scrollTop: t.scrollTop }
+          : { scrollLeft: t.scrollX, scrollTop: t.scrollY };
+      }
+      function ft(t) {
+        if (mt(t) === "html") return t;
+        let e = t.assignedSlot || t.parentNode || (A(t) && t.host) || N(t);
+        return A(e) ? e.host : e;
+      }
+      function ht(t) {
+        let e = ft(t);
+        return dt(e)
+          ? t.ownerDocument
+            ? t.ownerDocument.body
+            : t.body
+          : c(e) && S(e)
+            ? e
+            : ht(e);
+      }
+      function pt(t, e, o) {
+        var r;
+        (e === void 0 && (e = []), o === void 0 && (o = !0));
+        let a = ht(t),
+          u = a === ((r = t.ownerDocument) == null ? void 0 : r.body),
+          b = V(a);
+        if (u) {
+          let x = n(b);
+          return e.concat(
+            b,
+            b.visualViewport || [],
+            S(a) ? a : [],
+            x && o ? pt(x) : [],
+          );
+        }
+        return e.concat(a, pt(a, [], o));
+      }
+      function n(t) {
+        return t.parent && Object.getPrototypeOf(t.parent)
+          ? t.frameElement
+          : null;
+      }
+      function i(t) {
+        let e = rt(t),
+          o = parseFloat(e.width) || 0,
+          r = parseFloat(e.height) || 0,
+          a = c(t),
+          u = a ? t.offsetWidth : o,
+          b = a ? t.offsetHeight : r,
+          x = K(o) !== u || K(r) !== b;
+        return (x && ((o = u), (r = b)), { width: o, height: r, $: x });
+      }
+      function h(t) {
+        return l(t) ? t : t.contextElement;
+      }
+      function s(t) {
+        let e = h(t);
+        if (!c(e)) return st(1);
+        let o = e.getBoundingClientRect(),
+          { width: r, height: a, $: u } = i(e),
+          b = (u ? K(o.width) : o.width) / r,
+          x = (u ? K(o.height) : o.height) / a;
+        return (
+          (b && Number.isFinite(b)) || (b = 1),
+          (x && Number.isFinite(x)) || (x = 1),
+          { x: b, y: x }
+        );
+      }
+      let y = st(0);
+      function f(t) {
+        let e = V(t);
+        return nt() && e.visualViewport
+          ? { x: e.visualViewport.offsetLeft, y: e.visualViewport.offsetTop }
+          : y;
+      }
+      function w(t, e, o, r) {
+        (e === void 0 && (e = !1), o === void 0 && (o = !1));
+        let a = t.getBoundingClientRect(),
+          u = h(t),
+          b = st(1);
+        e && (r ? l(r) && (b = s(r)) : (b = s(t)));
+        let x = (function (et, Z, $) {
+            return (Z === void 0 && (Z = !1), !(!$ || (Z && $ !== V(et))) && Z);
+          })(u, o, r)
+            ? f(u)
+            : st(0),
+          d = (a.left + x.x) / b.x,
+          v = (a.top + x.y) / b.y,
+          I = a.width / b.x,
+          X = a.height / b.y;
+        if (u) {
+          let et = V(u),
+            Z = r && l(r) ? V(r) : r,
+            $ = et,
+            tt = n($);
+          for (; tt && r && Z !== $; ) {
+            let U = s(tt),
+              ut = tt.getBoundingClientRect(),
+              yt = rt(tt),
+              vt = ut.left + (tt.clientLeft + parseFloat(yt.paddingLeft)) * U.x,
+              bt = ut.top + (tt.clientTop + parseFloat(yt.paddingTop)) * U.y;
+            ((d *= U.x),
+              (v *= U.y),
+              (I *= U.x),
+              (X *= U.y),
+              (d += vt),
+              (v += bt),
+              ($ = V(tt)),
+              (tt = n($)));
+          }
+        }
+        return R.rectToClientRect({ width: I, height: X, x: d, y: v });
+      }
+      function k(t, e) {
+        let o = ct(t).scrollLeft;
+        return e ? e.left + o : w(N(t)).left + o;
+      }
+      function W(t, e, o) {
+        o === void 0 && (o = !1);
+        let r = t.getBoundingClientRect();
+        return {
+          x: r.left + e.scrollLeft - (o ? 0 : k(t, r)),
+          y: r.top + e.scrollTop,
+        };
+      }
+      function M(t, e, o) {
+        let r;
+        if (e === "viewport")
+          r = (function (a, u) {
+            let b = V(a),
+              x = N(a),
+              d = b.visualViewport,
+              v = x.clientWidth,
+              I = x.clientHeight,
+              X = 0,
+              et = 0;
+            if (d) {
+              ((v = d.width), (I = d.height));
+              let Z = nt();
+              (!Z || (Z && u === "fixed")) &&
+                ((X = d.offsetLeft), (et = d.offsetTop));
+            }
+            return { width: v, height: I, x: X, y: et };
+          })(t, o);
+        else if (e === "document")
+          r = (function (a) {
+            let u = N(a),
+              b = ct(a),
+              x = a.ownerDocument.body,
+              d = lt(
+                u.scrollWidth,
+                u.clientWidth,
+                x.scrollWidth,
+                x.clientWidth,
+              ),
+              v = lt(
+                u.scrollHeight,
+                u.clientHeight,
+                x.scrollHeight,
+                x.clientHeight,
+              ),
+              I = -b.scrollLeft + k(a),
+              X = -b.scrollTop;
+            return (
+              rt(x).direction === "rtl" &&
+                (I += lt(u.clientWidth, x.clientWidth) - d),
+              { width: d, height: v, x: I, y: X }
+            );
+          })(N(t));
+        else if (l(e))
+          r = (function (a, u) {
+            let b = w(a, !0, u === "fixed"),
+              x = b.top + a.clientTop,
+              d = b.left + a.clientLeft,
+              v = c(a) ? s(a) : st(1);
+            return {
+              width: a.clientWidth * v.x,
+              height: a.clientHeight * v.y,
+              x: d * v.x,
+              y: x * v.y,
+            };
+          })(e, o);
+        else {
+          let a = f(t);
+          r = { x: e.x - a.x, y: e.y - a.y, width: e.width, height: e.height };
+        }
+        return R.rectToClientRect(r);
+      }
+      function L(t, e) {
+        let o = ft(t);
+        return (
+          !(o === e || !l(o) || dt(o)) &&
+          (rt(o).position === "fixed" || L(o, e))
+        );
+      }
+      function p(t, e, o) {
+        let r = c(e),
+          a = N(e),
+          u = o === "fixed",
+          b = w(t, !0, u, e),
+          x = { scrollLeft: 0, scrollTop: 0 },
+          d = st(0);
+        function v() {
+          d.x = k(a);
+        }
+        if (r || (!r && !u))
+          if (((mt(e) !== "body" || S(a)) && (x = ct(e)), r)) {
+            let X = w(e, !0, u, e);
+            ((d.x = X.x + e.clientLeft), (d.y = X.y + e.clientTop));
+          } else a && v();
+        u && !r && a && v();
+        let I = !a || r || u ? st(0) : W(a, x);
+        return {
+          x: b.left + x.scrollLeft - d.x - I.x,
+          y: b.top + x.scrollTop - d.y - I.y,
+          width: b.width,
+          height: b.height,
+        };
+      }
+      function E(t) {
+        return rt(t).position === "static";
+      }
+      function m(t, e) {
+        if (!c(t) || rt(t).position === "fixed") return null;
+        if (e) return e(t);
+        let o = t.offsetParent;
+        return (N(t) === o && (o = o.ownerDocument.body), o);
+      }
+      function T(t, e) {
+        let o = V(t);
+        if (G(t)) return o;
+        if (!c(t)) {
+          let a = ft(t);
+          for (; a && !dt(a); ) {
+            if (l(a) && !E(a)) return a;
+            a = ft(a);
+          }
+          return o;
+        }
+        let r = m(t, e);
+        for (; r && q(r) && E(r); ) r = m(r, e);
+        return r && dt(r) && E(r) && !Q(r)
+          ? o
+          : r ||
+              (function (a) {
+                let u = ft(a);
+                for (; c(u) && !dt(u); ) {
+                  if (Q(u)) return u;
+                  if (G(u)) return null;
+                  u = ft(u);
+                }
+                return null;
+              })(t) ||
+              o;
+      }
+      let P = {
+        convertOffsetParentRelativeRectToViewportRelativeRect: function (t) {
+          let { elements: e, rect: o, offsetParent: r, strategy: a } = t,
+            u = a === "fixed",
+            b = N(r),
+            x = !!e && G(e.floating);
+          if (r === b || (x && u)) return o;
+          let d = { scrollLeft: 0, scrollTop: 0 },
+            v = st(1),
+            I = st(0),
+            X = c(r);
+          if (
+            (X || (!X && !u)) &&
+            ((mt(r) !== "body" || S(b)) && (d = ct(r)), c(r))
+          ) {
+            let Z = w(r);
+            ((v = s(r)), (I.x = Z.x + r.clientLeft), (I.y = Z.y + r.clientTop));
+          }
+          let et = !b || X || u ? st(0) : W(b, d, !0);
+          return {
+            width: o.width * v.x,
+            height: o.height * v.y,
+            x: o.x * v.x - d.scrollLeft * v.x + I.x + et.x,
+            y: o.y * v.y - d.scrollTop * v.y + I.y + et.y,
+          };
+        },
+        getDocumentElement: N,
+        getClippingRect: function (t) {
+          let { element: e, boundary: o, rootBoundary: r, strategy: a } = t,
+            u = [
+              ...(o === "clippingAncestors"
+                ? G(e)
+                  ? []
+                  : (function (d, v) {
+                      let I = v.get(d);
+                      if (I) return I;
+                      let X = pt(d, [], !1).filter(
+                          (tt) => l(tt) && mt(tt) !== "body",
+                        ),
+                        et = null,
+                        Z = rt(d).position === "fixed",
+                        $ = Z ? ft(d) : d;
+                      for (; l($) && !dt($); ) {
+                        let tt = rt($),
+                          U = Q($);
+                        (U || tt.position !== "fixed" || (et = null),
+                          (
+                            Z
+                              ? !U && !et
+                              : (!U &&
+                                  tt.position === "static" &&
+                                  et &&
+                                  ["absolute", "fixed"].includes(
+                                    et.position,
+                                  )) ||
+                                (S($) && !U && L(d, $))
+                          )
+                            ? (X = X.filter((ut) => ut !== $))
+                            : (et = tt),
+                          ($ = ft($)));
+                      }
+                      return (v.set(d, X), X);
+                    })(e, this._c)
+                : [].concat(o)),
+              r,
+            ],
+            b = u[0],
+            x = u.reduce(
+              (d, v) => {
+                let I = M(e, v, a);
+                return (
+                  (d.top = lt(I.top, d.top)),
+                  (d.right = at(I.right, d.right)),
+                  (d.bottom = at(I.bottom, d.bottom)),
+                  (d.left = lt(I.left, d.left)),
+                  d
+                );
+              },
+              M(e, b, a),
+            );
+          return {
+            width: x.right - x.left,
+            height: x.bottom - x.top,
+            x: x.left,
+            y: x.top,
+          };
+        },
+        getOffsetParent: T,
+        getElementRects: async function (t) {
+          let e = this.getOffsetParent || T,
+            o = this.getDimensions,
+            r = await o(t.floating);
+          return {
+            reference: p(t.reference, await e(t.floating), t.strategy),
+            floating: { x: 0, y: 0, width: r.width, height: r.height },
+          };
+        },
+        getClientRects: function (t) {
+          return Array.from(t.getClientRects());
+        },
+        getDimensions: function (t) {
+          let { width: e, height: o } = i(t);
+          return { width: e, height: o };
+        },
+        getScale: s,
+        isElement: l,
+        isRTL: function (t) {
+          return rt(t).direction === "rtl";
+        },
+      };
+      function O(t, e) {
+        return (
+          t.x === e.x &&
+          t.y === e.y &&
+          t.width === e.width &&
+          t.height === e.height
+        );
+      }
+      let H = R.detectOverflow,
+        B = R.offset,
+        C = R.autoPlacement,
+        D = R.shift,
+        F = R.flip,
+        Y = R.size,
+        J = R.hide,
+        ot = R.arrow,
+        z = R.inline,
+        j = R.limitShift;
+      return (
+        (g.arrow = ot),
+        (g.autoPlacement = C),
+        (g.autoUpdate = function (t, e, o, r) {
+          r === void 0 && (r = {});
+          let {
+              ancestorScroll: a = !0,
+              ancestorResize: u = !0,
+              elementResize: b = typeof ResizeObserver == "function",
+              layoutShift: x = typeof IntersectionObserver == "function",
+              animationFrame: d = !1,
+            } = r,
+            v = h(t),
+            I = a || u ? [...(v ? pt(v) : []), ...pt(e)] : [];
+          I.forEach((U) => {
+            (a && U.addEventListener("scroll", o, { passive: !0 }),
+              u && U.addEventListener("resize", o));
+          });
+          let X =
+              v && x
+                ? (function (U, ut) {
+                    let yt,
+                      vt = null,
+                      bt = N(U);
+                    function Pt() {
+                      var wt;
+                      (clearTimeout(yt),
+                        (wt = vt) == null || wt.disconnect(),
+                        (vt = null));
+                    }
+                    return (
+                      (function wt(Lt, xt) {
+                        (Lt === void 0 && (Lt = !1),
+                          xt === void 0 && (xt = 1),
+                          Pt());
+                        let Ot = U.getBoundingClientRect(),
+                          { left: Ct, top: Dt, width: St, height: kt } = Ot;
+                        if ((Lt || ut(), !St || !kt)) return;
+                        let Ft = {
+                            rootMargin:
+                              -_(Dt) +
+                              "px " +
+                              -_(bt.clientWidth - (Ct + St)) +
+                              "px " +
+                              -_(bt.clientHeight - (Dt + kt)) +
+                              "px " +
+                              -_(Ct) +
+                              "px",
+                            threshold: lt(0, at(1, xt)) || 1,
+                          },
+                          Bt = !0;
+                        function Ht(Mt) {
+                          let At = Mt[0].intersectionRatio;
+                          if (At !== xt) {
+                            if (!Bt) return wt();
+                            At
+                              ? wt(!1, At)
+                              : (yt = setTimeout(() => {
+                                  wt(!1, 1e-7);
+                                }, 1e3));
+                          }
+                          (At !== 1 || O(Ot, U.getBoundingClientRect()) || wt(),
+                            (Bt = !1));
+                        }
+                        try {
+                          vt = new IntersectionObserver(Ht, {
+                            ...Ft,
+                            root: bt.ownerDocument,
+                          });
+                        } catch {
+                          vt = new IntersectionObserver(Ht, Ft);
+                        }
+                        vt.observe(U);
+                      })(!0),
+                      Pt
+                    );
+                  })(v, o)
+                : null,
+            et,
+            Z = -1,
+            $ = null;
+          b &&
+            (($ = new ResizeObserver((U) => {
+              let [ut] = U;
+              (ut &&
+                ut.target === v &&
+                $ &&
+                ($.unobserve(e),
+                cancelAnimationFrame(Z),
+                (Z = requestAnimationFrame(() => {
+                  var yt;
+                  (yt = $) == null || yt.observe(e);
+                }))),
+                o());
+            })),
+            v && !d && $.observe(v),
+            $.observe(e));
+          let tt = d ? w(t) : null;
+          return (
+            d &&
+              (function U() {
+                let ut = w(t);
+                (tt && !O(tt, ut) && o(),
+                  (tt = ut),
+                  (et = requestAnimationFrame(U)));
+              })(),
+            o(),
+            () => {
+              var U;
+              (I.forEach((ut) => {
+                (a && ut.removeEventListener("scroll", o),
+                  u && ut.removeEventListener("resize", o));
+              }),
+                X?.(),
+                (U = $) == null || U.disconnect(),
+                ($ = null),
+                d && cancelAnimationFrame(et));
+            }
+          );
+        }),
+        (g.computePosition = (t, e, o) => {
+          let r = new Map(),
+            a = { platform: P, ...o },
+            u = { ...a.platform, _c: r };
+          return R.computePosition(t, e, { ...a, platform: u });
+        }),
+        (g.detectOverflow = H),
+        (g.flip = F),
+        (g.getOverflowAncestors = pt),
+        (g.hide = J),
+        (g.inline = z),
+        (g.limitShift = j),
+        (g.offset = B),
+        (g.platform = P),
+        (g.shift = D),
+        (g.size = Y),
+        (window.FloatingUIDOM = g),
+        g
+      );
+    });
+  });
+  var Jt = Wt(Ut()),
+    Kt = Wt(Et());
+})();
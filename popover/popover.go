@@ -1,11 +1,16 @@
 package popover
 
 import (
+	"crypto/sha256"
 	_ "embed"
+	"encoding/hex"
+	"net/http"
 	"strconv"
 
 	"github.com/plainkit/html"
+	"github.com/plainkit/ui/internal/assets"
 	"github.com/plainkit/ui/internal/styles"
+	"github.com/plainkit/ui/layer"
 )
 
 type Placement string
@@ -32,8 +37,22 @@ type ContentProps struct {
 	HoverDelay       int
 	HoverOutDelay    int
 	MatchWidth       bool
+	// Native renders a popover="manual" panel shown in the top layer, above every other
+	// element including modal dialogs, and positioned with CSS anchor positioning. Browsers
+	// without anchor positioning fall back to floating-ui. ESC and clicks outside still
+	// reach it through the layer manager.
+	Native bool
+	// FallbackURL serves floating-ui to Native panels in browsers without anchor
+	// positioning. It defaults to FallbackPath, where FallbackHandler must be mounted;
+	// otherwise those browsers leave the panel unpositioned and log a warning. The
+	// script is fetched only when such a browser opens a panel.
+	FallbackURL string
 }
 
+// FallbackPath is the default ContentProps.FallbackURL of Native panels. It names the
+// script by its content, so a new build of floating-ui gets a new URL.
+var FallbackPath = "/_ui/popover/floating-" + contentHash(floatingJS) + ".js"
+
 const (
 	PlacementTop         Placement = "top"
 	PlacementTopStart    Placement = "top-start"
@@ -52,6 +71,9 @@ const (
 const (
 	TriggerTypeHover TriggerType = "hover"
 	TriggerTypeClick TriggerType = "click"
+	// TriggerTypeManual leaves opening and closing to window.tui.popover, for components
+	// with their own pointer handling such as hovercard.
+	TriggerTypeManual TriggerType = "manual"
 )
 
 func triggerSpanArgsFromProps(baseClass string, extra ...string) func(p TriggerProps) []html.SpanArg {
@@ -103,8 +125,11 @@ func Trigger(args ...html.SpanArg) html.Node {
 		}
 	}
 
+	rest = append(rest, html.Child(layer.Assets()))
+
 	node := html.Span(append([]html.SpanArg{props}, rest...)...)
 
+	// Triggers load only the controller; floating-ui comes with the content needing it
 	return node.WithAssets("", popoverJS, "ui-popover")
 }
 
@@ -144,6 +169,19 @@ func contentDivArgsFromProps(baseClass string, extra ...string) func(p ContentPr
 			args = append(args, html.AData("pui-popover-match-width", "true"))
 		}
 
+		if p.Native {
+			fallback := p.FallbackURL
+			if fallback == "" {
+				fallback = FallbackPath
+			}
+
+			args = append(args,
+				html.AData("pui-popover-native", ""),
+				html.ACustom("popover", "manual"),
+				html.AData("pui-popover-fallback", fallback),
+			)
+		}
+
 		for _, a := range p.Attrs {
 			args = append(args, a)
 		}
@@ -153,9 +191,14 @@ func contentDivArgsFromProps(baseClass string, extra ...string) func(p ContentPr
 }
 
 func (p ContentProps) ApplyDiv(attrs *html.DivAttrs, children *[]html.Component) {
-	for _, a := range contentDivArgsFromProps(
-		styles.Panel("pointer-events-auto absolute z-[9999] hidden top-0 left-0 text-sm shadow-xl"),
-	)(p) {
+	base := styles.Panel("pointer-events-auto absolute z-50 hidden top-0 left-0 text-sm shadow-xl")
+	if p.Native {
+		// The popover attribute hides the panel until shown; inset and margin reset the
+		// user agent's centering so anchor positioning or floating-ui can place it
+		base = styles.Panel("pointer-events-auto fixed inset-auto m-0 overflow-visible p-0 text-sm shadow-xl")
+	}
+
+	for _, a := range contentDivArgsFromProps(base)(p) {
 		a.ApplyDiv(attrs, children)
 	}
 }
@@ -177,7 +220,7 @@ func Content(args ...html.DivArg) html.Node {
 
 	innerArgs := append([]html.DivArg{html.AClass("w-full overflow-hidden")}, rest...)
 
-	contentInner := []html.DivArg{html.Div(innerArgs...)}
+	contentInner := []html.DivArg{html.Div(innerArgs...), html.Child(layer.Assets())}
 	if props.ShowArrow {
 		contentInner = append(contentInner, html.Div(
 			html.AData("pui-popover-arrow", ""),
@@ -189,10 +232,39 @@ func Content(args ...html.DivArg) html.Node {
 		))
 	}
 
-	node := html.Div(append([]html.DivArg{props}, contentInner...)...)
+	// Native content loads the controller alone and fetches floating-ui when it has to
+	if !props.Native {
+		contentInner = append(contentInner, assets.Script("ui-floating", floatingJS))
+	}
+
+	contentInner = append(contentInner, assets.Script("ui-popover", popoverJS))
 
-	return node.WithAssets("", popoverJS, "ui-popover")
+	return html.Div(append([]html.DivArg{props}, contentInner...)...)
+}
+
+// FallbackHandler serves floating-ui for ContentProps.FallbackURL, usually mounted at
+// FallbackPath. That path changes with the script, so responses are cached for good.
+func FallbackHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/javascript; charset=utf-8")
+		w.Header().Set("Cache-Control", "public, max-age=31536000, immutable")
+
+		_, _ = w.Write([]byte(floatingJS))
+	})
 }
 
-//go:embed popover.js
-var popoverJS string
+// popover.js positions, opens and closes panels; floating.js is the floating-ui build it
+// positions non-native panels with.
+var (
+	//go:embed popover.js
+	popoverJS string
+	//go:embed floating.js
+	floatingJS string
+)
+
+// contentHash returns the first eight hex digits of the SHA-256 of s.
+func contentHash(s string) string {
+	sum := sha256.Sum256([]byte(s))
+
+	return hex.EncodeToString(sum[:4])
+}
@@ -120,7 +120,7 @@ func Progress(args ...html.DivArg) html.Node {
 		html.AClass(html.ClassMerge(
 			"h-full rounded-full transition-all",
 			sizeClass(props.Size),
-			variantClass(props.Variant),
+			VariantClass(props.Variant),
 			props.BarClass,
 		)),
 		html.AStyle("width: "+strconv.Itoa(percentage(props.Value, propsMax))+"%;"),
@@ -150,7 +150,9 @@ func sizeClass(size Size) string {
 	}
 }
 
-func variantClass(variant Variant) string {
+// VariantClass returns the indicator classes of variant, for scripts that switch the
+// variant of a rendered bar.
+func VariantClass(variant Variant) string {
 	switch variant {
 	case VariantSuccess:
 		return "bg-gradient-to-r from-emerald-500 to-emerald-400"
@@ -0,0 +1,248 @@
+package qrcode
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Level is the error correction level, trading capacity for resilience to damage.
+type Level int
+
+const (
+	LevelLow      Level = iota // Recovers about 7% of the symbol
+	LevelMedium                // Recovers about 15%
+	LevelQuartile              // Recovers about 25%
+	LevelHigh                  // Recovers about 30%
+)
+
+// ErrTooLong is returned by Encode when the data does not fit in a version 40 symbol.
+var ErrTooLong = errors.New("qrcode: data too long")
+
+// Code is an encoded QR symbol without its quiet zone.
+type Code struct {
+	Size    int // Modules per side
+	Version int
+	Level   Level
+	modules []bool
+}
+
+// Dark reports whether the module at column x and row y is dark; modules outside
+// the symbol are light.
+func (c *Code) Dark(x, y int) bool {
+	if x < 0 || y < 0 || x >= c.Size || y >= c.Size {
+		return false
+	}
+
+	return c.modules[y*c.Size+x]
+}
+
+// Encode encodes data in byte mode using the smallest version that holds it at level.
+func Encode(data []byte, level Level) (*Code, error) {
+	if level < LevelLow || level > LevelHigh {
+		return nil, fmt.Errorf("qrcode: unknown level %d", level)
+	}
+
+	version := 0
+
+	for v := 1; v <= 40; v++ {
+		if 4+countBits(v)+len(data)*8 <= dataCodewords(v, level)*8 {
+			version = v
+			break
+		}
+	}
+
+	if version == 0 {
+		return nil, fmt.Errorf("%w: %d bytes", ErrTooLong, len(data))
+	}
+
+	capacity := dataCodewords(version, level) * 8
+
+	var bits bitBuffer
+
+	bits.append(0b0100, 4) // Byte mode
+	bits.append(len(data), countBits(version))
+
+	for _, b := range data {
+		bits.append(int(b), 8)
+	}
+
+	bits.append(0, min(4, capacity-len(bits)))
+	bits.append(0, (8-len(bits)%8)%8)
+
+	for pad := 0xEC; len(bits) < capacity; pad ^= 0xEC ^ 0x11 {
+		bits.append(pad, 8)
+	}
+
+	codewords := make([]byte, len(bits)/8)
+	for i, bit := range bits {
+		if bit {
+			codewords[i/8] |= 1 << (7 - i%8)
+		}
+	}
+
+	m := newMatrix(version)
+	m.drawFunctionPatterns()
+	m.drawCodewords(addErrorCorrection(codewords, version, level))
+
+	// Keep the mask with the lowest penalty
+	best, bestPenalty := 0, -1
+
+	for mask := 0; mask < 8; mask++ {
+		m.applyMask(mask)
+		m.drawFormatBits(level, mask)
+
+		if p := m.penalty(); bestPenalty < 0 || p < bestPenalty {
+			best, bestPenalty = mask, p
+		}
+
+		m.applyMask(mask) // XOR again to undo
+	}
+
+	m.applyMask(best)
+	m.drawFormatBits(level, best)
+
+	return &Code{Size: m.size, Version: version, Level: level, modules: m.dark}, nil
+}
+
+type bitBuffer []bool
+
+func (b *bitBuffer) append(value, n int) {
+	for i := n - 1; i >= 0; i-- {
+		*b = append(*b, (value>>i)&1 != 0)
+	}
+}
+
+// countBits is the width of the byte mode character count field.
+func countBits(version int) int {
+	if version <= 9 {
+		return 8
+	}
+
+	return 16
+}
+
+var eccCodewordsPerBlock = [4][41]int{
+	{-1, 7, 10, 15, 20, 26, 18, 20, 24, 30, 18, 20, 24, 26, 30, 22, 24, 28, 30, 28, 28, 28, 28, 30, 30, 26, 28, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30},
+	{-1, 10, 16, 26, 18, 24, 16, 18, 22, 22, 26, 30, 22, 22, 24, 24, 28, 28, 26, 26, 26, 26, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28},
+	{-1, 13, 22, 18, 26, 18, 24, 18, 22, 20, 24, 28, 26, 24, 20, 30, 24, 28, 28, 26, 30, 28, 30, 30, 30, 30, 28, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30},
+	{-1, 17, 28, 22, 16, 22, 28, 26, 26, 24, 28, 24, 28, 22, 24, 24, 30, 28, 28, 26, 28, 30, 24, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30},
+}
+
+var eccBlocks = [4][41]int{
+	{-1, 1, 1, 1, 1, 1, 2, 2, 2, 2, 4, 4, 4, 4, 4, 6, 6, 6, 6, 7, 8, 8, 9, 9, 10, 12, 12, 12, 13, 14, 15, 16, 17, 18, 19, 19, 20, 21, 22, 24, 25},
+	{-1, 1, 1, 1, 2, 2, 4, 4, 4, 5, 5, 5, 8, 9, 9, 10, 10, 11, 13, 14, 16, 17, 17, 18, 20, 21, 23, 25, 26, 28, 29, 31, 33, 35, 37, 38, 40, 43, 45, 47, 49},
+	{-1, 1, 1, 2, 2, 4, 4, 6, 6, 8, 8, 8, 10, 12, 16, 12, 17, 16, 18, 21, 20, 23, 23, 25, 27, 29, 34, 34, 35, 38, 40, 43, 45, 48, 51, 53, 56, 59, 62, 65, 68},
+	{-1, 1, 1, 2, 4, 4, 4, 5, 6, 8, 8, 11, 11, 16, 16, 18, 16, 19, 21, 25, 25, 25, 34, 30, 32, 35, 37, 40, 42, 45, 48, 51, 54, 57, 60, 63, 66, 70, 74, 77, 81},
+}
+
+// rawDataModules counts the modules left for codewords after the function patterns.
+func rawDataModules(version int) int {
+	n := (16*version+128)*version + 64
+
+	if version >= 2 {
+		align := version/7 + 2
+		n -= (25*align-10)*align - 55
+
+		if version >= 7 {
+			n -= 36
+		}
+	}
+
+	return n
+}
+
+func dataCodewords(version int, level Level) int {
+	return rawDataModules(version)/8 - eccCodewordsPerBlock[level][version]*eccBlocks[level][version]
+}
+
+// addErrorCorrection splits data into blocks, appends the Reed-Solomon codewords of
+// each and interleaves the result.
+func addErrorCorrection(data []byte, version int, level Level) []byte {
+	numBlocks := eccBlocks[level][version]
+	eccLen := eccCodewordsPerBlock[level][version]
+	rawCodewords := rawDataModules(version) / 8
+	numShort := numBlocks - rawCodewords%numBlocks
+	shortLen := rawCodewords / numBlocks
+
+	divisor := rsDivisor(eccLen)
+	blocks := make([][]byte, numBlocks)
+
+	for i, k := 0, 0; i < numBlocks; i++ {
+		n := shortLen - eccLen
+		if i >= numShort {
+			n++
+		}
+
+		block := append([]byte(nil), data[k:k+n]...)
+		k += n
+
+		ecc := rsRemainder(block, divisor)
+
+		// Short blocks get a gap so every block interleaves at the same positions
+		if i < numShort {
+			block = append(block, 0)
+		}
+
+		blocks[i] = append(block, ecc...)
+	}
+
+	result := make([]byte, 0, rawCodewords)
+
+	for i := range blocks[0] {
+		for j, block := range blocks {
+			if i != shortLen-eccLen || j >= numShort {
+				result = append(result, block[i])
+			}
+		}
+	}
+
+	return result
+}
+
+// rsMultiply multiplies in GF(2^8) modulo x^8 + x^4 + x^3 + x^2 + 1.
+func rsMultiply(x, y byte) byte {
+	var z int
+
+	for i := 7; i >= 0; i-- {
+		z = (z << 1) ^ ((z >> 7) * 0x11D)
+		z ^= int((y>>i)&1) * int(x)
+	}
+
+	return byte(z)
+}
+
+func rsDivisor(degree int) []byte {
+	result := make([]byte, degree)
+	result[degree-1] = 1
+
+	root := byte(1)
+
+	for i := 0; i < degree; i++ {
+		for j := range result {
+			result[j] = rsMultiply(result[j], root)
+			if j+1 < len(result) {
+				result[j] ^= result[j+1]
+			}
+		}
+
+		root = rsMultiply(root, 0x02)
+	}
+
+	return result
+}
+
+func rsRemainder(data, divisor []byte) []byte {
+	result := make([]byte, len(divisor))
+
+	for _, b := range data {
+		factor := b ^ result[0]
+		copy(result, result[1:])
+		result[len(result)-1] = 0
+
+		for i, d := range divisor {
+			result[i] ^= rsMultiply(d, factor)
+		}
+	}
+
+	return result
+}
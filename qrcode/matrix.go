@@ -0,0 +1,345 @@
+package qrcode
+
+type matrix struct {
+	size     int
+	version  int
+	dark     []bool
+	function []bool // Modules reserved for function patterns, excluded from masking
+}
+
+func newMatrix(version int) *matrix {
+	size := version*4 + 17
+
+	return &matrix{
+		size:     size,
+		version:  version,
+		dark:     make([]bool, size*size),
+		function: make([]bool, size*size),
+	}
+}
+
+func (m *matrix) set(x, y int, dark bool) {
+	m.dark[y*m.size+x] = dark
+	m.function[y*m.size+x] = true
+}
+
+func (m *matrix) drawFunctionPatterns() {
+	// Timing patterns
+	for i := 0; i < m.size; i++ {
+		m.set(6, i, i%2 == 0)
+		m.set(i, 6, i%2 == 0)
+	}
+
+	m.drawFinder(3, 3)
+	m.drawFinder(m.size-4, 3)
+	m.drawFinder(3, m.size-4)
+
+	positions := m.alignmentPositions()
+	last := len(positions) - 1
+
+	for i, x := range positions {
+		for j, y := range positions {
+			// Skip the three corners holding finder patterns
+			if (i == 0 && j == 0) || (i == 0 && j == last) || (i == last && j == 0) {
+				continue
+			}
+
+			m.drawAlignment(x, y)
+		}
+	}
+
+	// Reserve the format areas with a dummy mask; drawFormatBits overwrites them
+	m.drawFormatBits(LevelLow, 0)
+	m.drawVersion()
+}
+
+// drawFinder draws a finder pattern and its separator centered at x, y.
+func (m *matrix) drawFinder(x, y int) {
+	for dy := -4; dy <= 4; dy++ {
+		for dx := -4; dx <= 4; dx++ {
+			xx, yy := x+dx, y+dy
+			if xx < 0 || yy < 0 || xx >= m.size || yy >= m.size {
+				continue
+			}
+
+			dist := max(abs(dx), abs(dy))
+			m.set(xx, yy, dist != 2 && dist != 4)
+		}
+	}
+}
+
+func (m *matrix) drawAlignment(x, y int) {
+	for dy := -2; dy <= 2; dy++ {
+		for dx := -2; dx <= 2; dx++ {
+			m.set(x+dx, y+dy, max(abs(dx), abs(dy)) != 1)
+		}
+	}
+}
+
+func (m *matrix) alignmentPositions() []int {
+	if m.version == 1 {
+		return nil
+	}
+
+	count := m.version/7 + 2
+
+	step := 26
+	if m.version != 32 {
+		step = (m.version*4 + count*2 + 1) / (count*2 - 2) * 2
+	}
+
+	positions := make([]int, count)
+	positions[0] = 6
+
+	for i, pos := count-1, m.size-7; i >= 1; i, pos = i-1, pos-step {
+		positions[i] = pos
+	}
+
+	return positions
+}
+
+var levelFormatBits = [4]int{1, 0, 3, 2}
+
+func (m *matrix) drawFormatBits(level Level, mask int) {
+	data := levelFormatBits[level]<<3 | mask
+
+	rem := data
+	for i := 0; i < 10; i++ {
+		rem = (rem << 1) ^ ((rem >> 9) * 0x537)
+	}
+
+	bits := (data<<10 | rem) ^ 0x5412
+
+	bit := func(i int) bool { return (bits>>i)&1 != 0 }
+
+	// First copy, around the top left finder
+	for i := 0; i <= 5; i++ {
+		m.set(8, i, bit(i))
+	}
+
+	m.set(8, 7, bit(6))
+	m.set(8, 8, bit(7))
+	m.set(7, 8, bit(8))
+
+	for i := 9; i < 15; i++ {
+		m.set(14-i, 8, bit(i))
+	}
+
+	// Second copy, split between the other two finders
+	for i := 0; i < 8; i++ {
+		m.set(m.size-1-i, 8, bit(i))
+	}
+
+	for i := 8; i < 15; i++ {
+		m.set(8, m.size-15+i, bit(i))
+	}
+
+	m.set(8, m.size-8, true) // Always dark
+}
+
+func (m *matrix) drawVersion() {
+	if m.version < 7 {
+		return
+	}
+
+	rem := m.version
+	for i := 0; i < 12; i++ {
+		rem = (rem << 1) ^ ((rem >> 11) * 0x1F25)
+	}
+
+	bits := m.version<<12 | rem
+
+	for i := 0; i < 18; i++ {
+		dark := (bits>>i)&1 != 0
+		a, b := m.size-11+i%3, i/3
+		m.set(a, b, dark)
+		m.set(b, a, dark)
+	}
+}
+
+// drawCodewords fills the non-function modules in the zigzag order of the standard.
+func (m *matrix) drawCodewords(data []byte) {
+	i := 0
+
+	for right := m.size - 1; right >= 1; right -= 2 {
+		if right == 6 {
+			right = 5 // Skip the vertical timing pattern
+		}
+
+		for vert := 0; vert < m.size; vert++ {
+			for j := 0; j < 2; j++ {
+				x := right - j
+
+				y := vert
+				if (right+1)&2 == 0 {
+					y = m.size - 1 - vert // Upward column
+				}
+
+				if m.function[y*m.size+x] || i >= len(data)*8 {
+					continue
+				}
+
+				m.dark[y*m.size+x] = (data[i>>3]>>(7-(i&7)))&1 != 0
+				i++
+			}
+		}
+	}
+}
+
+// applyMask XORs the data modules with mask; applying it twice restores them.
+func (m *matrix) applyMask(mask int) {
+	for y := 0; y < m.size; y++ {
+		for x := 0; x < m.size; x++ {
+			var invert bool
+
+			switch mask {
+			case 0:
+				invert = (x+y)%2 == 0
+			case 1:
+				invert = y%2 == 0
+			case 2:
+				invert = x%3 == 0
+			case 3:
+				invert = (x+y)%3 == 0
+			case 4:
+				invert = (x/3+y/2)%2 == 0
+			case 5:
+				invert = x*y%2+x*y%3 == 0
+			case 6:
+				invert = (x*y%2+x*y%3)%2 == 0
+			case 7:
+				invert = ((x+y)%2+x*y%3)%2 == 0
+			}
+
+			if invert && !m.function[y*m.size+x] {
+				m.dark[y*m.size+x] = !m.dark[y*m.size+x]
+			}
+		}
+	}
+}
+
+// penalty scores the symbol with the four rules of the standard; lower is better.
+func (m *matrix) penalty() int {
+	const (
+		n1 = 3
+		n2 = 3
+		n3 = 40
+		n4 = 10
+	)
+
+	result := 0
+	at := func(x, y int) bool { return m.dark[y*m.size+x] }
+
+	// Runs of five or more same-colored modules and finder-like patterns, in rows and columns
+	for pass := 0; pass < 2; pass++ {
+		for a := 0; a < m.size; a++ {
+			get := func(b int) bool {
+				if pass == 0 {
+					return at(b, a)
+				}
+
+				return at(a, b)
+			}
+
+			run := 0
+			color := false
+			history := make([]int, 7)
+
+			for b := 0; b < m.size; b++ {
+				if get(b) == color {
+					run++
+					if run == 5 {
+						result += n1
+					} else if run > 5 {
+						result++
+					}
+
+					continue
+				}
+
+				m.addHistory(history, run)
+
+				if !color {
+					result += m.finderLike(history) * n3
+				}
+
+				color = get(b)
+				run = 1
+			}
+
+			result += m.terminateHistory(color, run, history) * n3
+		}
+	}
+
+	// 2x2 blocks of the same color
+	for y := 0; y < m.size-1; y++ {
+		for x := 0; x < m.size-1; x++ {
+			c := at(x, y)
+			if c == at(x+1, y) && c == at(x, y+1) && c == at(x+1, y+1) {
+				result += n2
+			}
+		}
+	}
+
+	// Balance of dark and light modules
+	dark := 0
+
+	for _, d := range m.dark {
+		if d {
+			dark++
+		}
+	}
+
+	total := m.size * m.size
+	k := (abs(dark*20-total*10)+total-1)/total - 1
+	result += k * n4
+
+	return result
+}
+
+// addHistory records a completed run, treating the light border before the first run as part of it.
+func (m *matrix) addHistory(history []int, run int) {
+	if history[0] == 0 {
+		run += m.size
+	}
+
+	copy(history[1:], history[:len(history)-1])
+	history[0] = run
+}
+
+// finderLike counts 1:1:3:1:1 patterns with four light modules on either side.
+func (m *matrix) finderLike(h []int) int {
+	n := h[1]
+	core := n > 0 && h[2] == n && h[3] == n*3 && h[4] == n && h[5] == n
+
+	count := 0
+	if core && h[0] >= n*4 && h[6] >= n {
+		count++
+	}
+
+	if core && h[6] >= n*4 && h[0] >= n {
+		count++
+	}
+
+	return count
+}
+
+func (m *matrix) terminateHistory(color bool, run int, history []int) int {
+	if color {
+		m.addHistory(history, run)
+		run = 0
+	}
+
+	run += m.size // Light border after the last run
+	m.addHistory(history, run)
+
+	return m.finderLike(history)
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+
+	return n
+}
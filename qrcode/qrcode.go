@@ -0,0 +1,118 @@
+// Package qrcode encodes text as a QR code and renders it as an inline SVG.
+package qrcode
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/plainkit/html"
+)
+
+// DefaultBorder is the quiet zone, in modules, required around the symbol by the standard.
+const DefaultBorder = 4
+
+type Props struct {
+	ID     string
+	Class  string
+	Attrs  []html.Global
+	Value  string // Text to encode, such as an otpauth:// URI
+	Level  Level
+	Border int    // Quiet zone in modules; defaults to DefaultBorder, negative for none
+	Label  string // Accessible name; defaults to "QR code"
+}
+
+func (p Props) border() int {
+	switch {
+	case p.Border < 0:
+		return 0
+	case p.Border == 0:
+		return DefaultBorder
+	default:
+		return p.Border
+	}
+}
+
+// Apply implements the html.SvgArg interface for Props.
+func (p Props) Apply(attrs *html.SvgAttrs, children *[]html.Component) {
+	label := p.Label
+	if label == "" {
+		label = "QR code"
+	}
+
+	args := []html.SvgArg{
+		html.AClass(html.ClassMerge("size-48 rounded-lg bg-white text-black", p.Class)),
+		html.AXmlns("http://www.w3.org/2000/svg"),
+		html.ACustom("role", "img"),
+		html.AAria("label", label),
+		html.AShapeRendering("crispEdges"),
+		html.AData("pui-qrcode", ""),
+	}
+
+	if p.ID != "" {
+		args = append(args, html.AId(p.ID))
+	}
+
+	for _, a := range p.Attrs {
+		args = append(args, a)
+	}
+
+	code, err := Encode([]byte(p.Value), p.Level)
+	if err != nil {
+		// Keep the layout stable; the empty symbol tells the user to use the text fallback
+		args = append(args, html.AViewBox("0 0 1 1"), html.AData("pui-qrcode-error", err.Error()))
+	} else {
+		side := strconv.Itoa(code.Size + 2*p.border())
+		args = append(args,
+			html.AViewBox("0 0 "+side+" "+side),
+			html.SvgPath(html.AFill("currentColor"), html.AD(Path(code, p.border()))),
+		)
+	}
+
+	for _, a := range args {
+		a.Apply(attrs, children)
+	}
+}
+
+// QRCode renders Props.Value as an SVG QR code, dark modules in currentColor on white.
+func QRCode(args ...html.SvgArg) html.Node {
+	var (
+		props Props
+		rest  []html.SvgArg
+	)
+
+	for _, a := range args {
+		if v, ok := a.(Props); ok {
+			props = v
+		} else {
+			rest = append(rest, a)
+		}
+	}
+
+	return html.Svg(append([]html.SvgArg{props}, rest...)...)
+}
+
+// Path returns SVG path data drawing the dark modules of c, one unit per module, offset
+// by border modules. Horizontal runs are merged to keep the path short.
+func Path(c *Code, border int) string {
+	var sb strings.Builder
+
+	for y := 0; y < c.Size; y++ {
+		for x := 0; x < c.Size; {
+			if !c.Dark(x, y) {
+				x++
+				continue
+			}
+
+			run := 1
+			for c.Dark(x+run, y) {
+				run++
+			}
+
+			n := strconv.Itoa(run)
+			sb.WriteString("M" + strconv.Itoa(x+border) + " " + strconv.Itoa(y+border) + "h" + n + "v1h-" + n + "z")
+			x += run
+		}
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,163 @@
+package qrcode
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+// The golden symbols in testdata were produced by an independent encoder with the mask
+// this one picks, one row per line with # for dark modules.
+var goldens = []struct {
+	name    string
+	data    string
+	level   Level
+	version int
+	mask    int
+}{
+	{name: "1-L", data: "HELLO WORLD", level: LevelLow, version: 1, mask: 0},
+	{name: "7-M", data: strings.Repeat("0123456789", 11), level: LevelMedium, version: 7, mask: 2},
+	{name: "8-M", data: strings.Repeat("0123456789", 14), level: LevelMedium, version: 8, mask: 2},
+}
+
+func encode(t *testing.T, data string, level Level) *Code {
+	t.Helper()
+
+	c, err := Encode([]byte(data), level)
+	if err != nil {
+		t.Fatalf("Encode(%q) error: %v", data, err)
+	}
+
+	return c
+}
+
+func TestEncodeGolden(t *testing.T) {
+	for _, tt := range goldens {
+		t.Run(tt.name, func(t *testing.T) {
+			c := encode(t, tt.data, tt.level)
+
+			if c.Version != tt.version || c.Size != 17+4*tt.version {
+				t.Fatalf("Version, Size = %d, %d, want %d, %d", c.Version, c.Size, tt.version, 17+4*tt.version)
+			}
+
+			want, err := os.ReadFile("testdata/" + tt.name + ".txt")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			rows := strings.Split(strings.TrimSpace(string(want)), "\n")
+			if len(rows) != c.Size {
+				t.Fatalf("golden has %d rows, want %d", len(rows), c.Size)
+			}
+
+			for y, row := range rows {
+				var got strings.Builder
+				for x := range c.Size {
+					if c.Dark(x, y) {
+						got.WriteByte('#')
+					} else {
+						got.WriteByte('.')
+					}
+				}
+
+				if got.String() != row {
+					t.Errorf("row %d = %s, want %s", y, got.String(), row)
+				}
+			}
+		})
+	}
+}
+
+func TestFormatBits(t *testing.T) {
+	for _, tt := range goldens {
+		t.Run(tt.name, func(t *testing.T) {
+			c := encode(t, tt.data, tt.level)
+
+			read := func(at func(i int) (int, int)) int {
+				bits := 0
+				for i := range 15 {
+					if x, y := at(i); c.Dark(x, y) {
+						bits |= 1 << i
+					}
+				}
+
+				return bits
+			}
+
+			first := read(func(i int) (int, int) {
+				switch {
+				case i < 6:
+					return 8, i
+				case i < 8:
+					return 8, i + 1
+				case i == 8:
+					return 7, 8
+				default:
+					return 14 - i, 8
+				}
+			})
+			second := read(func(i int) (int, int) {
+				if i < 8 {
+					return c.Size - 1 - i, 8
+				}
+
+				return 8, c.Size - 15 + i
+			})
+
+			if first != second {
+				t.Fatalf("format copies differ: %015b and %015b", first, second)
+			}
+
+			bits := first ^ 0x5412
+			if rem := bchRemainder(bits, 0x537, 10); rem != 0 {
+				t.Errorf("format bits %015b fail the BCH check", first)
+			}
+
+			if level, mask := bits>>13, bits>>10&7; level != levelFormatBits[tt.level] || mask != tt.mask {
+				t.Errorf("format encodes level bits %02b mask %d, want %02b mask %d", level, mask, levelFormatBits[tt.level], tt.mask)
+			}
+		})
+	}
+}
+
+// TestVersionBits checks both version blocks against the table in ISO/IEC 18004 Annex D.
+func TestVersionBits(t *testing.T) {
+	want := map[int]int{7: 0x07C94, 8: 0x085BC}
+
+	for _, tt := range goldens {
+		if tt.version < 7 {
+			continue
+		}
+
+		t.Run(tt.name, func(t *testing.T) {
+			c := encode(t, tt.data, tt.level)
+
+			var right, bottom int
+			for i := range 18 {
+				a, b := c.Size-11+i%3, i/3
+				if c.Dark(a, b) {
+					right |= 1 << i
+				}
+
+				if c.Dark(b, a) {
+					bottom |= 1 << i
+				}
+			}
+
+			if right != want[tt.version] || bottom != want[tt.version] {
+				t.Errorf("version bits = %05X and %05X, want %05X", right, bottom, want[tt.version])
+			}
+		})
+	}
+}
+
+// bchRemainder divides the polynomial bits by generator, of degree n, over GF(2).
+func bchRemainder(bits, generator, n int) int {
+	for i := 14; i >= n; i-- {
+		if bits>>i&1 != 0 {
+			bits ^= generator << (i - n)
+		}
+	}
+
+	return bits
+}
@@ -1,8 +1,12 @@
 package radio
 
 import (
+	"strconv"
+
 	"github.com/plainkit/html"
+	"github.com/plainkit/ui/internal/randomid"
 	"github.com/plainkit/ui/internal/styles"
+	"github.com/plainkit/ui/option"
 )
 
 type Props struct {
@@ -17,6 +21,16 @@ type Props struct {
 	Checked  bool
 }
 
+type GroupProps struct {
+	ID       string
+	Class    string
+	Attrs    []html.Global
+	Name     string
+	Form     string
+	Disabled bool
+	Required bool
+}
+
 func inputArgsFromProps(baseClass string, extra ...string) func(p Props) []html.InputArg {
 	return func(p Props) []html.InputArg {
 		className := html.ClassMerge(
@@ -101,3 +115,91 @@ func Radio(args ...html.InputArg) html.Node {
 
 	return html.Input(append([]html.InputArg{props}, rest...)...)
 }
+
+// Group renders a labelled radio group for a typed option list.
+// Values are encoded with the list codec; use List.Parse on submit.
+func Group[T any](props GroupProps, list option.List[T], checked ...T) html.Node {
+	id := props.ID
+	if id == "" {
+		id = randomid.New("radio-group")
+	}
+
+	isChecked := list.Selected(checked...)
+
+	args := []html.DivArg{
+		html.AId(id),
+		html.AClass(html.ClassMerge("grid gap-3", props.Class)),
+		html.ACustom("role", "radiogroup"),
+	}
+
+	if props.Required {
+		args = append(args, html.AAria("required", "true"))
+	}
+
+	for _, a := range props.Attrs {
+		args = append(args, a)
+	}
+
+	index := 0
+
+	for _, g := range list.Groups() {
+		if g.Name != "" {
+			args = append(args, html.Span(
+				html.AClass(styles.SubHeading("pt-2 text-xs uppercase tracking-wide text-muted-foreground/70")),
+				html.Text(g.Name),
+			))
+		}
+
+		for _, o := range g.Options {
+			value := list.Encode(o.Value)
+			inputID := id + "-" + strconv.Itoa(index)
+			index++
+
+			text := []html.SpanArg{
+				html.AClass("grid gap-0.5"),
+				html.Span(html.AClass("text-sm font-medium text-foreground"), html.Text(list.Label(o))),
+			}
+
+			if o.Description != "" {
+				text = append(text, html.Span(html.AClass(styles.SubtleText("text-xs")), html.Text(o.Description)))
+			}
+
+			labelArgs := []html.LabelArg{
+				html.AFor(inputID),
+				html.AClass(html.ClassMerge(
+					"relative flex cursor-pointer items-start gap-3",
+					func() string {
+						if o.Disabled || props.Disabled {
+							return "cursor-not-allowed opacity-60"
+						}
+
+						return ""
+					}(),
+				)),
+				Radio(Props{
+					ID:       inputID,
+					Class:    "relative mt-0.5",
+					Name:     props.Name,
+					Value:    value,
+					Form:     props.Form,
+					Checked:  isChecked[value],
+					Disabled: o.Disabled || props.Disabled,
+					Required: props.Required,
+				}),
+			}
+
+			if o.Icon != nil {
+				labelArgs = append(labelArgs, html.Span(
+					html.AClass("mt-0.5 text-muted-foreground [&_svg]:size-4"),
+					html.Child(o.Icon),
+				))
+			}
+
+			labelArgs = append(labelArgs, html.Span(text...))
+
+			args = append(args, html.Label(labelArgs...))
+		}
+	}
+
+	return html.Div(args...)
+}
@@ -0,0 +1,114 @@
+(function () {
+  "use strict";
+
+  const timers = new WeakMap();
+  const controllers = new WeakMap();
+
+  function isRemote(content) {
+    return !!content && content.hasAttribute("data-pui-selectbox-remote-url");
+  }
+
+  function showState(content, state) {
+    content.querySelectorAll("[data-pui-selectbox-state]").forEach((el) => {
+      const active = el.getAttribute("data-pui-selectbox-state") === state;
+      el.classList.toggle("hidden", !active);
+      el.classList.toggle("flex", active);
+    });
+  }
+
+  function search(content, query, immediate) {
+    const url = content.getAttribute("data-pui-selectbox-remote-url");
+    const list = content.querySelector("[data-pui-selectbox-list]");
+    if (!url || !list) return;
+
+    clearTimeout(timers.get(content));
+    controllers.get(content)?.abort();
+
+    const minChars =
+      parseInt(content.getAttribute("data-pui-selectbox-remote-min-chars")) ||
+      0;
+    if (query.trim().length < minChars) {
+      list.innerHTML = "";
+      showState(content, null);
+      return;
+    }
+
+    const delay = immediate
+      ? 0
+      : parseInt(content.getAttribute("data-pui-selectbox-remote-debounce")) ||
+        250;
+
+    timers.set(
+      content,
+      setTimeout(() => {
+        const controller = new AbortController();
+        controllers.set(content, controller);
+        showState(content, "loading");
+
+        const target = new URL(url, window.location.href);
+        target.searchParams.set("q", query.trim());
+        (window.tui?.selectbox?.values(content.id) || []).forEach((value) => {
+          target.searchParams.append("selected", value);
+        });
+
+        fetch(target, {
+          signal: controller.signal,
+          headers: { Accept: "text/html" },
+        })
+          .then((res) => {
+            if (!res.ok) throw new Error("HTTP " + res.status);
+            return res.text();
+          })
+          .then((markup) => {
+            list.innerHTML = markup;
+
+            // selectbox.js marks the selected items and offers the create entry
+            content.dispatchEvent(
+              new CustomEvent("pui-selectbox:load", {
+                bubbles: true,
+                detail: { query: query },
+              }),
+            );
+
+            const empty =
+              !content.querySelector("[data-pui-selectbox-value]") &&
+              !content.querySelector("[data-pui-selectbox-create]:not(.hidden)");
+            showState(content, empty ? "empty" : null);
+          })
+          .catch((err) => {
+            if (err.name === "AbortError") return;
+            list.innerHTML = "";
+            showState(content, "error");
+          });
+      }, delay),
+    );
+  }
+
+  // Load the first results once the popover has been opened by popover.js
+  document.addEventListener("click", (e) => {
+    const trigger = e.target.closest("[data-pui-selectbox-content-id]");
+    if (!trigger) return;
+
+    const content = document.getElementById(
+      trigger.getAttribute("data-pui-selectbox-content-id"),
+    );
+    if (!isRemote(content)) return;
+
+    setTimeout(() => {
+      if (content.getAttribute("data-pui-popover-open") !== "true") return;
+      if (content.hasAttribute("data-pui-selectbox-loaded")) return;
+
+      content.setAttribute("data-pui-selectbox-loaded", "");
+      const input = content.querySelector("[data-pui-selectbox-search]");
+      search(content, input ? input.value : "", true);
+    }, 20);
+  });
+
+  document.addEventListener("input", (e) => {
+    const input = e.target.closest("[data-pui-selectbox-search]");
+    if (!input) return;
+
+    const content = input.closest("[data-pui-selectbox-content]");
+    if (isRemote(content)) search(content, input.value, false);
+  });
+})();
@@ -1,16 +1,22 @@
 package selectbox
 
 import (
-	"crypto/rand"
-	"encoding/hex"
+	_ "embed"
+	"encoding/json"
+	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/plainkit/html"
 	"github.com/plainkit/icons/lucide"
 	"github.com/plainkit/ui/button"
 	"github.com/plainkit/ui/input"
+	"github.com/plainkit/ui/internal/assets"
+	"github.com/plainkit/ui/internal/randomid"
 	"github.com/plainkit/ui/internal/styles"
+	"github.com/plainkit/ui/option"
 	"github.com/plainkit/ui/popover"
+	"github.com/plainkit/ui/virtual"
 )
 
 type Props struct {
@@ -32,6 +38,7 @@ type TriggerProps struct {
 	Multiple          bool
 	ShowPills         bool
 	SelectedCountText string
+	Selected          []option.Option[string] // Initially selected options, needed when items are loaded remotely
 }
 
 type ValueProps struct {
@@ -48,6 +55,15 @@ type ContentProps struct {
 	Attrs             []html.Global
 	NoSearch          bool
 	SearchPlaceholder string
+	RemoteURL         string // Endpoint returning rendered items for the search text (enables remote mode)
+	RemoteDebounce    int    // Milliseconds to wait after typing before querying RemoteURL
+	RemoteMinChars    int    // Minimum search length before RemoteURL is queried
+	LoadingText       string
+	EmptyText         string
+	ErrorText         string
+	Virtual           virtual.Props // Only mount the items scrolled into view; not combined with RemoteURL
+	Creatable         bool          // Offer the search text as a new value when no item matches it
+	CreateText        string        // Label of the create entry, "{q}" is replaced by the search text
 }
 
 type GroupProps struct {
@@ -62,6 +78,13 @@ type LabelProps struct {
 	Attrs []html.Global
 }
 
+const (
+	// QueryParam carries the search text sent to a remote endpoint.
+	QueryParam = "q"
+	// SelectedParam carries the currently selected values sent to a remote endpoint.
+	SelectedParam = "selected"
+)
+
 type ItemProps struct {
 	ID       string
 	Class    string
@@ -104,7 +127,7 @@ func spanArgsFromProps(baseClass string, extra ...string) func(p ValueProps) []h
 func (p Props) ApplyDiv(attrs *html.DivAttrs, children *[]html.Component) {
 	wrapperID := p.ID
 	if wrapperID == "" {
-		wrapperID = randomID("selectbox")
+		wrapperID = randomid.New("selectbox")
 	}
 
 	args := divArgsFromProps("select-container relative w-full space-y-2")(p)
@@ -162,7 +185,9 @@ func (p LabelProps) ApplySpan(attrs *html.SpanAttrs, children *[]html.Component)
 	}
 }
 
-// SelectBox renders a select box container
+// SelectBox renders a select box container. It loads the client runtime that selects
+// items, fills the hidden inputs, filters on search and moves focus with the arrow keys;
+// boxes whose Content sets RemoteURL also load the remote search runtime.
 func SelectBox(args ...html.DivArg) html.Node {
 	var (
 		props Props
@@ -177,13 +202,15 @@ func SelectBox(args ...html.DivArg) html.Node {
 		}
 	}
 
+	rest = append(rest, assets.Script("ui-selectbox", selectboxJS), html.Child(virtual.Assets()))
+
 	return html.Div(append([]html.DivArg{props}, rest...)...)
 }
 
 // Trigger creates a select box trigger button
 func Trigger(props TriggerProps, contentID string, args ...html.Node) html.Node {
 	if contentID == "" {
-		contentID = randomID("selectbox-content")
+		contentID = randomid.New("selectbox-content")
 	}
 
 	if props.ShowPills {
@@ -208,12 +235,27 @@ func Trigger(props TriggerProps, contentID string, args ...html.Node) html.Node
 		inputArgs = append(inputArgs, attr)
 	}
 
+	if len(props.Selected) > 0 {
+		inputArgs = append(inputArgs, html.AValue(props.Selected[0].Value))
+	}
+
 	hiddenInput := html.Input(inputArgs...)
 
 	// Button content with children and chevron
-	buttonContent := make([]html.ButtonArg, 0, len(args)+2)
+	buttonContent := make([]html.ButtonArg, 0, len(args)+len(props.Selected)+2)
 
 	buttonContent = append(buttonContent, hiddenInput)
+
+	// Additional selections of a multiple select submit as repeated fields
+	for i := 1; i < len(props.Selected) && props.Multiple; i++ {
+		buttonContent = append(buttonContent, html.Input(
+			html.AType("hidden"),
+			html.AName(props.Name),
+			html.AValue(props.Selected[i].Value),
+			html.AData("pui-selectbox-extra", ""),
+		))
+	}
+
 	for _, arg := range args {
 		buttonContent = append(buttonContent, arg)
 	}
@@ -258,6 +300,7 @@ func Trigger(props TriggerProps, contentID string, args ...html.Node) html.Node
 					html.AData("pui-selectbox-multiple", strconv.FormatBool(props.Multiple)),
 					html.AData("pui-selectbox-show-pills", strconv.FormatBool(props.ShowPills)),
 					html.AData("pui-selectbox-selected-count-text", props.SelectedCountText),
+					html.AData("pui-selectbox-labels", selectedLabels(props.Selected)),
 					html.ATabindex(0),
 					func() html.Global {
 						if props.Required {
@@ -305,17 +348,23 @@ func Value(args ...html.SpanArg) html.Node {
 func Content(props ContentProps, args ...html.DivArg) html.Node {
 	contentID := props.ID
 	if contentID == "" {
-		contentID = randomID("selectbox-content")
+		contentID = randomid.New("selectbox-content")
 	}
 
 	contentArgs := []html.DivArg{
 		html.AClass("max-h-[300px] overflow-y-auto"),
+		html.AData("pui-selectbox-list", ""),
+	}
+
+	if props.Virtual.Enabled() && props.RemoteURL == "" {
+		contentArgs = append(contentArgs, virtualList(props.Virtual, args)...)
+	} else {
+		contentArgs = append(contentArgs, args...)
 	}
-	contentArgs = append(contentArgs, args...)
 
 	var popoverContent []html.DivArg
 
-	if !props.NoSearch {
+	if !props.NoSearch || props.RemoteURL != "" || props.Creatable {
 		searchPlaceholder := "Search..."
 		if props.SearchPlaceholder != "" {
 			searchPlaceholder = props.SearchPlaceholder
@@ -335,6 +384,7 @@ func Content(props ContentProps, args ...html.DivArg) html.Node {
 					Placeholder: searchPlaceholder,
 					Attrs: []html.Global{
 						html.AData("pui-selectbox-search", ""),
+						html.ACustom("autocomplete", "off"),
 					},
 				}),
 			),
@@ -344,6 +394,50 @@ func Content(props ContentProps, args ...html.DivArg) html.Node {
 
 	popoverContent = append(popoverContent, html.Div(contentArgs...))
 
+	if props.Creatable {
+		popoverContent = append(popoverContent, html.Div(
+			html.AClass(html.ClassMerge(
+				styles.InteractiveGhost(
+					"hidden w-full cursor-pointer select-none items-center gap-3",
+					"rounded-lg px-3 py-2 text-sm",
+				),
+				"focus-visible:ring-0",
+			)),
+			html.ACustom("role", "option"),
+			html.ATabindex(0),
+			html.AData("pui-selectbox-create", orDefault(props.CreateText, `Create "{q}"`)),
+			lucide.Plus(html.AClass("size-4 shrink-0 text-muted-foreground")),
+			html.Span(html.AClass("truncate"), html.AData("pui-selectbox-create-label", "")),
+		))
+	}
+
+	attrs := []html.Global{
+		html.AAria("role", "listbox"),
+		html.ATabindex(-1),
+		html.AData("pui-selectbox-content", ""),
+	}
+
+	if props.RemoteURL != "" {
+		debounce := props.RemoteDebounce
+		if debounce <= 0 {
+			debounce = 250
+		}
+
+		attrs = append(attrs,
+			html.AData("pui-selectbox-remote-url", props.RemoteURL),
+			html.AData("pui-selectbox-remote-debounce", strconv.Itoa(debounce)),
+			html.AData("pui-selectbox-remote-min-chars", strconv.Itoa(props.RemoteMinChars)),
+		)
+
+		popoverContent = append(popoverContent,
+			remoteState("loading", orDefault(props.LoadingText, "Loading..."), lucide.LoaderCircle(html.AClass("size-4 animate-spin"))),
+			remoteState("empty", orDefault(props.EmptyText, "No results found.")),
+			remoteState("error", orDefault(props.ErrorText, "Could not load results.")),
+		)
+	}
+
+	attrs = append(attrs, props.Attrs...)
+
 	contentProps := popover.ContentProps{
 		ID:         contentID,
 		Placement:  popover.PlacementBottomStart,
@@ -356,15 +450,63 @@ func Content(props ContentProps, args ...html.DivArg) html.Node {
 			),
 			props.Class,
 		),
-		Attrs: []html.Global{
-			html.AAria("role", "listbox"),
-			html.ATabindex(-1),
-		},
+		Attrs: attrs,
+	}
+
+	if props.RemoteURL != "" {
+		popoverContent = append(popoverContent, assets.Script("ui-selectbox-remote", remoteJS))
 	}
 
 	return popover.Content(append([]html.DivArg{contentProps}, popoverContent...)...)
 }
 
+// virtualList moves the items into an inert source the client mounts from as the list scrolls.
+func virtualList(props virtual.Props, args []html.DivArg) []html.DivArg {
+	var (
+		items []html.DivArg
+		rest  []html.DivArg
+	)
+
+	for _, a := range args {
+		switch v := a.(type) {
+		case html.Node:
+			items = append(items, v)
+		case html.ChildOpt:
+			items = append(items, v)
+		default:
+			rest = append(rest, a)
+		}
+	}
+
+	if props.Selector == "" {
+		props.Selector = "[data-pui-selectbox-value]"
+	}
+
+	for _, attr := range virtual.Attrs(props) {
+		rest = append(rest, attr)
+	}
+
+	return append(rest, virtual.Source(items...))
+}
+
+func remoteState(state, text string, icon ...html.DivArg) html.Node {
+	args := []html.DivArg{
+		html.AClass(styles.SubtleText("hidden items-center justify-center gap-2 px-3 py-6 text-center")),
+		html.AData("pui-selectbox-state", state),
+	}
+
+	if state == "error" {
+		args = append(args, html.ACustom("role", "alert"))
+	} else {
+		args = append(args, html.AAria("live", "polite"))
+	}
+
+	args = append(args, icon...)
+	args = append(args, html.Span(html.Text(text)))
+
+	return html.Div(args...)
+}
+
 // Group creates a select box option group
 func Group(args ...html.DivArg) html.Node {
 	var (
@@ -449,6 +591,10 @@ func Item(props ItemProps, args ...html.SpanArg) html.Node {
 		html.ATabindex(0),
 	}
 
+	if props.Disabled {
+		divArgs = append(divArgs, html.AAria("disabled", "true"))
+	}
+
 	if props.ID != "" {
 		divArgs = append(divArgs, html.AId(props.ID))
 	}
@@ -482,11 +628,157 @@ func Item(props ItemProps, args ...html.SpanArg) html.Node {
 	return html.Div(divArgs...)
 }
 
-func randomID(prefix string) string {
-	buf := make([]byte, 4)
-	if _, err := rand.Read(buf); err != nil {
-		return prefix + "-id"
+// Items renders a list of string options as select box items, marking those whose value
+// is in selected. It is used to build the fragment returned to a remote Content.
+func Items(list option.List[string], selected ...string) html.Node {
+	return Options(list, selected...)
+}
+
+// OptionsProps configures a select box rendered from a typed option list.
+type OptionsProps struct {
+	Props       Props
+	Trigger     TriggerProps
+	Content     ContentProps
+	Placeholder string
+}
+
+// FromOptions renders a complete select box for a typed option list.
+// Values are encoded with the list codec; use List.Parse or List.ParseAll on submit.
+func FromOptions[T any](props OptionsProps, list option.List[T], selected ...T) html.Node {
+	contentID := props.Content.ID
+	if contentID == "" {
+		contentID = randomid.New("selectbox-content")
+	}
+
+	props.Content.ID = contentID
+	props.Props.Multiple = props.Props.Multiple || props.Trigger.Multiple
+	props.Trigger.Multiple = props.Props.Multiple
+
+	isSelected := list.Selected(selected...)
+	for _, o := range list.Options {
+		if value := list.Encode(o.Value); isSelected[value] {
+			props.Trigger.Selected = append(props.Trigger.Selected, option.Option[string]{Value: value, Label: list.Label(o)})
+		}
+	}
+
+	return SelectBox(
+		props.Props,
+		Trigger(props.Trigger, contentID, Value(ValueProps{Placeholder: props.Placeholder, Multiple: props.Props.Multiple})),
+		Content(props.Content, Options(list, selected...)),
+	)
+}
+
+// Options renders a typed option list as select box items, grouped by Option.Group.
+func Options[T any](list option.List[T], selected ...T) html.Node {
+	isSelected := list.Selected(selected...)
+
+	groups := list.Groups()
+	args := make([]html.DivArg, 0, len(groups))
+
+	for _, g := range groups {
+		items := make([]html.DivArg, 0, len(g.Options)+1)
+		if g.Name != "" {
+			items = append(items, Label(html.Text(g.Name)))
+		}
+
+		for _, o := range g.Options {
+			value := list.Encode(o.Value)
+			items = append(items, Item(ItemProps{
+				Value:    value,
+				Selected: isSelected[value],
+				Disabled: o.Disabled,
+				Attrs:    []html.Global{html.AData("pui-selectbox-label", list.Label(o))},
+			}, optionContent(list.Label(o), o.Description, o.Icon)...))
+		}
+
+		if g.Name == "" {
+			args = append(args, items...)
+
+			continue
+		}
+
+		args = append(args, Group(items...))
+	}
+
+	return html.Div(args...)
+}
+
+func optionContent(label, description string, icon html.Component) []html.SpanArg {
+	var args []html.SpanArg
+
+	if icon != nil {
+		args = append(args, html.Span(html.AClass("mr-2 inline-flex align-middle [&_svg]:size-4"), html.Child(icon)))
 	}
 
-	return prefix + "-" + hex.EncodeToString(buf)
+	args = append(args, html.Text(label))
+
+	if description != "" {
+		args = append(args, html.Span(
+			html.AClass("block text-xs text-muted-foreground/70"),
+			html.Text(description),
+		))
+	}
+
+	return args
+}
+
+// SearchFunc resolves remote options for the search text typed into a select box.
+type SearchFunc func(r *http.Request, query string) ([]option.Option[string], error)
+
+// RemoteHandler serves the item fragment requested by a Content with RemoteURL set.
+// Values already selected on the client are sent back so they stay marked as selected.
+func RemoteHandler(fn SearchFunc) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := strings.TrimSpace(r.URL.Query().Get(QueryParam))
+
+		options, err := fn(r, query)
+		if err != nil {
+			http.Error(w, "failed to load options", http.StatusInternalServerError)
+
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Header().Set("Cache-Control", "no-store")
+
+		_, _ = w.Write([]byte(html.Render(Items(option.New(option.String, options...), r.URL.Query()[SelectedParam]...))))
+	})
 }
+
+func selectedLabels(options []option.Option[string]) string {
+	if len(options) == 0 {
+		return ""
+	}
+
+	// An array keeps the pills in the order given; object keys would be reordered
+	type selected struct {
+		Value string `json:"value"`
+		Label string `json:"label"`
+	}
+
+	labels := make([]selected, 0, len(options))
+	for _, o := range options {
+		labels = append(labels, selected{Value: o.Value, Label: orDefault(o.Label, o.Value)})
+	}
+
+	data, err := json.Marshal(labels)
+	if err != nil {
+		return ""
+	}
+
+	return string(data)
+}
+
+func orDefault(value, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+
+	return value
+}
+
+//go:embed selectbox.js
+var selectboxJS string
+
+//go:embed remote.js
+var remoteJS string
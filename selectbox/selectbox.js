@@ -0,0 +1,489 @@
+(function () {
+  "use strict";
+
+  // Selected labels per trigger, kept so values survive item re-renders
+  const labels = new WeakMap();
+
+  function getTrigger(contentId) {
+    return document.querySelector(
+      '[data-pui-selectbox-content-id="' + CSS.escape(contentId) + '"]',
+    );
+  }
+
+  function getContent(trigger) {
+    return document.getElementById(
+      trigger.getAttribute("data-pui-selectbox-content-id"),
+    );
+  }
+
+  function isMultiple(trigger) {
+    return trigger.getAttribute("data-pui-selectbox-multiple") === "true";
+  }
+
+  function getLabels(trigger) {
+    let map = labels.get(trigger);
+    if (!map) {
+      map = new Map();
+      labels.set(trigger, map);
+    }
+
+    return map;
+  }
+
+  function itemLabel(item) {
+    const label = item.getAttribute("data-pui-selectbox-label");
+    if (label) return label;
+
+    const text = item.querySelector(".select-item-text");
+    return (text ? text.textContent : item.textContent).trim();
+  }
+
+  function allItems(content) {
+    return Array.from(content.querySelectorAll("[data-pui-selectbox-value]"));
+  }
+
+  // Items of a virtual list not currently mounted live in its source template
+  function sourceItems(content) {
+    const template = content.querySelector(
+      "[data-pui-virtual] > template[data-pui-virtual-source]",
+    );
+    if (!template) return allItems(content);
+
+    return Array.from(
+      template.content.querySelectorAll("[data-pui-selectbox-value]"),
+    );
+  }
+
+  function virtualList(content) {
+    return window.tui?.virtual
+      ? content.querySelector("[data-pui-virtual]")
+      : null;
+  }
+
+  function visibleItems(content) {
+    const items = allItems(content).filter(
+      (item) =>
+        !item.hidden &&
+        item.getAttribute("data-pui-selectbox-disabled") !== "true",
+    );
+
+    const create = getCreate(content);
+    if (create && isCreateVisible(create)) items.push(create);
+
+    return items;
+  }
+
+  function getCreate(content) {
+    return content.querySelector("[data-pui-selectbox-create]");
+  }
+
+  function isCreateVisible(create) {
+    return !create.classList.contains("hidden");
+  }
+
+  // Offer the search text as a new value unless an item already matches it
+  function updateCreate(content, query) {
+    const create = getCreate(content);
+    if (!create) return false;
+
+    query = query.trim();
+    const lower = query.toLowerCase();
+    const exists =
+      !query ||
+      sourceItems(content)
+        .concat(allItems(content))
+        .some(
+          (item) =>
+            item.getAttribute("data-pui-selectbox-value") === query ||
+            itemLabel(item).toLowerCase() === lower,
+        );
+
+    create.classList.toggle("hidden", exists);
+    create.classList.toggle("flex", !exists);
+    create.setAttribute("data-pui-selectbox-create-value", exists ? "" : query);
+
+    const label = create.querySelector("[data-pui-selectbox-create-label]");
+    if (label) {
+      label.textContent = create
+        .getAttribute("data-pui-selectbox-create")
+        .replace("{q}", query);
+    }
+
+    return !exists;
+  }
+
+  function createValue(create) {
+    const content = create.closest("[data-pui-selectbox-content]");
+    const trigger = content && getTrigger(content.id);
+    const value = create.getAttribute("data-pui-selectbox-create-value");
+    if (!trigger || !value) return;
+
+    const event = new CustomEvent("pui-selectbox:create", {
+      bubbles: true,
+      cancelable: true,
+      detail: { value: value },
+    });
+    if (!create.dispatchEvent(event)) return;
+
+    // Static lists get a real item so the value can be toggled like the others
+    const list = content.querySelector("[data-pui-selectbox-list]");
+    const model = list && allItems(content)[0];
+    if (
+      model &&
+      !list.hasAttribute("data-pui-virtual") &&
+      !content.hasAttribute("data-pui-selectbox-remote-url")
+    ) {
+      const item = model.cloneNode(true);
+      item.removeAttribute("id");
+      item.hidden = false;
+      item.setAttribute("data-pui-selectbox-value", value);
+      item.setAttribute("data-pui-selectbox-label", value);
+      item.setAttribute("data-pui-selectbox-disabled", "false");
+      item.removeAttribute("aria-disabled");
+      item.classList.remove("pointer-events-none", "opacity-50");
+
+      const text = item.querySelector(".select-item-text");
+      if (text) text.textContent = value;
+
+      list.appendChild(item);
+    }
+
+    const selected = getLabels(trigger);
+    if (!isMultiple(trigger)) selected.clear();
+    selected.set(value, value);
+    sync(trigger);
+
+    const search = content.querySelector("[data-pui-selectbox-search]");
+    if (search) search.value = "";
+    if (!content.hasAttribute("data-pui-selectbox-remote-url")) {
+      filter(content, "");
+    }
+    updateCreate(content, "");
+
+    if (!isMultiple(trigger)) {
+      window.tui?.popover?.close(content.id);
+      trigger.focus();
+    } else {
+      search?.focus();
+    }
+  }
+
+  // Write values to the hidden input(s) inside the trigger
+  function writeHidden(trigger, values) {
+    const input = trigger.querySelector('input[type="hidden"]');
+    if (!input) return;
+
+    trigger
+      .querySelectorAll("input[data-pui-selectbox-extra]")
+      .forEach((el) => el.remove());
+
+    input.value = values[0] || "";
+
+    values.slice(1).forEach((value) => {
+      const extra = input.cloneNode();
+      extra.removeAttribute("id");
+      extra.setAttribute("data-pui-selectbox-extra", "");
+      extra.value = value;
+      input.after(extra);
+    });
+
+    input.dispatchEvent(new Event("change", { bubbles: true }));
+  }
+
+  function markItems(trigger) {
+    const content = getContent(trigger);
+    if (!content) return;
+
+    const selected = getLabels(trigger);
+    allItems(content).forEach((item) => {
+      const isSelected = selected.has(
+        item.getAttribute("data-pui-selectbox-value"),
+      );
+      item.setAttribute("data-pui-selectbox-selected", String(isSelected));
+      item.setAttribute("aria-selected", String(isSelected));
+      item.classList.toggle("bg-accent/90", isSelected);
+      item.classList.toggle("text-accent-foreground", isSelected);
+
+      const check = item.querySelector(".select-check");
+      if (check) {
+        check.classList.toggle("opacity-100", isSelected);
+        check.classList.toggle("opacity-0", !isSelected);
+      }
+    });
+  }
+
+  function renderValue(trigger) {
+    const display = trigger.querySelector(".select-value");
+    if (!display) return;
+
+    const selected = getLabels(trigger);
+    const placeholder =
+      display.getAttribute("data-pui-selectbox-placeholder") || "";
+
+    display.textContent = "";
+
+    if (selected.size === 0) {
+      display.textContent = placeholder;
+      display.classList.add("text-muted-foreground/80");
+      return;
+    }
+
+    display.classList.remove("text-muted-foreground/80");
+
+    if (trigger.getAttribute("data-pui-selectbox-show-pills") === "true") {
+      display.classList.add("flex", "flex-wrap", "gap-1");
+      selected.forEach((label, value) => {
+        const pill = document.createElement("span");
+        pill.className =
+          "inline-flex items-center gap-1 rounded-full border border-border/40 bg-muted/60 px-2 py-0.5 text-xs font-medium text-foreground";
+        pill.setAttribute("data-pui-selectbox-pill", value);
+        pill.textContent = label;
+        display.appendChild(pill);
+      });
+      return;
+    }
+
+    const countText = trigger.getAttribute(
+      "data-pui-selectbox-selected-count-text",
+    );
+    if (isMultiple(trigger) && countText && selected.size > 1) {
+      display.textContent = countText.replace("{n}", String(selected.size));
+      return;
+    }
+
+    display.textContent = Array.from(selected.values()).join(", ");
+  }
+
+  function sync(trigger) {
+    writeHidden(trigger, Array.from(getLabels(trigger).keys()));
+    markItems(trigger);
+    renderValue(trigger);
+  }
+
+  function selectItem(item) {
+    if (item.getAttribute("data-pui-selectbox-disabled") === "true") return;
+
+    const content = item.closest("[data-pui-selectbox-content]");
+    if (!content) return;
+
+    const trigger = getTrigger(content.id);
+    if (!trigger) return;
+
+    const value = item.getAttribute("data-pui-selectbox-value");
+    const selected = getLabels(trigger);
+
+    if (isMultiple(trigger)) {
+      selected.has(value)
+        ? selected.delete(value)
+        : selected.set(value, itemLabel(item));
+      sync(trigger);
+      return;
+    }
+
+    selected.clear();
+    selected.set(value, itemLabel(item));
+    sync(trigger);
+
+    window.tui?.popover?.close(content.id);
+    trigger.focus();
+  }
+
+  // Static filtering of rendered items
+  function filter(content, query) {
+    query = query.trim().toLowerCase();
+
+    const list = virtualList(content);
+    if (list) {
+      if (list.hasAttribute("data-pui-virtual-url")) {
+        window.tui.virtual.setParams(list, { q: query });
+      } else {
+        window.tui.virtual.filter(
+          list,
+          query ? (item) => itemLabel(item).toLowerCase().includes(query) : null,
+        );
+      }
+      return;
+    }
+
+    allItems(content).forEach((item) => {
+      item.hidden = !!query && !itemLabel(item).toLowerCase().includes(query);
+    });
+
+    content.querySelectorAll('[aria-role="group"]').forEach((group) => {
+      group.hidden = !group.querySelector(
+        "[data-pui-selectbox-value]:not([hidden])",
+      );
+    });
+  }
+
+  function focusItem(content, delta) {
+    const list = virtualList(content);
+    if (list) {
+      window.tui.virtual.move(list, delta);
+      return;
+    }
+
+    const items = visibleItems(content);
+    if (items.length === 0) return;
+
+    const index = items.indexOf(document.activeElement);
+    let next = index + delta;
+    if (index < 0) next = delta > 0 ? 0 : items.length - 1;
+    if (next < 0) next = items.length - 1;
+    if (next >= items.length) next = 0;
+
+    items[next].focus();
+  }
+
+  document.addEventListener("click", (e) => {
+    const create = e.target.closest("[data-pui-selectbox-create]");
+    if (create) {
+      e.preventDefault();
+      createValue(create);
+      return;
+    }
+
+    const item = e.target.closest("[data-pui-selectbox-value]");
+    if (item && item.closest("[data-pui-selectbox-content]")) {
+      e.preventDefault();
+      selectItem(item);
+      return;
+    }
+
+    // Focus the search once the popover has been opened by popover.js
+    const trigger = e.target.closest("[data-pui-selectbox-content-id]");
+    if (!trigger) return;
+
+    const content = getContent(trigger);
+    if (!content) return;
+
+    setTimeout(() => {
+      if (content.getAttribute("data-pui-popover-open") !== "true") return;
+      content.querySelector("[data-pui-selectbox-search]")?.focus();
+    }, 20);
+  });
+
+  document.addEventListener("input", (e) => {
+    const search = e.target.closest("[data-pui-selectbox-search]");
+    if (!search) return;
+
+    const content = search.closest("[data-pui-selectbox-content]");
+    if (!content) return;
+
+    // Remote lists are searched by remote.js, which offers the entry once results load
+    if (content.hasAttribute("data-pui-selectbox-remote-url")) {
+      updateCreate(content, "");
+    } else {
+      filter(content, search.value);
+      updateCreate(content, search.value);
+    }
+  });
+
+  document.addEventListener("keydown", (e) => {
+    const content = e.target.closest?.("[data-pui-selectbox-content]");
+    if (!content) return;
+
+    if (e.key === "ArrowDown" || e.key === "ArrowUp") {
+      // Inside a virtual list the runtime moves focus itself
+      if (e.target.closest("[data-pui-virtual]")) return;
+
+      e.preventDefault();
+      focusItem(content, e.key === "ArrowDown" ? 1 : -1);
+      return;
+    }
+
+    const create = e.target.closest("[data-pui-selectbox-create]");
+    if (create && (e.key === "Enter" || e.key === " ")) {
+      e.preventDefault();
+      createValue(create);
+      return;
+    }
+
+    // Enter in the search field creates the typed value when nothing matches
+    if (e.key === "Enter" && e.target.closest("[data-pui-selectbox-search]")) {
+      e.preventDefault();
+      const entry = getCreate(content);
+      if (entry && isCreateVisible(entry)) createValue(entry);
+      return;
+    }
+
+    const item = e.target.closest("[data-pui-selectbox-value]");
+    if (item && (e.key === "Enter" || e.key === " ")) {
+      e.preventDefault();
+      selectItem(item);
+    }
+  });
+
+  // Remote results replaced the items
+  document.addEventListener("pui-selectbox:load", (e) => {
+    const content = e.target.closest("[data-pui-selectbox-content]");
+    const trigger = content && getTrigger(content.id);
+    if (!trigger) return;
+
+    markItems(trigger);
+    updateCreate(content, e.detail.query);
+  });
+
+  // Virtual lists mount fresh item copies while scrolling
+  document.addEventListener("pui-virtual:render", (e) => {
+    const content = e.target.closest("[data-pui-selectbox-content]");
+    const trigger = content && getTrigger(content.id);
+    if (trigger) markItems(trigger);
+  });
+
+  document.addEventListener("reset", (e) => {
+    if (!e.target.matches("form")) return;
+
+    e.target
+      .querySelectorAll("[data-pui-selectbox-content-id]")
+      .forEach((trigger) => {
+        getLabels(trigger).clear();
+        setTimeout(() => sync(trigger));
+      });
+  });
+
+  function init() {
+    document
+      .querySelectorAll("[data-pui-selectbox-content-id]")
+      .forEach((trigger) => {
+        if (labels.has(trigger)) return;
+
+        const selected = getLabels(trigger);
+        const content = getContent(trigger);
+        if (content) {
+          sourceItems(content).forEach((item) => {
+            if (item.getAttribute("data-pui-selectbox-selected") === "true") {
+              selected.set(
+                item.getAttribute("data-pui-selectbox-value"),
+                itemLabel(item),
+              );
+            }
+          });
+        }
+
+        // Remote selects carry selections whose items are not rendered yet
+        const initial = trigger.getAttribute("data-pui-selectbox-labels");
+        if (selected.size === 0 && initial) {
+          JSON.parse(initial).forEach((o) => selected.set(o.value, o.label));
+        }
+
+        if (selected.size > 0) sync(trigger);
+      });
+  }
+
+  if (document.readyState === "loading") {
+    document.addEventListener("DOMContentLoaded", init);
+  } else {
+    init();
+  }
+
+  // Expose public API
+  window.tui = window.tui || {};
+  window.tui.selectbox = {
+    init: init,
+    values: (contentId) => {
+      const trigger = getTrigger(contentId);
+      return trigger ? Array.from(getLabels(trigger).keys()) : [];
+    },
+  };
+})();
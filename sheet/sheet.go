@@ -0,0 +1,372 @@
+package sheet
+
+import (
+	_ "embed"
+
+	"github.com/plainkit/html"
+	"github.com/plainkit/icons/lucide"
+	"github.com/plainkit/ui/button"
+	"github.com/plainkit/ui/dialog"
+	"github.com/plainkit/ui/internal/randomid"
+	"github.com/plainkit/ui/internal/styles"
+	"github.com/plainkit/ui/layer"
+	"github.com/plainkit/ui/urlstate"
+)
+
+// Side is the edge of the viewport a sheet slides in from.
+type Side string
+
+const (
+	SideTop    Side = "top"
+	SideRight  Side = "right"
+	SideBottom Side = "bottom"
+	SideLeft   Side = "left"
+)
+
+// Size limits the width of left and right sheets and the height of top and bottom ones.
+type Size string
+
+const (
+	SizeSm   Size = "sm"
+	SizeMd   Size = "md"
+	SizeLg   Size = "lg"
+	SizeXl   Size = "xl"
+	SizeFull Size = "full"
+)
+
+type TriggerProps struct {
+	ID       string
+	Class    string
+	Attrs    []html.Global
+	Disabled bool
+	For      string // ID of the sheet to open
+	URL      string // Loads the dialog.Body inside the sheet from URL when this trigger opens it
+}
+
+type ContentProps struct {
+	ID              string
+	Class           string
+	Attrs           []html.Global
+	Side            Side // Default SideRight
+	Size            Size // Default SizeMd
+	HideCloseButton bool
+	Open            bool          // Initial open state
+	Label           string        // Accessible name for sheets without a Title
+	DisableSwipe    bool          // Turns off dragging the sheet back towards its edge to dismiss it on touch
+	Handle          bool          // Shows a grab handle; meant for top and bottom sheets
+	Sync            urlstate.Sync // Mirrors the open state into the URL as Param=ID, as dialog.ContentProps.Sync does
+}
+
+type CloseProps struct {
+	ID    string
+	Class string
+	Attrs []html.Global
+	For   string // ID of the sheet to close (optional, defaults to the closest sheet)
+}
+
+type HeaderProps struct {
+	ID    string
+	Class string
+	Attrs []html.Global
+}
+
+type FooterProps struct {
+	ID    string
+	Class string
+	Attrs []html.Global
+}
+
+type TitleProps struct {
+	ID    string
+	Class string
+	Attrs []html.Global
+}
+
+type DescriptionProps struct {
+	ID    string
+	Class string
+	Attrs []html.Global
+}
+
+// Trigger creates a button that opens a sheet.
+func Trigger(triggerProps TriggerProps, buttonProps button.Props, args ...html.ButtonArg) html.Node {
+	return html.Div(
+		html.AClass("contents"),
+		dialog.Trigger(dialog.TriggerProps{
+			ID:       triggerProps.ID,
+			Class:    triggerProps.Class,
+			Attrs:    triggerProps.Attrs,
+			Disabled: triggerProps.Disabled,
+			For:      triggerProps.For,
+			URL:      triggerProps.URL,
+		}, buttonProps, args...),
+	).WithAssets("", sheetJS, "ui-sheet")
+}
+
+// Content creates the sheet panel and its backdrop. It is a dialog to dialog.js, which
+// handles focus, scroll locking, stacking and Escape for both.
+func Content(props ContentProps, args ...html.DivArg) html.Node {
+	instanceID := props.ID
+	if instanceID == "" {
+		instanceID = randomid.New("sheet")
+	}
+
+	side := props.Side
+	if side == "" {
+		side = SideRight
+	}
+
+	overlayArgs := []html.DivArg{
+		html.AClass(dialog.OverlayClass),
+		html.AData("pui-dialog-backdrop", ""),
+		html.AData("dialog-instance", instanceID),
+	}
+
+	contentClasses := html.ClassMerge(
+		styles.Panel("fixed z-50 flex flex-col gap-6 p-6 outline-none"),
+		"transition-transform duration-300 ease-out",
+		dialog.PanelStateClass,
+		sideClass(side),
+		sizeClass(side, props.Size),
+		props.Class,
+	)
+
+	contentArgs := []html.DivArg{
+		html.AId(dialog.ContentID(instanceID)),
+		html.AClass(contentClasses),
+		html.ACustom("role", "dialog"),
+		html.AAria("modal", "true"),
+		html.ATabindex(-1),
+		html.AData("pui-dialog-content", ""),
+		html.AData("dialog-instance", instanceID),
+		html.AData("pui-sheet", ""),
+		html.AData("pui-sheet-side", string(side)),
+	}
+
+	if props.Label != "" {
+		contentArgs = append(contentArgs, html.AAria("label", props.Label))
+	}
+
+	if !props.DisableSwipe {
+		contentArgs = append(contentArgs, html.AData("pui-sheet-swipe", ""))
+	}
+
+	if props.Open {
+		overlayArgs = append(overlayArgs, html.AData("pui-dialog-open", "true"))
+		contentArgs = append(contentArgs, html.AData("pui-dialog-open", "true"))
+	} else {
+		overlayArgs = append(overlayArgs, html.AData("pui-dialog-open", "false"), html.AData("pui-dialog-hidden", "true"))
+		contentArgs = append(contentArgs, html.AData("pui-dialog-open", "false"), html.AData("pui-dialog-hidden", "true"))
+	}
+
+	for _, attr := range props.Sync.Attrs() {
+		contentArgs = append(contentArgs, attr)
+	}
+
+	for _, attr := range props.Attrs {
+		contentArgs = append(contentArgs, attr)
+	}
+
+	if props.Handle {
+		contentArgs = append(contentArgs, html.Div(
+			html.AClass(handleClass(side)),
+			html.AAria("hidden", "true"),
+			html.AData("pui-sheet-handle", ""),
+		))
+	}
+
+	contentArgs = append(contentArgs, args...)
+
+	if !props.HideCloseButton {
+		contentArgs = append(contentArgs, html.Button(
+			html.AClass(dialog.CloseButtonClass),
+			html.AData("pui-dialog-close", instanceID),
+			html.AAria("label", "Close"),
+			html.AType("button"),
+			lucide.X(),
+			html.Span(html.AClass("sr-only"), html.Text("Close")),
+		))
+	}
+
+	wrapperArgs := []html.DivArg{
+		html.Div(overlayArgs...),
+		html.Div(contentArgs...),
+		html.Child(dialog.Assets()),
+		html.Child(urlstate.Assets()),
+		html.Child(layer.Assets()),
+	}
+
+	return html.Div(wrapperArgs...).WithAssets("", sheetJS, "ui-sheet")
+}
+
+// sideClass pins the panel to its edge and slides it out past that edge when closed.
+func sideClass(side Side) string {
+	switch side {
+	case SideTop:
+		return "inset-x-0 top-0 w-full touch-pan-x rounded-t-none border-x-0 border-t-0 data-[pui-dialog-open=false]:-translate-y-full"
+	case SideBottom:
+		return "inset-x-0 bottom-0 w-full touch-pan-x rounded-b-none border-x-0 border-b-0 pb-[max(1.5rem,env(safe-area-inset-bottom))] data-[pui-dialog-open=false]:translate-y-full"
+	case SideLeft:
+		return "inset-y-0 left-0 h-full touch-pan-y rounded-l-none border-y-0 border-l-0 data-[pui-dialog-open=false]:-translate-x-full"
+	default:
+		return "inset-y-0 right-0 h-full touch-pan-y rounded-r-none border-y-0 border-r-0 data-[pui-dialog-open=false]:translate-x-full"
+	}
+}
+
+func sizeClass(side Side, size Size) string {
+	vertical := side == SideTop || side == SideBottom
+
+	if vertical {
+		switch size {
+		case SizeSm:
+			return "max-h-[40dvh] overflow-y-auto"
+		case SizeLg:
+			return "max-h-[80dvh] overflow-y-auto"
+		case SizeXl:
+			return "max-h-[90dvh] overflow-y-auto"
+		case SizeFull:
+			return "h-dvh overflow-y-auto rounded-none"
+		default:
+			return "max-h-[60dvh] overflow-y-auto"
+		}
+	}
+
+	switch size {
+	case SizeSm:
+		return "w-3/4 overflow-y-auto sm:max-w-sm"
+	case SizeLg:
+		return "w-3/4 overflow-y-auto sm:max-w-lg"
+	case SizeXl:
+		return "w-3/4 overflow-y-auto sm:max-w-2xl"
+	case SizeFull:
+		return "w-full overflow-y-auto rounded-none"
+	default:
+		return "w-3/4 overflow-y-auto sm:max-w-md"
+	}
+}
+
+func handleClass(side Side) string {
+	base := "mx-auto h-1.5 w-12 shrink-0 touch-none rounded-full bg-muted-foreground/30"
+	if side == SideTop {
+		return html.ClassMerge(base, "order-last")
+	}
+
+	return base
+}
+
+func (p CloseProps) ApplySpan(attrs *html.SpanAttrs, children *[]html.Component) {
+	dialog.CloseProps{ID: p.ID, Class: p.Class, Attrs: p.Attrs, For: p.For}.ApplySpan(attrs, children)
+}
+
+// Close creates an element that closes its sheet when clicked.
+func Close(args ...html.SpanArg) html.Node {
+	var (
+		props CloseProps
+		rest  []html.SpanArg
+	)
+
+	for _, a := range args {
+		if v, ok := a.(CloseProps); ok {
+			props = v
+		} else {
+			rest = append(rest, a)
+		}
+	}
+
+	return html.Span(append([]html.SpanArg{props}, rest...)...)
+}
+
+func (p HeaderProps) ApplyDiv(attrs *html.DivAttrs, children *[]html.Component) {
+	dialog.HeaderProps{
+		ID:    p.ID,
+		Class: html.ClassMerge("gap-1.5 pr-10 text-left sm:text-left", p.Class),
+		Attrs: p.Attrs,
+	}.ApplyDiv(attrs, children)
+}
+
+// Header creates a sheet header container.
+func Header(args ...html.DivArg) html.Node {
+	var (
+		props HeaderProps
+		rest  []html.DivArg
+	)
+
+	for _, a := range args {
+		if v, ok := a.(HeaderProps); ok {
+			props = v
+		} else {
+			rest = append(rest, a)
+		}
+	}
+
+	return html.Div(append([]html.DivArg{props}, rest...)...)
+}
+
+func (p FooterProps) ApplyDiv(attrs *html.DivAttrs, children *[]html.Component) {
+	dialog.FooterProps{ID: p.ID, Class: html.ClassMerge("mt-auto", p.Class), Attrs: p.Attrs}.ApplyDiv(attrs, children)
+}
+
+// Footer creates a sheet footer container, pushed to the far end of the panel.
+func Footer(args ...html.DivArg) html.Node {
+	var (
+		props FooterProps
+		rest  []html.DivArg
+	)
+
+	for _, a := range args {
+		if v, ok := a.(FooterProps); ok {
+			props = v
+		} else {
+			rest = append(rest, a)
+		}
+	}
+
+	return html.Div(append([]html.DivArg{props}, rest...)...)
+}
+
+func (p TitleProps) ApplyH2(attrs *html.H2Attrs, children *[]html.Component) {
+	dialog.TitleProps{ID: p.ID, Class: html.ClassMerge("text-lg", p.Class), Attrs: p.Attrs}.ApplyH2(attrs, children)
+}
+
+// Title creates a sheet title, which names the sheet for assistive technology.
+func Title(args ...html.H2Arg) html.Node {
+	var (
+		props TitleProps
+		rest  []html.H2Arg
+	)
+
+	for _, a := range args {
+		if v, ok := a.(TitleProps); ok {
+			props = v
+		} else {
+			rest = append(rest, a)
+		}
+	}
+
+	return html.H2(append([]html.H2Arg{props}, rest...)...)
+}
+
+func (p DescriptionProps) ApplyP(attrs *html.PAttrs, children *[]html.Component) {
+	dialog.DescriptionProps{ID: p.ID, Class: p.Class, Attrs: p.Attrs}.ApplyP(attrs, children)
+}
+
+// Description creates a sheet description.
+func Description(args ...html.PArg) html.Node {
+	var (
+		props DescriptionProps
+		rest  []html.PArg
+	)
+
+	for _, a := range args {
+		if v, ok := a.(DescriptionProps); ok {
+			props = v
+		} else {
+			rest = append(rest, a)
+		}
+	}
+
+	return html.P(append([]html.PArg{props}, rest...)...)
+}
+
+//go:embed sheet.js
+var sheetJS string
@@ -0,0 +1,132 @@
+(function () {
+  "use strict";
+
+  // Fraction of the panel, or speed in px/ms, a drag must reach to dismiss the sheet
+  const DISMISS_RATIO = 0.3;
+  const DISMISS_VELOCITY = 0.5;
+
+  // Moves of a few pixels are still taps
+  const SLOP = 8;
+
+  let drag = null;
+
+  // Unit vector pointing from the panel towards the edge it slides out of
+  const directions = {
+    top: { x: 0, y: -1 },
+    right: { x: 1, y: 0 },
+    bottom: { x: 0, y: 1 },
+    left: { x: -1, y: 0 },
+  };
+
+  function backdropFor(panel) {
+    const id = panel.getAttribute("data-dialog-instance");
+    return document.querySelector('[data-pui-dialog-backdrop][data-dialog-instance="' + CSS.escape(id) + '"]');
+  }
+
+  // A drag that starts in content scrolled away from the edge scrolls it instead
+  function scrolledAway(target, panel, dir) {
+    for (let el = target; el && el !== panel.parentElement; el = el.parentElement) {
+      if (dir.y === 0) continue;
+      if (el.scrollHeight <= el.clientHeight) continue;
+
+      const atTop = el.scrollTop <= 0;
+      const atBottom = el.scrollTop + el.clientHeight >= el.scrollHeight - 1;
+      if ((dir.y > 0 && !atTop) || (dir.y < 0 && !atBottom)) return true;
+    }
+    return false;
+  }
+
+  function distance(e) {
+    return (e.clientX - drag.startX) * drag.dir.x + (e.clientY - drag.startY) * drag.dir.y;
+  }
+
+  function release(dismiss) {
+    const { panel, backdrop } = drag;
+    drag = null;
+
+    panel.style.transition = "";
+    panel.style.transform = "";
+    if (backdrop) {
+      backdrop.style.transition = "";
+      backdrop.style.opacity = "";
+    }
+
+    // Closing animates on from where the drag left the panel
+    if (dismiss && window.tui && window.tui.dialog) {
+      window.tui.dialog.close(panel.getAttribute("data-dialog-instance"));
+    }
+  }
+
+  document.addEventListener("pointerdown", (e) => {
+    if (e.pointerType !== "touch" || drag) return;
+
+    const panel = e.target.closest("[data-pui-sheet-swipe]");
+    if (!panel || panel.getAttribute("data-pui-dialog-open") !== "true") return;
+    if (e.target.closest("input, textarea, select, [contenteditable], [data-pui-slider-input]")) return;
+
+    const dir = directions[panel.getAttribute("data-pui-sheet-side")] || directions.right;
+    if (!e.target.closest("[data-pui-sheet-handle]") && scrolledAway(e.target, panel, dir)) return;
+
+    drag = {
+      panel: panel,
+      backdrop: backdropFor(panel),
+      dir: dir,
+      pointerId: e.pointerId,
+      startX: e.clientX,
+      startY: e.clientY,
+      startTime: e.timeStamp,
+      size: dir.x !== 0 ? panel.offsetWidth : panel.offsetHeight,
+      active: false,
+    };
+  });
+
+  document.addEventListener(
+    "pointermove",
+    (e) => {
+      if (!drag || e.pointerId !== drag.pointerId) return;
+
+      const offset = Math.max(distance(e), 0);
+      if (!drag.active) {
+        // Only a move mostly along the dismiss direction starts a drag
+        const across = Math.abs((e.clientX - drag.startX) * drag.dir.y + (e.clientY - drag.startY) * drag.dir.x);
+        if (offset < SLOP) {
+          if (across > SLOP) drag = null;
+          return;
+        }
+        drag.active = true;
+        drag.panel.style.transition = "none";
+        if (drag.backdrop) drag.backdrop.style.transition = "none";
+      }
+
+      e.preventDefault();
+      drag.panel.style.transform =
+        "translate(" + offset * drag.dir.x + "px, " + offset * drag.dir.y + "px)";
+      if (drag.backdrop) drag.backdrop.style.opacity = String(1 - Math.min(offset / drag.size, 1));
+    },
+    { passive: false },
+  );
+
+  function end(e) {
+    if (!drag || e.pointerId !== drag.pointerId) return;
+    if (!drag.active) {
+      drag = null;
+      return;
+    }
+
+    const offset = Math.max(distance(e), 0);
+    const velocity = offset / Math.max(e.timeStamp - drag.startTime, 1);
+    release(e.type === "pointerup" && (offset > drag.size * DISMISS_RATIO || velocity > DISMISS_VELOCITY));
+  }
+
+  document.addEventListener("pointerup", end);
+  document.addEventListener("pointercancel", end);
+
+  // Panels being dragged must not scroll the page or themselves
+  document.addEventListener(
+    "touchmove",
+    (e) => {
+      if (drag && drag.active) e.preventDefault();
+    },
+    { passive: false },
+  );
+})();
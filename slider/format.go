@@ -0,0 +1,104 @@
+package slider
+
+import (
+	"encoding/json"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Format describes how slider values are displayed. It is applied the same way by the
+// server and by slider.js, so rendered and live values always match.
+type Format struct {
+	Prefix   string // Such as "$"
+	Suffix   string // Such as "%" or " km"
+	Decimals int    // Digits after the decimal point; raised to the precision of Step
+	Group    bool   // Thousands separators
+}
+
+// Currency formats whole amounts with a leading symbol and thousands separators.
+func Currency(symbol string) Format {
+	return Format{Prefix: symbol, Group: true}
+}
+
+// Percent appends a percent sign; values are not scaled.
+func Percent() Format {
+	return Format{Suffix: "%"}
+}
+
+// Unit appends unit after a space, such as Unit("km").
+func Unit(unit string) Format {
+	return Format{Suffix: " " + unit}
+}
+
+// Format renders v rounded half away from zero to f.Decimals digits.
+func (f Format) Format(v float64) string {
+	decimals := max(f.Decimals, 0)
+	scale := math.Pow(10, float64(decimals))
+	rounded := math.Round(math.Abs(v)*scale) / scale
+
+	s := strconv.FormatFloat(rounded, 'f', decimals, 64)
+
+	if f.Group {
+		whole, fraction, found := strings.Cut(s, ".")
+		s = group(whole)
+
+		if found {
+			s += "." + fraction
+		}
+	}
+
+	sign := ""
+	if v < 0 && rounded != 0 {
+		sign = "-"
+	}
+
+	return sign + f.Prefix + s + f.Suffix
+}
+
+// withStep raises Decimals to the precision of step, so a 0.25 step shows two digits.
+func (f Format) withStep(step float64) Format {
+	_, fraction, _ := strings.Cut(formatNumber(step), ".")
+	f.Decimals = max(f.Decimals, len(fraction))
+
+	return f
+}
+
+func (f Format) json() string {
+	data, _ := json.Marshal(map[string]any{
+		"prefix":   f.Prefix,
+		"suffix":   f.Suffix,
+		"decimals": f.Decimals,
+		"group":    f.Group,
+	})
+
+	return string(data)
+}
+
+func group(digits string) string {
+	if len(digits) <= 3 {
+		return digits
+	}
+
+	var sb strings.Builder
+
+	head := len(digits) % 3
+	if head > 0 {
+		sb.WriteString(digits[:head])
+	}
+
+	for i := head; i < len(digits); i += 3 {
+		if sb.Len() > 0 {
+			sb.WriteByte(',')
+		}
+
+		sb.WriteString(digits[i : i+3])
+	}
+
+	return sb.String()
+}
+
+// formatNumber renders v for attributes in its shortest exact form.
+func formatNumber(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
@@ -0,0 +1,413 @@
+package slider
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+
+	"github.com/plainkit/html"
+)
+
+// Tick marks a value on the scale under the slider, optionally labelled.
+type Tick struct {
+	Value float64
+	Label string
+}
+
+type TicksProps struct {
+	ID          string
+	Class       string
+	Attrs       []html.Global
+	Min         float64
+	Max         float64 // Defaults to 100, like the native input, unless HasMax is set
+	HasMax      bool    // Uses Max even when it is zero
+	Ticks       []Tick
+	Orientation Orientation
+}
+
+// RangeProps configures a two-thumb slider selecting a range between Min and Max.
+type RangeProps struct {
+	ID          string
+	Class       string
+	Attrs       []html.Global
+	Name        string // Submitted as Name+"_min" and Name+"_max" unless MinName and MaxName are set
+	MinName     string
+	MaxName     string
+	Min         float64
+	Max         float64 // Defaults to 100 unless HasMax is set
+	HasMax      bool    // Uses Max even when it is zero
+	Step        float64 // Defaults to 1; fractional steps are supported
+	Low         float64 // Start of the selection, clamped to Min
+	High        float64 // End of the selection; defaults to Max unless HasHigh is set
+	HasHigh     bool    // Uses High even when it is zero
+	MinDistance float64 // Smallest allowed gap between the thumbs
+	Ticks       []Tick
+	Orientation Orientation
+	Format      *Format
+	Label       string // Accessible name of the group; the thumbs read "Minimum" and "Maximum" followed by it
+	ShowValue   bool   // Renders Label and the formatted selection above the slider
+	Disabled    bool
+}
+
+// ErrRange is returned by ParseRange for values that are not numbers or do not form a
+// valid selection.
+var ErrRange = errors.New("slider: invalid range")
+
+// bounds returns min and max, with a zero max standing for the native default of 100
+// unless hasMax is set.
+func bounds(min, max float64, hasMax bool) (float64, float64) {
+	if max == 0 && !hasMax && min < 100 {
+		max = 100
+	}
+
+	return min, math.Max(min, max)
+}
+
+func (p RangeProps) step() float64 {
+	if p.Step <= 0 {
+		return 1
+	}
+
+	return p.Step
+}
+
+func (p RangeProps) names() (string, string) {
+	minName, maxName := p.MinName, p.MaxName
+	if minName == "" && p.Name != "" {
+		minName = p.Name + "_min"
+	}
+
+	if maxName == "" && p.Name != "" {
+		maxName = p.Name + "_max"
+	}
+
+	return minName, maxName
+}
+
+// values returns the selection clamped to the bounds, with High defaulting to Max.
+func (p RangeProps) values() (float64, float64) {
+	min, max := bounds(p.Min, p.Max, p.HasMax)
+
+	high := p.High
+	if high == 0 && !p.HasHigh {
+		high = max
+	}
+
+	high = clamp(high, min, max)
+
+	return clamp(math.Min(p.Low, high), min, max), high
+}
+
+func (p RangeProps) format() Format {
+	f := Format{}
+	if p.Format != nil {
+		f = *p.Format
+	}
+
+	return f.withStep(p.step())
+}
+
+// RangeText is the visible selection, such as "$100 – $500".
+func RangeText(p RangeProps) string {
+	low, high := p.values()
+	f := p.format()
+
+	return f.Format(low) + " – " + f.Format(high)
+}
+
+// ParseRange reads the two submitted values of a range slider. Missing values default to
+// the bounds, so an untouched filter selects everything; values outside the bounds are
+// clamped.
+func ParseRange(r *http.Request, p RangeProps) (low, high float64, err error) {
+	if err := r.ParseForm(); err != nil {
+		return 0, 0, err
+	}
+
+	min, max := bounds(p.Min, p.Max, p.HasMax)
+	minName, maxName := p.names()
+
+	parse := func(name string, fallback float64) (float64, error) {
+		raw := r.Form.Get(name)
+		if name == "" || raw == "" {
+			return fallback, nil
+		}
+
+		v, err := strconv.ParseFloat(raw, 64)
+		if err != nil || math.IsNaN(v) || math.IsInf(v, 0) {
+			return 0, fmt.Errorf("%w: %s is not a number: %q", ErrRange, name, raw)
+		}
+
+		return clamp(v, min, max), nil
+	}
+
+	if low, err = parse(minName, min); err != nil {
+		return 0, 0, err
+	}
+
+	if high, err = parse(maxName, max); err != nil {
+		return 0, 0, err
+	}
+
+	// Allow for float error in values that are a multiple of a fractional step
+	if high-low < p.MinDistance-1e-9 {
+		return 0, 0, fmt.Errorf("%w: %s to %s is closer than %s", ErrRange, formatNumber(low), formatNumber(high), formatNumber(p.MinDistance))
+	}
+
+	return low, high, nil
+}
+
+// position returns the CSS offset of the thumb center at pct percent of the track,
+// accounting for the 1rem thumb that never overhangs the ends. slider.js mirrors it.
+func position(pct float64) string {
+	offset := 0.5 - pct/100 // In rem, half a thumb at either end
+	round := func(v float64) string { return formatNumber(math.Round(v*1e4) / 1e4) }
+
+	return "calc(" + round(pct) + "% + " + round(offset) + "rem)"
+}
+
+func percent(v, min, max float64) float64 {
+	if max <= min {
+		return 0
+	}
+
+	return (clamp(v, min, max) - min) / (max - min) * 100
+}
+
+func clamp(v, min, max float64) float64 {
+	return math.Min(math.Max(v, min), max)
+}
+
+// ApplyDiv implements the html.DivArg interface for TicksProps.
+func (p TicksProps) ApplyDiv(attrs *html.DivAttrs, children *[]html.Component) {
+	min, max := bounds(p.Min, p.Max, p.HasMax)
+	vertical := p.Orientation == OrientationVertical
+
+	args := []html.DivArg{
+		html.AClass(html.ClassMerge(
+			"relative text-xs text-muted-foreground",
+			orientationClass(p.Orientation, "h-6 w-full", "h-48 w-12"),
+			p.Class,
+		)),
+		html.AAria("hidden", "true"), // Screen readers get the values from the thumbs
+		html.AData("pui-slider-ticks", ""),
+	}
+
+	if p.ID != "" {
+		args = append(args, html.AId(p.ID))
+	}
+
+	for _, a := range p.Attrs {
+		args = append(args, a)
+	}
+
+	for _, t := range p.Ticks {
+		style := "left: " + position(percent(t.Value, min, max)) + ";"
+		if vertical {
+			style = "bottom: " + position(percent(t.Value, min, max)) + ";"
+		}
+
+		args = append(args, html.Span(
+			html.AClass(orientationClass(p.Orientation,
+				"absolute top-0 flex -translate-x-1/2 flex-col items-center gap-0.5",
+				"absolute left-0 flex translate-y-1/2 items-center gap-1.5",
+			)),
+			html.AStyle(style),
+			html.AData("pui-slider-tick", formatNumber(t.Value)),
+			html.Span(html.AClass(orientationClass(p.Orientation, "h-1.5 w-px bg-border", "h-px w-1.5 bg-border"))),
+			html.Span(html.AClass("whitespace-nowrap"), html.Text(t.Label)),
+		))
+	}
+
+	for _, a := range args {
+		a.ApplyDiv(attrs, children)
+	}
+}
+
+// Ticks renders a labelled scale aligned with the thumb positions of a slider with the
+// same bounds.
+func Ticks(args ...html.DivArg) html.Node {
+	var (
+		props TicksProps
+		rest  []html.DivArg
+	)
+
+	for _, a := range args {
+		if v, ok := a.(TicksProps); ok {
+			props = v
+		} else {
+			rest = append(rest, a)
+		}
+	}
+
+	return html.Div(append([]html.DivArg{props}, rest...)...)
+}
+
+// ApplyDiv implements the html.DivArg interface for RangeProps.
+func (p RangeProps) ApplyDiv(attrs *html.DivAttrs, children *[]html.Component) {
+	args := []html.DivArg{
+		html.AId(p.ID),
+		html.AClass(html.ClassMerge(
+			orientationClass(p.Orientation, "w-full space-y-2", "inline-flex flex-col gap-2"),
+			p.Class,
+		)),
+		html.ACustom("role", "group"),
+		html.AData("pui-slider-range", ""),
+		html.AData("pui-slider-format", p.format().json()),
+	}
+
+	if p.Label != "" {
+		args = append(args, html.AAria("label", p.Label))
+	}
+
+	if p.MinDistance > 0 {
+		args = append(args, html.AData("pui-slider-min-distance", formatNumber(p.MinDistance)))
+	}
+
+	if p.Orientation == OrientationVertical {
+		args = append(args, html.AData("pui-slider-orientation", string(OrientationVertical)))
+	}
+
+	for _, a := range p.Attrs {
+		args = append(args, a)
+	}
+
+	for _, a := range args {
+		a.ApplyDiv(attrs, children)
+	}
+}
+
+// Range renders a two-thumb slider made of two native range inputs sharing one track,
+// so each thumb keeps native keyboard support and submits its own form value.
+func Range(args ...html.DivArg) html.Node {
+	var (
+		props RangeProps
+		rest  []html.DivArg
+	)
+
+	for _, a := range args {
+		if v, ok := a.(RangeProps); ok {
+			props = v
+		} else {
+			rest = append(rest, a)
+		}
+	}
+
+	if props.ID == "" {
+		props.ID = randomID("slider-range")
+	}
+
+	min, max := bounds(props.Min, props.Max, props.HasMax)
+	low, high := props.values()
+	vertical := props.Orientation == OrientationVertical
+
+	fillStyle := "left: " + position(percent(low, min, max)) + "; right: calc(100% - " + position(percent(high, min, max)) + ");"
+	if vertical {
+		fillStyle = "bottom: " + position(percent(low, min, max)) + "; top: calc(100% - " + position(percent(high, min, max)) + ");"
+	}
+
+	track := html.Div(
+		html.AClass(html.ClassMerge(
+			"relative",
+			orientationClass(props.Orientation, "h-4 w-full", "h-48 w-4"),
+		)),
+		html.Div(
+			html.AClass(orientationClass(props.Orientation,
+				"absolute inset-x-0 top-1/2 h-2 -translate-y-1/2 rounded-full bg-muted/70",
+				"absolute inset-y-0 left-1/2 w-2 -translate-x-1/2 rounded-full bg-muted/70",
+			)),
+			html.Div(
+				html.AClass(html.ClassMerge(
+					"absolute rounded-full bg-primary",
+					orientationClass(props.Orientation, "h-full", "w-full"),
+				)),
+				html.AStyle(fillStyle),
+				html.AData("pui-slider-fill", ""),
+			),
+		),
+		thumb(props, "low", low),
+		thumb(props, "high", high),
+	)
+
+	content := []html.DivArg{props}
+
+	if props.ShowValue {
+		header := []html.DivArg{html.AClass("flex items-center justify-between gap-4 text-sm")}
+		if props.Label != "" {
+			header = append(header, html.Span(html.AClass("font-medium"), html.Text(props.Label)))
+		}
+
+		header = append(header, html.Span(
+			html.AClass("font-semibold tabular-nums text-muted-foreground"),
+			html.AData("pui-slider-value", ""),
+			html.AData("pui-slider-value-for", props.ID),
+			html.Text(RangeText(props)),
+		))
+
+		content = append(content, html.Div(header...))
+	}
+
+	body := []html.DivArg{html.AClass(orientationClass(props.Orientation, "space-y-1", "flex gap-2")), track}
+	if len(props.Ticks) > 0 {
+		body = append(body, Ticks(TicksProps{Min: props.Min, Max: props.Max, HasMax: props.HasMax, Ticks: props.Ticks, Orientation: props.Orientation}))
+	}
+
+	content = append(content, html.Div(body...))
+
+	return html.Div(append(content, rest...)...).WithAssets("", sliderJS, "ui-slider")
+}
+
+func thumb(p RangeProps, which string, value float64) html.Node {
+	min, max := bounds(p.Min, p.Max, p.HasMax)
+	minName, maxName := p.names()
+
+	name, label := minName, "Minimum"
+	if which == "high" {
+		name, label = maxName, "Maximum"
+	}
+
+	if p.Label != "" {
+		label += " " + p.Label
+	}
+
+	args := []html.InputArg{
+		html.AId(p.ID + "-" + which),
+		html.AType("range"),
+		html.AClass(html.ClassMerge(
+			"pointer-events-none absolute inset-0 h-full w-full appearance-none bg-transparent focus-visible:outline-none",
+			"[&::-webkit-slider-thumb]:pointer-events-auto [&::-webkit-slider-thumb]:cursor-grab [&::-moz-range-thumb]:pointer-events-auto [&::-moz-range-thumb]:cursor-grab",
+			"[&:focus-visible::-webkit-slider-thumb]:ring-2 [&:focus-visible::-webkit-slider-thumb]:ring-ring/50 [&:focus-visible::-moz-range-thumb]:ring-2 [&:focus-visible::-moz-range-thumb]:ring-ring/50",
+			thumbClass,
+			"data-[pui-slider-active=true]:z-10 disabled:opacity-60",
+			orientationClass(p.Orientation, "", verticalClass),
+		)),
+		html.AMin(formatNumber(min)),
+		html.AMax(formatNumber(max)),
+		html.AStep(formatNumber(p.step())),
+		html.AValue(formatNumber(value)),
+		html.AAria("label", label),
+		html.AAria("valuetext", p.format().Format(value)),
+		html.AData("pui-slider-thumb", which),
+	}
+
+	if name != "" {
+		args = append(args, html.AName(name))
+	}
+
+	if p.Orientation == OrientationVertical {
+		args = append(args, html.AAria("orientation", "vertical"))
+	}
+
+	if p.Disabled {
+		args = append(args, html.ADisabled())
+	}
+
+	// Keep the low thumb reachable when both sit at the top of the track
+	low, high := p.values()
+	if which == "low" && low == high && low > (min+max)/2 {
+		args = append(args, html.AData("pui-slider-active", "true"))
+	}
+
+	return html.Input(args...)
+}
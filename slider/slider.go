@@ -4,7 +4,6 @@ import (
 	"crypto/rand"
 	_ "embed"
 	"encoding/hex"
-	"strconv"
 
 	"github.com/plainkit/html"
 	"github.com/plainkit/ui/internal/styles"
@@ -16,16 +15,25 @@ type Props struct {
 	Attrs []html.Global
 }
 
+type Orientation string
+
+const (
+	OrientationHorizontal Orientation = "horizontal"
+	OrientationVertical   Orientation = "vertical"
+)
+
 type InputProps struct {
-	ID       string
-	Class    string
-	Attrs    []html.Global
-	Name     string
-	Min      int
-	Max      int
-	Step     int
-	Value    int
-	Disabled bool
+	ID          string
+	Class       string
+	Attrs       []html.Global
+	Name        string
+	Min         float64
+	Max         float64
+	Step        float64 // Fractional steps such as 0.5 are supported
+	Value       float64
+	Disabled    bool
+	Orientation Orientation
+	Format      *Format // Formats the value in Value outputs and for screen readers
 }
 
 type ValueProps struct {
@@ -69,15 +77,10 @@ func (p InputProps) ApplyInput(attrs *html.InputAttrs, children *[]html.Componen
 		html.AId(id),
 		html.AType("range"),
 		html.AClass(html.ClassMerge(
-			"w-full appearance-none cursor-pointer",
-			"h-2 rounded-full bg-muted/70",
+			"appearance-none cursor-pointer rounded-full bg-muted/70",
+			orientationClass(p.Orientation, "h-2 w-full", "h-48 w-2 "+verticalClass),
 			"focus-visible:outline-none focus-visible:ring-2 focus-visible:ring-ring/40 focus-visible:ring-offset-2 focus-visible:ring-offset-background",
-			"[&::-webkit-slider-thumb]:appearance-none [&::-webkit-slider-thumb]:size-4",
-			"[&::-webkit-slider-thumb]:rounded-full [&::-webkit-slider-thumb]:bg-primary [&::-webkit-slider-thumb]:shadow-md",
-			"[&::-webkit-slider-thumb]:hover:bg-primary/90",
-			"[&::-moz-range-thumb]:size-4 [&::-moz-range-thumb]:border-0",
-			"[&::-moz-range-thumb]:rounded-full [&::-moz-range-thumb]:bg-primary [&::-moz-range-thumb]:shadow-md",
-			"[&::-moz-range-thumb]:hover:bg-primary/90",
+			thumbClass,
 			"disabled:cursor-not-allowed disabled:opacity-60",
 			p.Class,
 		)),
@@ -88,25 +91,39 @@ func (p InputProps) ApplyInput(attrs *html.InputAttrs, children *[]html.Componen
 	}
 
 	if p.Value != 0 {
-		args = append(args, html.AValue(strconv.Itoa(p.Value)))
+		args = append(args, html.AValue(formatNumber(p.Value)))
 	}
 
 	if p.Min != 0 {
-		args = append(args, html.AMin(strconv.Itoa(p.Min)))
+		args = append(args, html.AMin(formatNumber(p.Min)))
 	}
 
 	if p.Max != 0 {
-		args = append(args, html.AMax(strconv.Itoa(p.Max)))
+		args = append(args, html.AMax(formatNumber(p.Max)))
 	}
 
 	if p.Step != 0 {
-		args = append(args, html.AStep(strconv.Itoa(p.Step)))
+		args = append(args, html.AStep(formatNumber(p.Step)))
 	}
 
 	if p.Disabled {
 		args = append(args, html.ADisabled())
 	}
 
+	if p.Orientation == OrientationVertical {
+		args = append(args, html.AAria("orientation", "vertical"))
+	}
+
+	if p.Format != nil {
+		f := p.Format.withStep(p.Step)
+		args = append(args, html.AData("pui-slider-format", f.json()))
+
+		// Without a value the browser picks the midpoint; slider.js fills it in then
+		if p.Value != 0 {
+			args = append(args, html.AAria("valuetext", f.Format(p.Value)))
+		}
+	}
+
 	for _, a := range p.Attrs {
 		args = append(args, a)
 	}
@@ -116,6 +133,25 @@ func (p InputProps) ApplyInput(attrs *html.InputAttrs, children *[]html.Componen
 	}
 }
 
+// thumbClass styles the native thumb of every range input in the package.
+const thumbClass = "[&::-webkit-slider-thumb]:appearance-none [&::-webkit-slider-thumb]:size-4 " +
+	"[&::-webkit-slider-thumb]:rounded-full [&::-webkit-slider-thumb]:bg-primary [&::-webkit-slider-thumb]:shadow-md " +
+	"[&::-webkit-slider-thumb]:hover:bg-primary/90 " +
+	"[&::-moz-range-thumb]:size-4 [&::-moz-range-thumb]:border-0 " +
+	"[&::-moz-range-thumb]:rounded-full [&::-moz-range-thumb]:bg-primary [&::-moz-range-thumb]:shadow-md " +
+	"[&::-moz-range-thumb]:hover:bg-primary/90"
+
+// verticalClass turns a native range input upright with its minimum at the bottom.
+const verticalClass = "[writing-mode:vertical-lr] [direction:rtl]"
+
+func orientationClass(o Orientation, horizontal, vertical string) string {
+	if o == OrientationVertical {
+		return vertical
+	}
+
+	return horizontal
+}
+
 func (p ValueProps) ApplySpan(attrs *html.SpanAttrs, children *[]html.Component) {
 	if p.For == "" {
 		// Apply error styling
@@ -1,20 +1,164 @@
 (function () {
   if (typeof document === "undefined") return;
-  function updateValue(input) {
-    var wrapper = input.closest("[data-pui-slider-wrapper]");
-    if (!wrapper) return;
-    var valueEls = wrapper.querySelectorAll(
-      '[data-pui-slider-value][data-pui-slider-value-for="' + input.id + '"]',
+
+  // Mirrors Format.Format in format.go
+  function format(value, f) {
+    if (!f) return String(value);
+    var decimals = Math.max(f.decimals || 0, 0);
+    var scale = Math.pow(10, decimals);
+    var rounded = Math.round(Math.abs(value) * scale) / scale;
+    var s = rounded.toFixed(decimals);
+    if (f.group) {
+      var parts = s.split(".");
+      parts[0] = parts[0].replace(/\B(?=(\d{3})+(?!\d))/g, ",");
+      s = parts.join(".");
+    }
+    var sign = value < 0 && rounded !== 0 ? "-" : "";
+    return sign + (f.prefix || "") + s + (f.suffix || "");
+  }
+
+  function readFormat(el) {
+    var raw = el.getAttribute("data-pui-slider-format");
+    if (!raw) return null;
+    try {
+      return JSON.parse(raw);
+    } catch (e) {
+      return null;
+    }
+  }
+
+  // Mirrors position in range.go
+  function position(pct) {
+    var offset = 0.5 - pct / 100;
+    return "calc(" + pct + "% + " + offset + "rem)";
+  }
+
+  function percent(input) {
+    var min = parseFloat(input.min || "0");
+    var max = parseFloat(input.max || "100");
+    if (max <= min) return 0;
+    return ((parseFloat(input.value) - min) / (max - min)) * 100;
+  }
+
+  function valueElements(id, scope) {
+    var root = (scope && scope.closest("[data-pui-slider-wrapper]")) || document;
+    return root.querySelectorAll(
+      '[data-pui-slider-value][data-pui-slider-value-for="' + CSS.escape(id) + '"]',
     );
-    valueEls.forEach(function (el) {
-      el.textContent = input.value;
+  }
+
+  function updateValue(input) {
+    var f = readFormat(input);
+    var text = format(parseFloat(input.value), f);
+    if (f) input.setAttribute("aria-valuetext", text);
+    if (!input.id) return;
+    valueElements(input.id, input).forEach(function (el) {
+      el.textContent = text;
     });
   }
+
+  function thumbs(range) {
+    return {
+      low: range.querySelector('[data-pui-slider-thumb="low"]'),
+      high: range.querySelector('[data-pui-slider-thumb="high"]'),
+    };
+  }
+
+  function updateRange(range, moved) {
+    var t = thumbs(range);
+    if (!t.low || !t.high) return;
+
+    // Keep the thumbs MinDistance apart by holding back the one being dragged
+    var distance = parseFloat(range.getAttribute("data-pui-slider-min-distance") || "0");
+    var low = parseFloat(t.low.value);
+    var high = parseFloat(t.high.value);
+    if (high - low < distance) {
+      if (moved === t.high) {
+        t.high.value = String(low + distance);
+        if (parseFloat(t.high.value) - low < distance) t.low.value = String(parseFloat(t.high.value) - distance);
+      } else {
+        t.low.value = String(high - distance);
+        if (high - parseFloat(t.low.value) < distance) t.high.value = String(parseFloat(t.low.value) + distance);
+      }
+      low = parseFloat(t.low.value);
+      high = parseFloat(t.high.value);
+    }
+
+    // The thumb moved last stays on top, so overlapping thumbs remain draggable apart
+    if (moved) {
+      t.low.setAttribute("data-pui-slider-active", String(moved === t.low));
+      t.high.setAttribute("data-pui-slider-active", String(moved === t.high));
+    }
+
+    var fill = range.querySelector("[data-pui-slider-fill]");
+    if (fill) {
+      var start = position(percent(t.low));
+      var end = "calc(100% - " + position(percent(t.high)) + ")";
+      if (range.getAttribute("data-pui-slider-orientation") === "vertical") {
+        fill.style.bottom = start;
+        fill.style.top = end;
+      } else {
+        fill.style.left = start;
+        fill.style.right = end;
+      }
+    }
+
+    var f = readFormat(range);
+    t.low.setAttribute("aria-valuetext", format(low, f));
+    t.high.setAttribute("aria-valuetext", format(high, f));
+
+    var text = format(low, f) + " – " + format(high, f);
+    if (range.id) {
+      valueElements(range.id, range).forEach(function (el) {
+        el.textContent = text;
+      });
+    }
+
+    range.dispatchEvent(
+      new CustomEvent("pui-slider:change", {
+        bubbles: true,
+        detail: { low: low, high: high },
+      }),
+    );
+  }
+
   document.addEventListener("input", function (event) {
     var target = event.target;
-    if (target && target.matches("[data-pui-slider-input]")) {
+    if (!target || !target.matches) return;
+
+    if (target.matches("[data-pui-slider-input]")) {
       updateValue(target);
+      return;
+    }
+
+    if (target.matches("[data-pui-slider-thumb]")) {
+      var range = target.closest("[data-pui-slider-range]");
+      if (range) updateRange(range, target);
     }
   });
+
+  document.addEventListener("reset", function (event) {
+    var form = event.target;
+    if (!(form instanceof HTMLFormElement)) return;
+
+    // Inputs hold their default values only after the reset completes
+    setTimeout(function () {
+      form.querySelectorAll("[data-pui-slider-input]").forEach(updateValue);
+      form.querySelectorAll("[data-pui-slider-range]").forEach(function (range) {
+        updateRange(range, null);
+      });
+    });
+  });
+
   document.querySelectorAll("[data-pui-slider-input]").forEach(updateValue);
+
+  // Expose public API
+  window.tui = window.tui || {};
+  window.tui.slider = {
+    format: format,
+    update: function (el) {
+      if (el.matches("[data-pui-slider-range]")) updateRange(el, null);
+      else updateValue(el);
+    },
+  };
 })();
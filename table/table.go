@@ -3,12 +3,14 @@ package table
 import (
 	"github.com/plainkit/html"
 	"github.com/plainkit/ui/internal/styles"
+	"github.com/plainkit/ui/virtual"
 )
 
 type Props struct {
-	ID    string
-	Class string
-	Attrs []html.Global
+	ID        string
+	Class     string
+	Attrs     []html.Global
+	MaxHeight string // Scroll the table inside its frame past this height, e.g. "480px"; the header stays pinned
 }
 
 type HeaderProps struct {
@@ -18,9 +20,10 @@ type HeaderProps struct {
 }
 
 type BodyProps struct {
-	ID    string
-	Class string
-	Attrs []html.Global
+	ID      string
+	Class   string
+	Attrs   []html.Global
+	Virtual virtual.Props // Only mount the rows scrolled into view; rows must share Virtual.ItemHeight
 }
 
 type FooterProps struct {
@@ -89,10 +92,20 @@ func Table(args ...html.TableArg) html.Node {
 		}
 	}
 
-	return html.Div(
-		html.AClass(styles.Surface("relative w-full overflow-hidden rounded-3xl")),
-		html.Table(append([]html.TableArg{props}, rest...)...),
-	)
+	frame := []html.DivArg{html.AClass(styles.Surface("relative w-full overflow-hidden rounded-3xl"))}
+
+	if props.MaxHeight != "" {
+		frame = []html.DivArg{
+			html.AClass(html.ClassMerge(
+				styles.Surface("relative w-full overflow-auto overscroll-contain rounded-3xl"),
+				"[&_thead]:sticky [&_thead]:top-0 [&_thead]:z-10",
+			)),
+			html.AStyle("max-height: " + props.MaxHeight),
+			html.AData("pui-virtual-viewport", ""),
+		}
+	}
+
+	return html.Div(append(frame, html.Table(append([]html.TableArg{props}, rest...)...))...)
 }
 
 func theadArgsFromProps(baseClass string, extra ...string) func(p HeaderProps) []html.TheadArg {
@@ -168,9 +181,43 @@ func Body(args ...html.TbodyArg) html.Node {
 		}
 	}
 
+	if props.Virtual.Enabled() {
+		return virtualBody(props, rest)
+	}
+
 	return html.Tbody(append([]html.TbodyArg{props}, rest...)...)
 }
 
+// virtualBody moves the rows into an inert source the client mounts from as the table scrolls.
+func virtualBody(props BodyProps, args []html.TbodyArg) html.Node {
+	var rows []html.DivArg
+
+	tbodyArgs := []html.TbodyArg{props}
+
+	for _, a := range args {
+		switch v := a.(type) {
+		case html.Node:
+			rows = append(rows, v)
+		case html.ChildOpt:
+			rows = append(rows, v)
+		default:
+			tbodyArgs = append(tbodyArgs, a)
+		}
+	}
+
+	if props.Virtual.Selector == "" {
+		props.Virtual.Selector = "tr"
+	}
+
+	for _, attr := range virtual.Attrs(props.Virtual) {
+		tbodyArgs = append(tbodyArgs, attr)
+	}
+
+	tbodyArgs = append(tbodyArgs, virtual.Source(rows...), html.Child(virtual.Assets()))
+
+	return html.Tbody(tbodyArgs...)
+}
+
 func tfootArgsFromProps(baseClass string, extra ...string) func(p FooterProps) []html.TfootArg {
 	return func(p FooterProps) []html.TfootArg {
 		args := []html.TfootArg{html.AClass(html.ClassMerge(append([]string{baseClass}, append(extra, p.Class)...)...))}
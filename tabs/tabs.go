@@ -7,12 +7,17 @@ import (
 
 	"github.com/plainkit/html"
 	"github.com/plainkit/ui/internal/styles"
+	"github.com/plainkit/ui/urlstate"
 )
 
 type Props struct {
 	ID    string
 	Class string
 	Attrs []html.Global
+	// Sync mirrors the selected tab into the URL. Mark the trigger and content whose Value
+	// equals Sync.ValueOr(r, Default) as active so links to a tab work without JavaScript.
+	Sync    urlstate.Sync
+	Default string // Tab selected when the URL names none; it is left out of the URL
 }
 
 type ListProps struct {
@@ -53,6 +58,14 @@ func tabsDivArgsFromProps(baseClass string, extra ...string) func(p Props) []htm
 			html.AId(p.ID),
 		}
 
+		if p.Default != "" {
+			args = append(args, html.AData("pui-tabs-default", p.Default))
+		}
+
+		for _, a := range p.Sync.Attrs() {
+			args = append(args, a)
+		}
+
 		for _, a := range p.Attrs {
 			args = append(args, a)
 		}
@@ -81,6 +94,8 @@ func Tabs(args ...html.DivArg) html.Node {
 		}
 	}
 
+	rest = append(rest, html.Child(urlstate.Assets()))
+
 	node := html.Div(append([]html.DivArg{props}, rest...)...)
 
 	return node.WithAssets("", tabsJS, "ui-tabs")
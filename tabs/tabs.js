@@ -22,7 +22,53 @@
       );
       panel.classList.toggle("hidden", !isActive);
     });
+    var root = document.getElementById(tabsId);
+    if (root) {
+      root.dispatchEvent(
+        new CustomEvent("pui-tabs:change", {
+          bubbles: true,
+          detail: { id: tabsId, value: value },
+        }),
+      );
+    }
+  }
+  // URL sync through urlstate.js, for tabs rendered with a Sync
+  var initial = new WeakMap();
+
+  function defaultValue(root) {
+    return root.getAttribute("data-pui-tabs-default") || initial.get(root) || "";
+  }
+
+  function syncUrl(tabsId, value) {
+    var root = document.getElementById(tabsId);
+    if (!root || !root.hasAttribute("data-pui-url-param") || !window.tui || !window.tui.url) return;
+    window.tui.url.set(root, value === defaultValue(root) ? "" : value);
+  }
+
+  function hasTab(tabsId, value) {
+    return !!document.querySelector(
+      '[data-pui-tabs-trigger][data-pui-tabs-id="' + tabsId + '"][data-pui-tabs-value="' + CSS.escape(value) + '"]',
+    );
   }
+
+  function activeValue(tabsId) {
+    var active = document.querySelector(
+      '[data-pui-tabs-trigger][data-pui-tabs-id="' + tabsId + '"][data-pui-tabs-state="active"]',
+    );
+    return active ? active.getAttribute("data-pui-tabs-value") : "";
+  }
+
+  // Select the tab named by the URL after Back, Forward or a hash change, and on load
+  document.addEventListener("pui-url:change", function () {
+    document.querySelectorAll("[data-pui-tabs][data-pui-url-param]").forEach(function (root) {
+      var tabsId = root.id;
+      if (!initial.has(root)) initial.set(root, activeValue(tabsId));
+
+      var value = window.tui.url.get(root) || defaultValue(root);
+      if (value && hasTab(tabsId, value) && value !== activeValue(tabsId)) activate(tabsId, value);
+    });
+  });
+
   document.addEventListener("click", function (event) {
     var target = event.target;
     if (target && target.nodeType === 3) target = target.parentElement;
@@ -33,5 +79,6 @@
     var value = trigger.getAttribute("data-pui-tabs-value");
     if (!tabsId || !value) return;
     activate(tabsId, value);
+    syncUrl(tabsId, value);
   });
 })();
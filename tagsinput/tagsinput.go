@@ -1,28 +1,64 @@
 package tagsinput
 
 import (
-	"crypto/rand"
 	_ "embed"
-	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
 
 	"github.com/plainkit/html"
 	"github.com/plainkit/icons/lucide"
 	"github.com/plainkit/ui/badge"
 	"github.com/plainkit/ui/input"
+	"github.com/plainkit/ui/internal/randomid"
 	"github.com/plainkit/ui/internal/styles"
+	"github.com/plainkit/ui/layer"
+)
+
+// DuplicatePolicy controls how a tag equal to an existing one is treated.
+type DuplicatePolicy string
+
+const (
+	DuplicatesIgnoreCase DuplicatePolicy = "ignore-case" // Reject tags equal to an existing one regardless of case
+	DuplicatesExact      DuplicatePolicy = "exact"       // Reject only exact repeats
+	DuplicatesAllow      DuplicatePolicy = "allow"
+)
+
+// QueryParam is the query string parameter carrying the typed text sent to SuggestURL.
+const QueryParam = "q"
+
+var (
+	// ErrTooMany is returned by Clean when more than MaxTags tags were submitted.
+	ErrTooMany = errors.New("tagsinput: too many tags")
+	// ErrPattern is returned by Clean when a tag does not match Pattern.
+	ErrPattern = errors.New("tagsinput: tag does not match the allowed pattern")
 )
 
 type Props struct {
-	ID          string
-	Name        string
-	Value       []string
-	Form        string
-	Placeholder string
-	Class       string
-	Attrs       []html.Global
-	HasError    bool
-	Disabled    bool
-	Readonly    bool
+	ID              string
+	Name            string
+	Value           []string
+	Form            string
+	Placeholder     string
+	Class           string
+	Attrs           []html.Global
+	HasError        bool
+	Disabled        bool
+	Readonly        bool
+	Suggestions     []string        // Values offered in a dropdown while typing
+	SuggestURL      string          // Endpoint returning rendered Suggestion items for the typed text
+	SuggestDebounce int             // Milliseconds to wait after typing before querying SuggestURL
+	MaxTags         int             // Maximum number of tags, 0 for no limit
+	Duplicates      DuplicatePolicy // Defaults to DuplicatesIgnoreCase
+	// Pattern is a regular expression every tag must match in full, e.g. "[a-z0-9-]+".
+	// Clean checks it with Go's regexp and the browser with a Unicode-aware RegExp, so use
+	// syntax both accept: classes, \d, \w, \s, \p{L} and the usual quantifiers, but no
+	// inline flags such as (?i) and no \A, \z or (?P<name>...).
+	Pattern    string
+	Delimiters string // Characters completing a tag and splitting pasted text (default ","); Enter always completes
 }
 
 func divArgsFromProps(baseClass string, extra ...string) func(p Props) []html.DivArg {
@@ -43,11 +79,11 @@ func divArgsFromProps(baseClass string, extra ...string) func(p Props) []html.Di
 func (p Props) ApplyDiv(attrs *html.DivAttrs, children *[]html.Component) {
 	id := p.ID
 	if id == "" {
-		id = randomID("tagsinput")
+		id = randomid.New("tagsinput")
 	}
 
 	containerClass := html.ClassMerge(
-		styles.Input("flex w-full flex-wrap items-center gap-2 rounded-2xl border border-input/60 bg-background/60 py-2 pl-3 pr-10 text-sm transition-[border,box-shadow]"),
+		styles.Input("relative flex w-full flex-wrap items-center gap-2 rounded-2xl border border-input/60 bg-background/60 py-2 pl-3 pr-10 text-sm transition-[border,box-shadow]"),
 		"min-h-[2.75rem] cursor-text",
 		func() string {
 			if p.Disabled {
@@ -71,8 +107,30 @@ func (p Props) ApplyDiv(attrs *html.DivAttrs, children *[]html.Component) {
 		html.AData("pui-tagsinput", ""),
 		html.AData("pui-tagsinput-name", p.Name),
 		html.AData("pui-tagsinput-form", p.Form),
+		html.AData("pui-tagsinput-duplicates", string(p.duplicates())),
+		html.AData("pui-tagsinput-delimiters", p.delimiters()),
 	}, args...)
 
+	if p.MaxTags > 0 {
+		args = append(args, html.AData("pui-tagsinput-max", strconv.Itoa(p.MaxTags)))
+	}
+
+	if p.Pattern != "" {
+		args = append(args, html.AData("pui-tagsinput-pattern", p.Pattern))
+	}
+
+	if p.SuggestURL != "" {
+		debounce := p.SuggestDebounce
+		if debounce <= 0 {
+			debounce = 200
+		}
+
+		args = append(args,
+			html.AData("pui-tagsinput-suggest-url", p.SuggestURL),
+			html.AData("pui-tagsinput-suggest-debounce", strconv.Itoa(debounce)),
+		)
+	}
+
 	// Add existing tags as children
 	tagChildren := make([]html.DivArg, 0, len(p.Value))
 	for _, tag := range p.Value {
@@ -108,6 +166,21 @@ func (p Props) ApplyDiv(attrs *html.DivAttrs, children *[]html.Component) {
 	tagsContainer := html.Div(tagsContainerArgs...)
 
 	// Text input
+	inputAttrs := []html.Global{
+		html.AData("pui-tagsinput-text-input", ""),
+	}
+
+	hasSuggestions := len(p.Suggestions) > 0 || p.SuggestURL != ""
+	if hasSuggestions {
+		inputAttrs = append(inputAttrs,
+			html.ACustom("role", "combobox"),
+			html.ACustom("autocomplete", "off"),
+			html.AAria("autocomplete", "list"),
+			html.AAria("expanded", "false"),
+			html.AAria("controls", id+"-suggestions"),
+		)
+	}
+
 	textInput := input.Input(input.Props{
 		ID:          id,
 		Class:       "min-h-0 grow border-0 bg-transparent px-0 py-1 text-sm shadow-none focus-visible:ring-0",
@@ -115,9 +188,7 @@ func (p Props) ApplyDiv(attrs *html.DivAttrs, children *[]html.Component) {
 		Placeholder: p.Placeholder,
 		Disabled:    p.Disabled,
 		Readonly:    p.Readonly,
-		Attrs: []html.Global{
-			html.AData("pui-tagsinput-text-input", ""),
-		},
+		Attrs:       inputAttrs,
 	})
 
 	// Add existing hidden inputs
@@ -144,6 +215,20 @@ func (p Props) ApplyDiv(attrs *html.DivAttrs, children *[]html.Component) {
 		hiddenInputsContainer,
 	)
 
+	if hasSuggestions {
+		suggestionArgs := []html.DivArg{
+			html.AId(id + "-suggestions"),
+			html.AClass(styles.Panel("absolute left-0 top-full z-50 mt-2 hidden max-h-60 w-full flex-col overflow-y-auto p-1")),
+			html.ACustom("role", "listbox"),
+			html.AData("pui-tagsinput-suggestions", ""),
+		}
+		for _, value := range p.Suggestions {
+			suggestionArgs = append(suggestionArgs, Suggestion(value))
+		}
+
+		*children = append(*children, html.Div(suggestionArgs...))
+	}
+
 	for _, a := range args {
 		a.ApplyDiv(attrs, children)
 	}
@@ -164,16 +249,121 @@ func TagsInput(args ...html.DivArg) html.Node {
 		}
 	}
 
+	rest = append(rest, html.Child(layer.Assets()))
+
 	return html.Div(append([]html.DivArg{props}, rest...)...).WithAssets("", tagsinputJS, "ui-tagsinput")
 }
 
-func randomID(prefix string) string {
-	buf := make([]byte, 4)
-	if _, err := rand.Read(buf); err != nil {
-		return prefix + "-id"
+// Suggestion renders one entry of the suggestion dropdown.
+func Suggestion(value string) html.Node {
+	return html.Div(
+		html.AClass(html.ClassMerge(
+			styles.InteractiveGhost("flex w-full cursor-pointer select-none items-center justify-start rounded-lg px-3 py-2 text-left text-sm"),
+			"data-[pui-tagsinput-active=true]:bg-muted/70 data-[pui-tagsinput-active=true]:text-foreground",
+		)),
+		html.ACustom("role", "option"),
+		html.AAria("selected", "false"),
+		html.AData("pui-tagsinput-suggestion", value),
+		html.Text(value),
+	)
+}
+
+// SuggestFunc resolves suggestions for the text typed into a tags input.
+type SuggestFunc func(r *http.Request, query string) ([]string, error)
+
+// SuggestHandler serves the suggestions requested by Props.SuggestURL.
+func SuggestHandler(fn SuggestFunc) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		values, err := fn(r, strings.TrimSpace(r.URL.Query().Get(QueryParam)))
+		if err != nil {
+			http.Error(w, "could not load suggestions", http.StatusInternalServerError)
+			return
+		}
+
+		var sb strings.Builder
+		for _, value := range values {
+			sb.WriteString(html.Render(Suggestion(value)))
+		}
+
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Header().Set("Cache-Control", "no-store")
+
+		_, _ = w.Write([]byte(sb.String()))
+	})
+}
+
+// Clean applies the rules enforced by the client to submitted tags: values are trimmed,
+// empty ones dropped, duplicates removed per Duplicates, and Pattern and MaxTags checked.
+func Clean(p Props, values []string) ([]string, error) {
+	var pattern *regexp.Regexp
+
+	if p.Pattern != "" {
+		re, err := regexp.Compile("^(?:" + p.Pattern + ")$")
+		if err != nil {
+			return nil, err
+		}
+
+		pattern = re
+	}
+
+	var (
+		tags []string
+		seen = map[string]bool{}
+	)
+
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			continue
+		}
+
+		if pattern != nil && !pattern.MatchString(value) {
+			return nil, fmt.Errorf("%w: %q", ErrPattern, value)
+		}
+
+		key := value
+		if p.duplicates() == DuplicatesIgnoreCase {
+			key = strings.ToLower(value)
+		}
+
+		if p.duplicates() != DuplicatesAllow && seen[key] {
+			continue
+		}
+
+		seen[key] = true
+		tags = append(tags, value)
+	}
+
+	if p.MaxTags > 0 && len(tags) > p.MaxTags {
+		return nil, ErrTooMany
+	}
+
+	return tags, nil
+}
+
+// Parse reads the tags submitted under p.Name and cleans them with Clean.
+func Parse(r *http.Request, p Props) ([]string, error) {
+	if err := r.ParseForm(); err != nil {
+		return nil, err
+	}
+
+	return Clean(p, r.Form[p.Name])
+}
+
+func (p Props) duplicates() DuplicatePolicy {
+	if p.Duplicates == "" {
+		return DuplicatesIgnoreCase
+	}
+
+	return p.Duplicates
+}
+
+func (p Props) delimiters() string {
+	if p.Delimiters == "" {
+		return ","
 	}
 
-	return prefix + "-" + hex.EncodeToString(buf)
+	return p.Delimiters
 }
 
 //go:embed tagsinput.js
@@ -1,6 +1,9 @@
 (function () {
   "use strict";
 
+  const timers = new WeakMap();
+  const controllers = new WeakMap();
+
   // Create tag chip element
   function createTagChip(tagValue, isDisabled) {
     const tagChip = document.createElement("div");
@@ -8,32 +11,120 @@
     tagChip.className =
       "inline-flex items-center gap-2 rounded-md border px-2.5 py-0.5 text-xs font-semibold transition-colors focus:outline-hidden focus:ring-2 focus:ring-ring focus:ring-offset-2 border-transparent bg-primary text-primary-foreground";
 
-    tagChip.innerHTML =
-      "<span>" +
-      tagValue +
-      "</span>" +
+    const label = document.createElement("span");
+    label.textContent = tagValue;
+    tagChip.appendChild(label);
+
+    tagChip.insertAdjacentHTML(
+      "beforeend",
       '<button type="button"' +
-      ' class="ml-1 text-current hover:text-destructive disabled:opacity-50 disabled:cursor-not-allowed cursor-pointer"' +
-      ' data-pui-tagsinput-remove=""' +
-      (isDisabled ? " disabled" : "") +
-      ">" +
-      '<svg xmlns="http://www.w3.org/2000/svg" class="h-3 w-3 pointer-events-none" fill="none" viewBox="0 0 24 24" stroke="currentColor" stroke-width="2">' +
-      '<path stroke-linecap="round" stroke-linejoin="round" d="M6 18L18 6M6 6l12 12" />' +
-      "</svg>" +
-      "</button>";
+        ' class="ml-1 text-current hover:text-destructive disabled:opacity-50 disabled:cursor-not-allowed cursor-pointer"' +
+        ' data-pui-tagsinput-remove=""' +
+        (isDisabled ? " disabled" : "") +
+        ">" +
+        '<svg xmlns="http://www.w3.org/2000/svg" class="h-3 w-3 pointer-events-none" fill="none" viewBox="0 0 24 24" stroke="currentColor" stroke-width="2">' +
+        '<path stroke-linecap="round" stroke-linejoin="round" d="M6 18L18 6M6 6l12 12" />' +
+        "</svg>" +
+        "</button>",
+    );
 
     return tagChip;
   }
 
-  // Add tag
-  function addTag(container, value) {
-    const textInput = container.querySelector(
-      "[data-pui-tagsinput-text-input]",
+  function getTextInput(container) {
+    return container.querySelector("[data-pui-tagsinput-text-input]");
+  }
+
+  function hiddenInputs(container) {
+    return Array.from(
+      container.querySelectorAll(
+        '[data-pui-tagsinput-hidden-inputs] input[type="hidden"]',
+      ),
+    );
+  }
+
+  function tagValues(container) {
+    return hiddenInputs(container).map((input) => input.value);
+  }
+
+  function delimiters(container) {
+    const value = container.getAttribute("data-pui-tagsinput-delimiters");
+    return value === null ? "," : value;
+  }
+
+  // Splits on the configured delimiters and on line breaks from pasted text
+  function splitter(container) {
+    const chars = delimiters(container).replace(/[\\\]^-]/g, "\\$&");
+    return new RegExp("[" + chars + "\\r\\n\\t]+");
+  }
+
+  function isDuplicate(container, value) {
+    const policy =
+      container.getAttribute("data-pui-tagsinput-duplicates") || "ignore-case";
+    if (policy === "allow") return false;
+
+    const normalize = (v) => (policy === "exact" ? v : v.toLowerCase());
+    return tagValues(container).some((v) => normalize(v) === normalize(value));
+  }
+
+  // Returns why value cannot be added, or an empty string
+  function validate(container, value) {
+    const max = parseInt(container.getAttribute("data-pui-tagsinput-max")) || 0;
+    if (max > 0 && tagValues(container).length >= max) return "max";
+
+    const pattern = container.getAttribute("data-pui-tagsinput-pattern");
+    if (pattern) {
+      try {
+        // Unicode mode reads \p{L} and astral characters the way Go's regexp does
+        if (!new RegExp("^(?:" + pattern + ")$", "u").test(value)) return "pattern";
+      } catch (err) {
+        // An invalid pattern is reported by Clean on the server
+      }
+    }
+
+    if (isDuplicate(container, value)) return "duplicate";
+
+    return "";
+  }
+
+  function setInvalid(container, invalid) {
+    const textInput = getTextInput(container);
+    container.classList.toggle("border-destructive", invalid);
+    container.classList.toggle("ring-destructive/30", invalid);
+    if (textInput) {
+      invalid
+        ? textInput.setAttribute("aria-invalid", "true")
+        : textInput.removeAttribute("aria-invalid");
+    }
+  }
+
+  function updateFull(container) {
+    const max = parseInt(container.getAttribute("data-pui-tagsinput-max")) || 0;
+    container.toggleAttribute(
+      "data-pui-tagsinput-full",
+      max > 0 && tagValues(container).length >= max,
     );
-    if (textInput && textInput.hasAttribute("disabled")) return;
+  }
+
+  // Add tag, returns whether it was added
+  function addTag(container, value) {
+    const textInput = getTextInput(container);
+    if (textInput && textInput.hasAttribute("disabled")) return false;
 
     const tagValue = value.trim();
-    if (!tagValue) return;
+    if (!tagValue) return false;
+
+    const reason = validate(container, tagValue);
+    if (reason) {
+      setInvalid(container, reason !== "duplicate");
+      container.dispatchEvent(
+        new CustomEvent("pui-tagsinput:invalid", {
+          bubbles: true,
+          detail: { value: tagValue, reason: reason },
+        }),
+      );
+      return reason === "duplicate";
+    }
 
     const hiddenInputsContainer = container.querySelector(
       "[data-pui-tagsinput-hidden-inputs]",
@@ -44,17 +135,6 @@
     const name = container.getAttribute("data-pui-tagsinput-name");
     const form = container.getAttribute("data-pui-tagsinput-form");
 
-    // Check for duplicates
-    const existingTags = hiddenInputsContainer.querySelectorAll(
-      'input[type="hidden"]',
-    );
-    for (const t of existingTags) {
-      if (t.value.toLowerCase() === tagValue.toLowerCase()) {
-        textInput.value = "";
-        return;
-      }
-    }
-
     // Add tag chip and hidden input
     const tagChip = createTagChip(
       tagValue,
@@ -72,7 +152,30 @@
 
     hiddenInputsContainer.appendChild(hiddenInput);
 
-    textInput.value = "";
+    setInvalid(container, false);
+    updateFull(container);
+    container.dispatchEvent(
+      new CustomEvent("pui-tagsinput:add", {
+        bubbles: true,
+        detail: { value: tagValue },
+      }),
+    );
+
+    return true;
+  }
+
+  // Add every delimited part of text, keeping rejected parts in the input
+  function commit(container, text) {
+    const textInput = getTextInput(container);
+    const rejected = text
+      .split(splitter(container))
+      .filter((part) => part.trim() && !addTag(container, part));
+
+    if (textInput) {
+      textInput.value = rejected.join(delimiters(container).charAt(0) || " ");
+    }
+
+    closeSuggestions(container);
   }
 
   // Remove tag
@@ -81,30 +184,215 @@
     if (!tagChip) return;
 
     const container = tagChip.closest("[data-pui-tagsinput]");
-    const tagValue = tagChip.querySelector("span").textContent.trim();
-    const hiddenInputsContainer = container.querySelector(
-      "[data-pui-tagsinput-hidden-inputs]",
+    const chips = Array.from(
+      container.querySelectorAll("[data-pui-tagsinput-chip]"),
     );
+    const hiddenInput = hiddenInputs(container)[chips.indexOf(tagChip)];
+    const tagValue = hiddenInput
+      ? hiddenInput.value
+      : tagChip.querySelector("span").textContent.trim();
 
-    const hiddenInput = hiddenInputsContainer.querySelector(
-      'input[type="hidden"][value="' + tagValue + '"]',
-    );
     if (hiddenInput) hiddenInput.remove();
-
     tagChip.remove();
+
+    updateFull(container);
+    container.dispatchEvent(
+      new CustomEvent("pui-tagsinput:remove", {
+        bubbles: true,
+        detail: { value: tagValue },
+      }),
+    );
+  }
+
+  function getPanel(container) {
+    return container.querySelector("[data-pui-tagsinput-suggestions]");
+  }
+
+  function suggestions(container) {
+    const panel = getPanel(container);
+    return panel
+      ? Array.from(panel.querySelectorAll("[data-pui-tagsinput-suggestion]"))
+      : [];
+  }
+
+  function visibleSuggestions(container) {
+    return suggestions(container).filter((item) => !item.hidden);
+  }
+
+  function setActive(container, item) {
+    const textInput = getTextInput(container);
+
+    suggestions(container).forEach((el) => {
+      const active = el === item;
+      el.setAttribute("data-pui-tagsinput-active", String(active));
+      el.setAttribute("aria-selected", String(active));
+    });
+
+    if (!item) {
+      textInput?.removeAttribute("aria-activedescendant");
+      return;
+    }
+
+    if (!item.id) {
+      item.id = "pui-tagsinput-option-" + Math.random().toString(36).slice(2, 10);
+    }
+    textInput?.setAttribute("aria-activedescendant", item.id);
+    item.scrollIntoView({ block: "nearest" });
+  }
+
+  // The shared layer manager from layer.js, which routes ESC and outside clicks
+  function layers() {
+    return window.tui && window.tui.layers;
+  }
+
+  function showPanel(container, open) {
+    const panel = getPanel(container);
+    if (!panel) return;
+
+    // Open suggestions are the topmost layer, so ESC closes them before a dialog
+    // the input sits in
+    if (open && !layers()?.isOpen(panel)) {
+      layers()?.open(panel, {
+        anchors: [container],
+        escape: () => closeSuggestions(container),
+        outside: () => closeSuggestions(container),
+      });
+    } else if (!open) {
+      layers()?.close(panel);
+    }
+
+    panel.classList.toggle("hidden", !open);
+    panel.classList.toggle("flex", open);
+    getTextInput(container)?.setAttribute("aria-expanded", String(open));
+
+    if (!open) setActive(container, null);
+  }
+
+  function closeSuggestions(container) {
+    clearTimeout(timers.get(container));
+    controllers.get(container)?.abort();
+    showPanel(container, false);
+  }
+
+  // Hide suggestions that do not match or are already added
+  function filterSuggestions(container, query) {
+    query = query.trim().toLowerCase();
+
+    suggestions(container).forEach((item) => {
+      const value = item.getAttribute("data-pui-tagsinput-suggestion");
+      item.hidden =
+        (!!query && !value.toLowerCase().includes(query)) ||
+        isDuplicate(container, value);
+    });
+
+    const visible = visibleSuggestions(container);
+    showPanel(container, visible.length > 0);
+    setActive(container, null);
+  }
+
+  function remoteSuggest(container, query) {
+    const url = container.getAttribute("data-pui-tagsinput-suggest-url");
+    const panel = getPanel(container);
+    if (!url || !panel) return;
+
+    clearTimeout(timers.get(container));
+    controllers.get(container)?.abort();
+
+    if (!query.trim()) {
+      panel.innerHTML = "";
+      showPanel(container, false);
+      return;
+    }
+
+    const delay =
+      parseInt(container.getAttribute("data-pui-tagsinput-suggest-debounce")) ||
+      200;
+
+    timers.set(
+      container,
+      setTimeout(() => {
+        const controller = new AbortController();
+        controllers.set(container, controller);
+
+        const target = new URL(url, window.location.href);
+        target.searchParams.set("q", query.trim());
+
+        fetch(target, {
+          signal: controller.signal,
+          headers: { Accept: "text/html" },
+        })
+          .then((res) => (res.ok ? res.text() : ""))
+          .then((markup) => {
+            panel.innerHTML = markup;
+            filterSuggestions(container, "");
+          })
+          .catch(() => {});
+      }, delay),
+    );
+  }
+
+  function updateSuggestions(container, query) {
+    if (container.hasAttribute("data-pui-tagsinput-suggest-url")) {
+      remoteSuggest(container, query);
+    } else if (getPanel(container)) {
+      filterSuggestions(container, query);
+    }
+  }
+
+  function moveActive(container, delta) {
+    const items = visibleSuggestions(container);
+    if (items.length === 0) return;
+
+    const current = items.findIndex(
+      (item) => item.getAttribute("data-pui-tagsinput-active") === "true",
+    );
+    let next = current + delta;
+    if (current < 0) next = delta > 0 ? 0 : items.length - 1;
+    if (next < 0) next = items.length - 1;
+    if (next >= items.length) next = 0;
+
+    setActive(container, items[next]);
+  }
+
+  function chooseSuggestion(container, item) {
+    const textInput = getTextInput(container);
+    if (addTag(container, item.getAttribute("data-pui-tagsinput-suggestion"))) {
+      if (textInput) textInput.value = "";
+    }
+
+    closeSuggestions(container);
+    textInput?.focus();
   }
 
   // Event delegation
   document.addEventListener("keydown", (e) => {
-    const textInput = e.target.closest("[data-pui-tagsinput-text-input]");
+    const textInput = e.target.closest?.("[data-pui-tagsinput-text-input]");
     if (!textInput) return;
 
     const container = textInput.closest("[data-pui-tagsinput]");
     if (!container) return;
 
-    if (e.key === "Enter" || e.key === ",") {
+    const panel = getPanel(container);
+    const open = panel && !panel.classList.contains("hidden");
+
+    if (e.key === "ArrowDown" || e.key === "ArrowUp") {
+      if (!panel) return;
+
       e.preventDefault();
-      addTag(container, textInput.value);
+      if (!open) updateSuggestions(container, textInput.value);
+      moveActive(container, e.key === "ArrowDown" ? 1 : -1);
+    } else if (e.key === "Enter") {
+      e.preventDefault();
+
+      const active = open
+        ? panel.querySelector('[data-pui-tagsinput-active="true"]')
+        : null;
+      active
+        ? chooseSuggestion(container, active)
+        : commit(container, textInput.value);
+    } else if (e.key.length === 1 && delimiters(container).includes(e.key)) {
+      e.preventDefault();
+      commit(container, textInput.value);
     } else if (e.key === "Backspace" && textInput.value === "") {
       e.preventDefault();
       const lastChip = container.querySelector(
@@ -118,7 +406,51 @@
     }
   });
 
+  document.addEventListener("input", (e) => {
+    const textInput = e.target.closest?.("[data-pui-tagsinput-text-input]");
+    if (!textInput) return;
+
+    const container = textInput.closest("[data-pui-tagsinput]");
+    if (!container) return;
+
+    setInvalid(container, false);
+    updateSuggestions(container, textInput.value);
+  });
+
+  document.addEventListener("paste", (e) => {
+    const textInput = e.target.closest?.("[data-pui-tagsinput-text-input]");
+    if (!textInput || textInput.hasAttribute("readonly")) return;
+
+    const container = textInput.closest("[data-pui-tagsinput]");
+    const text = e.clipboardData ? e.clipboardData.getData("text") : "";
+    if (!container || !splitter(container).test(text)) return;
+
+    e.preventDefault();
+    commit(container, textInput.value + text);
+  });
+
+  document.addEventListener("focusout", (e) => {
+    const container = e.target.closest?.("[data-pui-tagsinput]");
+    if (!container || container.contains(e.relatedTarget)) return;
+
+    closeSuggestions(container);
+  });
+
+  // Keep focus in the input while picking a suggestion
+  document.addEventListener("mousedown", (e) => {
+    if (e.target.closest?.("[data-pui-tagsinput-suggestion]")) {
+      e.preventDefault();
+    }
+  });
+
   document.addEventListener("click", (e) => {
+    const suggestion = e.target.closest("[data-pui-tagsinput-suggestion]");
+    if (suggestion) {
+      const container = suggestion.closest("[data-pui-tagsinput]");
+      if (container) chooseSuggestion(container, suggestion);
+      return;
+    }
+
     // Handle remove button clicks
     const removeButton = e.target.closest("[data-pui-tagsinput-remove]");
     if (removeButton && !removeButton.disabled) {
@@ -131,9 +463,7 @@
     // Focus input when clicking container
     const container = e.target.closest("[data-pui-tagsinput]");
     if (container && !e.target.closest("input")) {
-      const textInput = container.querySelector(
-        "[data-pui-tagsinput-text-input]",
-      );
+      const textInput = getTextInput(container);
       if (textInput) textInput.focus();
     }
   });
@@ -146,15 +476,13 @@
       container
         .querySelectorAll("[data-pui-tagsinput-chip]")
         .forEach((chip) => chip.remove());
-      container
-        .querySelectorAll(
-          '[data-pui-tagsinput-hidden-inputs] input[type="hidden"]',
-        )
-        .forEach((input) => input.remove());
-      const textInput = container.querySelector(
-        "[data-pui-tagsinput-text-input]",
-      );
+      hiddenInputs(container).forEach((input) => input.remove());
+      const textInput = getTextInput(container);
       if (textInput) textInput.value = "";
+
+      setInvalid(container, false);
+      updateFull(container);
+      closeSuggestions(container);
     });
   });
 })();
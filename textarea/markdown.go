@@ -0,0 +1,143 @@
+package textarea
+
+import (
+	_ "embed"
+
+	"github.com/plainkit/html"
+	"github.com/plainkit/icons/lucide"
+	"github.com/plainkit/ui/button"
+	"github.com/plainkit/ui/markdown"
+	"github.com/plainkit/ui/tabs"
+)
+
+// markdownEditor wraps the textarea in a frame with a formatting toolbar and, when
+// PreviewURL is set, Write and Preview tabs.
+func markdownEditor(p Props, rest []html.TextareaArg) html.Node {
+	field := p
+	field.Class = html.ClassMerge(
+		"min-h-[160px] rounded-none border-0 bg-transparent shadow-none backdrop-blur-none",
+		"focus-visible:ring-0 focus-visible:ring-offset-0",
+		p.Class,
+	)
+	field.Attrs = append([]html.Global{html.AData("pui-markdown-input", "")}, p.Attrs...)
+
+	node := html.Textarea(append([]html.TextareaArg{field}, rest...)...)
+	if p.AutoResize {
+		node = node.WithAssets("", textareaResizeJS, "ui-textarea-autoresize")
+	}
+
+	frameClass := html.ClassMerge(
+		"w-full gap-0 overflow-hidden rounded-lg border border-input/60 bg-background/60 shadow-xs transition-[border-color,box-shadow]",
+		"focus-within:border-ring focus-within:ring-2 focus-within:ring-ring/40",
+		func() string {
+			if p.HasError {
+				return "border-destructive ring-destructive/20 dark:ring-destructive/40"
+			}
+
+			return ""
+		}(),
+		func() string {
+			if p.Disabled {
+				return "opacity-60"
+			}
+
+			return ""
+		}(),
+	)
+
+	frameAttrs := []html.Global{
+		html.AData("pui-markdown", ""),
+		html.AData("pui-markdown-for", p.ID),
+	}
+
+	toolbar := markdownToolbar(p)
+
+	if p.PreviewURL == "" {
+		args := []html.DivArg{
+			html.AClass(html.ClassMerge("flex flex-col", frameClass)),
+			html.Div(
+				html.AClass("flex items-center justify-end border-b border-border/60 px-2 py-1"),
+				toolbar,
+			),
+			node,
+		}
+		for _, a := range frameAttrs {
+			args = append(args, a)
+		}
+
+		return html.Div(args...).WithAssets("", markdownJS, "ui-textarea-markdown")
+	}
+
+	tabsID := p.ID + "-tabs"
+	frameAttrs = append(frameAttrs, html.AData("pui-markdown-preview-url", p.PreviewURL))
+
+	return tabs.Tabs(
+		tabs.Props{ID: tabsID, Class: frameClass, Attrs: frameAttrs},
+		html.Div(
+			html.AClass("flex items-center justify-between gap-2 border-b border-border/60 px-2 py-1"),
+			tabs.List(
+				tabs.ListProps{TabsID: tabsID, Class: "h-9 p-1"},
+				tabs.Trigger(tabs.TriggerProps{TabsID: tabsID, Value: "write", IsActive: true, Class: "px-3 py-1 text-xs"}, html.Text("Write")),
+				tabs.Trigger(tabs.TriggerProps{TabsID: tabsID, Value: "preview", Class: "px-3 py-1 text-xs"}, html.Text("Preview")),
+			),
+			toolbar,
+		),
+		tabs.Content(
+			tabs.ContentProps{TabsID: tabsID, Value: "write", IsActive: true, Class: "rounded-none border-none bg-transparent p-0 shadow-none"},
+			node,
+		),
+		tabs.Content(
+			tabs.ContentProps{TabsID: tabsID, Value: "preview", Class: "rounded-none border-none bg-transparent p-0 shadow-none"},
+			markdown.View(markdown.Props{
+				Source: p.Value,
+				Class:  "min-h-[160px] px-3 py-3 empty:before:text-muted-foreground empty:before:content-['Nothing_to_preview']",
+				Attrs: []html.Global{
+					html.AData("pui-markdown-preview", ""),
+					html.AAria("live", "polite"),
+				},
+			}),
+		),
+	).WithAssets("", markdownJS, "ui-textarea-markdown")
+}
+
+func markdownToolbar(p Props) html.Node {
+	actions := []struct {
+		action string
+		label  string
+		icon   func(...html.SvgArg) html.Node
+	}{
+		{"bold", "Bold (Ctrl+B)", lucide.Bold},
+		{"italic", "Italic (Ctrl+I)", lucide.Italic},
+		{"link", "Link (Ctrl+K)", lucide.Link},
+		{"code", "Code (Ctrl+E)", lucide.Code},
+		{"list", "Bulleted list (Ctrl+Shift+8)", lucide.List},
+		{"ordered-list", "Numbered list (Ctrl+Shift+7)", lucide.ListOrdered},
+	}
+
+	args := []html.DivArg{
+		html.AClass("flex items-center gap-0.5"),
+		html.ACustom("role", "toolbar"),
+		html.AAria("label", "Formatting"),
+		html.AAria("controls", p.ID),
+		html.AData("pui-markdown-toolbar", ""),
+	}
+
+	for _, a := range actions {
+		args = append(args, button.Button(button.Props{
+			Variant:  button.VariantGhost,
+			Size:     button.SizeIcon,
+			Class:    "size-8 rounded-md text-muted-foreground",
+			Disabled: p.Disabled || p.Readonly,
+			Attrs: []html.Global{
+				html.AAria("label", a.label),
+				html.ATitle(a.label),
+				html.AData("pui-markdown-action", a.action),
+			},
+		}, a.icon(html.AClass("size-4"))))
+	}
+
+	return html.Div(args...)
+}
+
+//go:embed markdown.js
+var markdownJS string
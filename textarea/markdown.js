@@ -0,0 +1,283 @@
+(function () {
+  "use strict";
+
+  const rendered = new WeakMap(); // editor -> source of the current preview
+  const controllers = new WeakMap(); // editor -> AbortController of the preview request
+
+  const LIST_ITEM = /^(\s*)([-*+]|(\d+)([.)]))(\s+)(\[[ xX]\]\s+)?/;
+
+  function editorOf(el) {
+    return el?.closest?.("[data-pui-markdown]") || null;
+  }
+
+  function inputOf(editor) {
+    return editor.querySelector("[data-pui-markdown-input]");
+  }
+
+  // Replace a range keeping the browser's undo history where possible
+  function replace(textarea, start, end, text, selStart, selEnd) {
+    textarea.focus();
+    textarea.setSelectionRange(start, end);
+
+    let done = false;
+    try {
+      done = document.execCommand("insertText", false, text);
+    } catch (_) {
+      done = false;
+    }
+
+    if (!done || textarea.value.slice(start, start + text.length) !== text) {
+      textarea.setRangeText(text, start, end, "end");
+      textarea.dispatchEvent(new Event("input", { bubbles: true }));
+    }
+
+    textarea.setSelectionRange(selStart, selEnd);
+  }
+
+  // Toggle a marker such as ** around the selection
+  function wrap(textarea, marker) {
+    const { selectionStart: start, selectionEnd: end, value } = textarea;
+    const selected = value.slice(start, end);
+    const m = marker.length;
+
+    if (value.slice(start - m, start) === marker && value.slice(end, end + m) === marker) {
+      replace(textarea, start - m, end + m, selected, start - m, end - m);
+      return;
+    }
+
+    if (selected.length > 2 * m && selected.startsWith(marker) && selected.endsWith(marker)) {
+      const inner = selected.slice(m, -m);
+      replace(textarea, start, end, inner, start, start + inner.length);
+      return;
+    }
+
+    replace(textarea, start, end, marker + selected + marker, start + m, end + m);
+  }
+
+  function code(textarea) {
+    const { selectionStart: start, selectionEnd: end, value } = textarea;
+    const selected = value.slice(start, end);
+
+    if (!selected.includes("\n")) {
+      wrap(textarea, "`");
+      return;
+    }
+
+    const before = start > 0 && value[start - 1] !== "\n" ? "\n" : "";
+    const text = before + "```\n" + selected.replace(/\n$/, "") + "\n```\n";
+    const offset = start + before.length + 4;
+    replace(textarea, start, end, text, offset, offset + selected.replace(/\n$/, "").length);
+  }
+
+  function link(textarea) {
+    const { selectionStart: start, selectionEnd: end, value } = textarea;
+    const selected = value.slice(start, end);
+
+    if (/^https?:\/\/\S+$/.test(selected)) {
+      const text = "[](" + selected + ")";
+      replace(textarea, start, end, text, start + 1, start + 1);
+      return;
+    }
+
+    const label = selected || "text";
+    const text = "[" + label + "](url)";
+    const urlStart = start + label.length + 3;
+    if (selected) {
+      replace(textarea, start, end, text, urlStart, urlStart + 3);
+    } else {
+      replace(textarea, start, end, text, start + 1, start + 1 + label.length);
+    }
+  }
+
+  // Toggle list markers on every line touched by the selection
+  function list(textarea, ordered) {
+    const { selectionStart: start, selectionEnd: end, value } = textarea;
+    const lineStart = value.lastIndexOf("\n", start - 1) + 1;
+    let lineEnd = value.indexOf("\n", end > start && value[end - 1] === "\n" ? end - 1 : end);
+    if (lineEnd < 0) lineEnd = value.length;
+
+    const lines = value.slice(lineStart, lineEnd).split("\n");
+    const marked = lines.every((line) =>
+      ordered ? /^\s*\d+[.)]\s/.test(line) : /^\s*[-*+]\s/.test(line),
+    );
+
+    const next = lines.map((line, i) => {
+      const bare = line.replace(/^(\s*)([-*+]|\d+[.)])\s+/, "$1");
+      if (marked) return bare;
+      return (ordered ? i + 1 + ". " : "- ") + bare;
+    });
+
+    const text = next.join("\n");
+    replace(textarea, lineStart, lineEnd, text, lineStart, lineStart + text.length);
+  }
+
+  function run(textarea, action) {
+    if (textarea.disabled || textarea.readOnly) return;
+
+    switch (action) {
+      case "bold":
+        wrap(textarea, "**");
+        break;
+      case "italic":
+        wrap(textarea, "_");
+        break;
+      case "code":
+        code(textarea);
+        break;
+      case "link":
+        link(textarea);
+        break;
+      case "list":
+        list(textarea, false);
+        break;
+      case "ordered-list":
+        list(textarea, true);
+        break;
+    }
+  }
+
+  // Enter inside a list item continues the list; on an empty item it ends it
+  function continueList(textarea) {
+    const { selectionStart: start, selectionEnd: end, value } = textarea;
+    if (start !== end) return false;
+
+    const lineStart = value.lastIndexOf("\n", start - 1) + 1;
+    const line = value.slice(lineStart, start);
+    const m = LIST_ITEM.exec(line);
+    if (!m) return false;
+
+    if (line.slice(m[0].length).trim() === "" && value.slice(start).split("\n")[0].trim() === "") {
+      replace(textarea, lineStart, start, "", lineStart, lineStart);
+      return true;
+    }
+
+    const marker = m[3] ? parseInt(m[3], 10) + 1 + m[4] : m[2];
+    const task = m[6] ? "[ ] " : "";
+    const text = "\n" + m[1] + marker + m[5] + task;
+    replace(textarea, start, end, text, start + text.length, start + text.length);
+    return true;
+  }
+
+  function showTab(editor, value) {
+    const trigger = editor.querySelector(
+      '[data-pui-tabs-trigger][data-pui-tabs-value="' + value + '"]',
+    );
+    trigger?.click();
+  }
+
+  async function preview(editor) {
+    const url = editor.getAttribute("data-pui-markdown-preview-url");
+    const input = inputOf(editor);
+    const target = editor.querySelector("[data-pui-markdown-preview]");
+    if (!url || !input || !target) return;
+
+    const source = input.value;
+    if (rendered.get(editor) === source) return;
+
+    controllers.get(editor)?.abort();
+    const controller = new AbortController();
+    controllers.set(editor, controller);
+
+    target.setAttribute("aria-busy", "true");
+
+    try {
+      const res = await fetch(url, {
+        method: "POST",
+        headers: { "Content-Type": "application/x-www-form-urlencoded" },
+        body: new URLSearchParams({ text: source }),
+        signal: controller.signal,
+      });
+      if (!res.ok) throw new Error("preview " + res.status);
+
+      // The server renders and sanitizes the markup
+      target.innerHTML = await res.text();
+      rendered.set(editor, source);
+    } catch (err) {
+      if (err.name === "AbortError") return;
+      target.textContent = "The preview could not be loaded.";
+    } finally {
+      if (controllers.get(editor) === controller) {
+        target.removeAttribute("aria-busy");
+      }
+    }
+  }
+
+  document.addEventListener("click", (e) => {
+    const button = e.target.closest?.("[data-pui-markdown-action]");
+    if (!button) return;
+
+    const editor = editorOf(button);
+    const input = editor && inputOf(editor);
+    if (input) run(input, button.getAttribute("data-pui-markdown-action"));
+  });
+
+  document.addEventListener("keydown", (e) => {
+    const input = e.target.closest?.("[data-pui-markdown-input]");
+    if (!input) return;
+
+    const editor = editorOf(input);
+    const mod = e.metaKey || e.ctrlKey;
+
+    if (mod && !e.altKey) {
+      let action = "";
+      if (!e.shiftKey) {
+        action = { b: "bold", i: "italic", k: "link", e: "code" }[e.key.toLowerCase()] || "";
+      } else if (e.code === "Digit8") {
+        action = "list";
+      } else if (e.code === "Digit7") {
+        action = "ordered-list";
+      } else if (e.key.toLowerCase() === "p" && editor?.hasAttribute("data-pui-markdown-preview-url")) {
+        e.preventDefault();
+        showTab(editor, "preview");
+        return;
+      }
+
+      if (action) {
+        e.preventDefault();
+        run(input, action);
+      }
+      return;
+    }
+
+    if (e.key === "Enter" && !e.shiftKey && !e.isComposing && !input.readOnly) {
+      if (continueList(input)) e.preventDefault();
+    }
+  });
+
+  // Leaving the preview with the same shortcut returns to the text
+  document.addEventListener("keydown", (e) => {
+    if (!(e.metaKey || e.ctrlKey) || !e.shiftKey || e.key.toLowerCase() !== "p") return;
+
+    const editor = editorOf(e.target);
+    if (!editor || e.target.closest("[data-pui-markdown-input]")) return;
+
+    e.preventDefault();
+    showTab(editor, "write");
+    inputOf(editor)?.focus();
+  });
+
+  document.addEventListener("pui-tabs:change", (e) => {
+    const editor = e.target.closest?.("[data-pui-markdown]");
+    if (!editor || e.target !== editor) return;
+
+    const previewing = e.detail.value === "preview";
+    editor
+      .querySelectorAll("[data-pui-markdown-action]")
+      .forEach((button) => {
+        const input = inputOf(editor);
+        button.disabled = previewing || !input || input.disabled || input.readOnly;
+      });
+
+    if (previewing) preview(editor);
+  });
+
+  // Expose public API
+  window.tui = window.tui || {};
+  window.tui.markdown = {
+    run: (input, action) => run(input, action),
+    preview: (el) => {
+      const editor = editorOf(el);
+      if (editor) preview(editor);
+    },
+  };
+})();
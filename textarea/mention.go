@@ -0,0 +1,274 @@
+package textarea
+
+import (
+	_ "embed"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/plainkit/html"
+	"github.com/plainkit/ui/internal/styles"
+	"github.com/plainkit/ui/popover"
+)
+
+const (
+	// QueryParam is the query string parameter carrying the text typed after a trigger.
+	QueryParam = "q"
+	// TriggerParam is the query string parameter carrying the trigger character.
+	TriggerParam = "trigger"
+)
+
+// Trigger opens a suggestion list at the caret when Char is typed at the start of a word,
+// as in "@ana", "#42" or "/shrug".
+type Trigger struct {
+	Char        string        // Single character such as "@", "#" or "/"
+	Items       []MentionItem // Suggestions filtered on the client
+	URL         string        // Endpoint served by MentionHandler; replaces Items
+	Name        string        // Hidden form field collecting the IDs of the inserted items
+	Selected    []MentionItem // Items already referenced by Value, kept in the hidden field
+	StartOfLine bool          // Only open at the start of a line, as slash commands usually do
+}
+
+// MentionItem is one suggestion of a Trigger.
+type MentionItem struct {
+	ID     string
+	Label  string // Inserted after the trigger character, e.g. "ana" for "@ana"
+	Hint   string // Secondary text such as a full name or an issue title
+	Insert string // Text inserted instead of Char+Label, e.g. the expansion of a slash command
+}
+
+// Token returns the text inserted for item when selected through t.
+func (t Trigger) Token(item MentionItem) string {
+	if item.Insert != "" {
+		return item.Insert
+	}
+
+	return t.Char + item.Label
+}
+
+// mentionNodes renders the suggestion panel and the hidden fields of the triggers.
+func mentionNodes(p Props) []html.DivArg {
+	panelID := p.ID + "-mentions"
+
+	// Options of the active trigger are copied from its source into the list
+	content := []html.DivArg{
+		html.Div(
+			html.AId(panelID+"-list"),
+			html.AClass("flex max-h-64 min-w-56 flex-col overflow-y-auto p-1"),
+			html.ACustom("role", "listbox"),
+			html.AData("pui-mention-list", ""),
+		),
+	}
+
+	values := []html.DivArg{
+		html.AClass("hidden"),
+		html.AData("pui-mention-values", p.ID),
+	}
+
+	for _, t := range p.Triggers {
+		if t.Char == "" {
+			continue
+		}
+
+		source := []html.DivArg{
+			html.AClass("hidden"),
+			html.AData("pui-mention-source", t.Char),
+		}
+
+		if t.URL != "" {
+			source = append(source, html.AData("pui-mention-url", t.URL))
+		} else {
+			for _, item := range t.Items {
+				source = append(source, MentionOption(item))
+			}
+		}
+
+		if t.Name != "" {
+			source = append(source, html.AData("pui-mention-name", t.Name))
+		}
+
+		if t.StartOfLine {
+			source = append(source, html.AData("pui-mention-line-start", "true"))
+		}
+
+		content = append(content, html.Div(source...))
+
+		if t.Name == "" {
+			continue
+		}
+
+		for _, item := range t.Selected {
+			values = append(values, mentionValue(p, t, item))
+		}
+	}
+
+	panel := popover.Content(append([]html.DivArg{
+		popover.ContentProps{
+			ID:        panelID,
+			Placement: popover.PlacementBottomStart,
+			// The caret moves while typing, so the script opens and closes the panel itself
+			DisableClickAway: true,
+			DisableESC:       true,
+			Attrs: []html.Global{
+				html.AData("pui-mention-panel", p.ID),
+			},
+		},
+	}, content...)...)
+
+	return []html.DivArg{html.Div(values...), panel}
+}
+
+func mentionValue(p Props, t Trigger, item MentionItem) html.Node {
+	args := []html.InputArg{
+		html.AType("hidden"),
+		html.AName(t.Name),
+		html.AValue(item.ID),
+		html.AData("pui-mention-token", t.Token(item)),
+	}
+
+	if p.Form != "" {
+		args = append(args, html.AForm(p.Form))
+	}
+
+	return html.Input(args...)
+}
+
+func mentionAttrs(p Props) []html.TextareaArg {
+	if len(p.Triggers) == 0 {
+		return nil
+	}
+
+	return []html.TextareaArg{
+		html.AData("pui-mention", p.ID+"-mentions"),
+		html.AAria("autocomplete", "list"),
+		html.AAria("controls", p.ID+"-mentions-list"),
+	}
+}
+
+// MentionOption renders one entry of the suggestion list.
+func MentionOption(item MentionItem) html.Node {
+	args := []html.DivArg{
+		html.AClass(html.ClassMerge(
+			styles.InteractiveGhost("flex w-full cursor-pointer select-none items-baseline justify-start gap-2 rounded-lg px-3 py-2 text-left text-sm"),
+			"data-[pui-mention-active=true]:bg-muted/70 data-[pui-mention-active=true]:text-foreground",
+		)),
+		html.ACustom("role", "option"),
+		html.AAria("selected", "false"),
+		html.AData("pui-mention-option", ""),
+		html.AData("pui-mention-id", item.ID),
+		html.AData("pui-mention-label", item.Label),
+		html.Span(html.AClass("font-medium"), html.Text(item.Label)),
+	}
+
+	if item.Insert != "" {
+		args = append(args, html.AData("pui-mention-insert", item.Insert))
+	}
+
+	if item.Hint != "" {
+		args = append(args, html.Span(html.AClass("truncate text-xs text-muted-foreground"), html.Text(item.Hint)))
+	}
+
+	return html.Div(args...)
+}
+
+// MentionFunc resolves the suggestions for the text typed after a trigger character.
+type MentionFunc func(r *http.Request, trigger, query string) ([]MentionItem, error)
+
+// MentionHandler serves the suggestions requested by Trigger.URL.
+func MentionHandler(fn MentionFunc) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+
+		items, err := fn(r, q.Get(TriggerParam), strings.TrimSpace(q.Get(QueryParam)))
+		if err != nil {
+			http.Error(w, "could not load suggestions", http.StatusInternalServerError)
+			return
+		}
+
+		var sb strings.Builder
+		for _, item := range items {
+			sb.WriteString(html.Render(MentionOption(item)))
+		}
+
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Header().Set("Cache-Control", "no-store")
+
+		_, _ = w.Write([]byte(sb.String()))
+	})
+}
+
+// CleanMentions trims and de-duplicates the submitted IDs of t, and drops those whose
+// token no longer appears in text, the submitted value of the textarea. When t offers a
+// static list, IDs outside of Items and Selected are dropped too. IDs found through URL
+// and missing from Selected have no token to look for, so they are kept.
+func CleanMentions(t Trigger, text string, ids []string) []string {
+	static := t.URL == "" && len(t.Items) > 0
+	tokens := make(map[string][]string, len(t.Items)+len(t.Selected))
+
+	for _, items := range [][]MentionItem{t.Items, t.Selected} {
+		for _, item := range items {
+			tokens[item.ID] = append(tokens[item.ID], t.Token(item))
+		}
+	}
+
+	inText := func(token string) bool { return containsToken(text, token) }
+
+	seen := make(map[string]bool, len(ids))
+	out := make([]string, 0, len(ids))
+
+	for _, id := range ids {
+		id = strings.TrimSpace(id)
+		if id == "" || seen[id] {
+			continue
+		}
+
+		known, ok := tokens[id]
+		if ok && !slices.ContainsFunc(known, inText) || !ok && static {
+			continue
+		}
+
+		seen[id] = true
+		out = append(out, id)
+	}
+
+	return out
+}
+
+// containsToken reports whether token appears in text without a word character or
+// hyphen right after it, as mention.js checks.
+func containsToken(text, token string) bool {
+	if token == "" {
+		return false
+	}
+
+	for i := 0; ; {
+		j := strings.Index(text[i:], token)
+		if j < 0 {
+			return false
+		}
+
+		end := i + j + len(token)
+		if end == len(text) || !isWordByte(text[end]) {
+			return true
+		}
+
+		i += j + 1
+	}
+}
+
+func isWordByte(c byte) bool {
+	return c == '_' || c == '-' || c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+// ParseMentions reads the IDs inserted through t from a submitted form, checked against
+// the text of the textarea submitted as name.
+func ParseMentions(r *http.Request, name string, t Trigger) ([]string, error) {
+	if err := r.ParseForm(); err != nil {
+		return nil, err
+	}
+
+	return CleanMentions(t, r.Form.Get(name), r.Form[t.Name]), nil
+}
+
+//go:embed mention.js
+var mentionJS string
@@ -0,0 +1,473 @@
+(function () {
+  "use strict";
+
+  const MAX_QUERY = 40;
+  const MAX_RESULTS = 8;
+  const DEBOUNCE = 150;
+
+  const states = new WeakMap(); // textarea -> { char, start, query }
+  const timers = new WeakMap();
+  const controllers = new WeakMap();
+
+  // Style properties copied to the mirror used to measure the caret position
+  const MIRRORED = [
+    "boxSizing",
+    "width",
+    "borderTopWidth",
+    "borderRightWidth",
+    "borderBottomWidth",
+    "borderLeftWidth",
+    "paddingTop",
+    "paddingRight",
+    "paddingBottom",
+    "paddingLeft",
+    "fontFamily",
+    "fontSize",
+    "fontStyle",
+    "fontVariant",
+    "fontWeight",
+    "fontStretch",
+    "letterSpacing",
+    "lineHeight",
+    "textIndent",
+    "textTransform",
+    "wordSpacing",
+    "tabSize",
+  ];
+
+  function panelOf(textarea) {
+    const id = textarea.getAttribute("data-pui-mention");
+    return id ? document.getElementById(id) : null;
+  }
+
+  function listOf(panel) {
+    return panel.querySelector("[data-pui-mention-list]");
+  }
+
+  function sourceOf(panel, char) {
+    return Array.from(panel.querySelectorAll("[data-pui-mention-source]")).find(
+      (el) => el.getAttribute("data-pui-mention-source") === char,
+    );
+  }
+
+  function valuesOf(textarea) {
+    return document.querySelector(
+      '[data-pui-mention-values="' + CSS.escape(textarea.id) + '"]',
+    );
+  }
+
+  function isOpen(panel) {
+    return panel.getAttribute("data-pui-popover-open") === "true";
+  }
+
+  function caretRect(textarea, index) {
+    const style = getComputedStyle(textarea);
+    const mirror = document.createElement("div");
+
+    MIRRORED.forEach((prop) => {
+      mirror.style[prop] = style[prop];
+    });
+    Object.assign(mirror.style, {
+      position: "absolute",
+      visibility: "hidden",
+      top: "0",
+      left: "-9999px",
+      whiteSpace: "pre-wrap",
+      overflowWrap: "break-word",
+      overflow: "hidden",
+    });
+
+    mirror.textContent = textarea.value.slice(0, index);
+    const marker = document.createElement("span");
+    marker.textContent = "\u200b";
+    mirror.appendChild(marker);
+    document.body.appendChild(mirror);
+
+    const box = textarea.getBoundingClientRect();
+    const x = box.left + marker.offsetLeft - textarea.scrollLeft;
+    const y = box.top + marker.offsetTop - textarea.scrollTop;
+    const height = marker.offsetHeight || parseFloat(style.lineHeight) || 16;
+
+    mirror.remove();
+
+    return { x, y, width: 0, height, left: x, top: y, right: x, bottom: y + height };
+  }
+
+  function position(textarea, panel, index) {
+    if (!window.FloatingUIDOM) return;
+
+    const { computePosition, offset, flip, shift } = window.FloatingUIDOM;
+    const reference = {
+      getBoundingClientRect: () => caretRect(textarea, index),
+      contextElement: textarea,
+    };
+
+    computePosition(reference, panel, {
+      placement: panel.getAttribute("data-pui-popover-placement") || "bottom-start",
+      middleware: [offset(4), flip({ padding: 10 }), shift({ padding: 10 })],
+    }).then(({ x, y }) => {
+      Object.assign(panel.style, { left: `${x}px`, top: `${y}px` });
+    });
+  }
+
+  // The shared layer manager from layer.js, which routes ESC and outside clicks
+  function layers() {
+    return window.tui && window.tui.layers;
+  }
+
+  function open(textarea, panel) {
+    const portal = document.querySelector("[data-pui-popover-portal-container]");
+    if (portal && panel.parentNode !== portal) portal.appendChild(panel);
+
+    if (!isOpen(panel)) {
+      panel.style.display = "block";
+      panel.classList.remove("popover-animate-out");
+      panel.classList.add("popover-animate-in");
+      panel.setAttribute("data-pui-popover-open", "true");
+
+      // The list is a layer above the textarea's own, so ESC closes it and not a
+      // dialog the textarea sits in
+      layers()?.open(panel, {
+        anchors: [textarea],
+        escape: () => close(textarea),
+        outside: () => close(textarea),
+      });
+    }
+
+    position(textarea, panel, states.get(textarea).start);
+  }
+
+  function close(textarea) {
+    states.delete(textarea);
+    clearTimeout(timers.get(textarea));
+    controllers.get(textarea)?.abort();
+    textarea.removeAttribute("aria-activedescendant");
+
+    const panel = panelOf(textarea);
+    if (!panel || !isOpen(panel)) return;
+
+    layers()?.close(panel);
+    panel.setAttribute("data-pui-popover-open", "false");
+    panel.style.display = "none";
+    panel.classList.remove("popover-animate-in", "popover-animate-out");
+  }
+
+  // Finds a trigger character starting the word that ends at the caret
+  function detect(textarea, panel) {
+    const { selectionStart: caret, selectionEnd, value } = textarea;
+    if (caret !== selectionEnd) return null;
+
+    for (let i = caret - 1; i >= 0 && caret - i <= MAX_QUERY + 1; i--) {
+      const ch = value[i];
+      if (/\s/.test(ch)) return null;
+
+      const source = sourceOf(panel, ch);
+      if (!source) continue;
+
+      const before = i > 0 ? value[i - 1] : "\n";
+      const lineStart = source.hasAttribute("data-pui-mention-line-start");
+      if (lineStart ? before !== "\n" : !/\s/.test(before)) continue;
+
+      return { char: ch, start: i, query: value.slice(i + 1, caret) };
+    }
+
+    return null;
+  }
+
+  function options(panel) {
+    return Array.from(listOf(panel).querySelectorAll("[data-pui-mention-option]"));
+  }
+
+  function setActive(textarea, panel, index) {
+    const items = options(panel);
+    items.forEach((item, i) => {
+      const active = i === index;
+      item.setAttribute("data-pui-mention-active", String(active));
+      item.setAttribute("aria-selected", String(active));
+      if (active) {
+        textarea.setAttribute("aria-activedescendant", item.id);
+        item.scrollIntoView({ block: "nearest" });
+      }
+    });
+  }
+
+  function show(textarea, panel, items) {
+    const list = listOf(panel);
+    list.querySelectorAll("[data-pui-mention-option]").forEach((el) => el.remove());
+
+    if (items.length === 0) {
+      close(textarea);
+      return;
+    }
+
+    items.forEach((item, i) => {
+      item.id = list.id + "-" + i;
+      list.appendChild(item);
+    });
+
+    open(textarea, panel);
+    setActive(textarea, panel, 0);
+  }
+
+  function rank(option, query) {
+    const label = (option.getAttribute("data-pui-mention-label") || "").toLowerCase();
+    const text = option.textContent.toLowerCase();
+    if (!query) return 1;
+    if (label.startsWith(query)) return 0;
+    if (text.includes(query)) return 1;
+    return -1;
+  }
+
+  function suggest(textarea, panel, state) {
+    const source = sourceOf(panel, state.char);
+    const url = source.getAttribute("data-pui-mention-url");
+
+    if (!url) {
+      const query = state.query.toLowerCase();
+      const items = Array.from(source.querySelectorAll("[data-pui-mention-option]"))
+        .map((option) => ({ option, score: rank(option, query) }))
+        .filter((entry) => entry.score >= 0)
+        .sort((a, b) => a.score - b.score)
+        .slice(0, MAX_RESULTS)
+        .map((entry) => entry.option.cloneNode(true));
+
+      show(textarea, panel, items);
+      return;
+    }
+
+    clearTimeout(timers.get(textarea));
+    controllers.get(textarea)?.abort();
+
+    timers.set(
+      textarea,
+      setTimeout(() => {
+        const controller = new AbortController();
+        controllers.set(textarea, controller);
+
+        const target = new URL(url, window.location.href);
+        target.searchParams.set("q", state.query);
+        target.searchParams.set("trigger", state.char);
+
+        fetch(target, {
+          signal: controller.signal,
+          headers: { Accept: "text/html" },
+        })
+          .then((res) => (res.ok ? res.text() : ""))
+          .then((markup) => {
+            if (states.get(textarea) !== state) return;
+
+            const holder = document.createElement("div");
+            holder.innerHTML = markup;
+            show(
+              textarea,
+              panel,
+              Array.from(holder.querySelectorAll("[data-pui-mention-option]")).slice(
+                0,
+                MAX_RESULTS,
+              ),
+            );
+          })
+          .catch(() => {});
+      }, DEBOUNCE),
+    );
+  }
+
+  function refresh(textarea) {
+    const panel = panelOf(textarea);
+    if (!panel || textarea.disabled || textarea.readOnly) return;
+
+    const found = detect(textarea, panel);
+    if (!found) {
+      close(textarea);
+      return;
+    }
+
+    const previous = states.get(textarea);
+    if (
+      previous &&
+      previous.char === found.char &&
+      previous.start === found.start &&
+      previous.query === found.query
+    ) {
+      return;
+    }
+
+    states.set(textarea, found);
+    suggest(textarea, panel, found);
+  }
+
+  function replace(textarea, start, end, text) {
+    textarea.focus();
+    textarea.setSelectionRange(start, end);
+
+    let done = false;
+    try {
+      done = document.execCommand("insertText", false, text);
+    } catch (_) {
+      done = false;
+    }
+
+    if (!done || textarea.value.slice(start, start + text.length) !== text) {
+      textarea.setRangeText(text, start, end, "end");
+      textarea.dispatchEvent(new Event("input", { bubbles: true }));
+    }
+  }
+
+  function addValue(textarea, name, id, token) {
+    const values = valuesOf(textarea);
+    if (!values || !name || !id) return;
+
+    const exists = Array.from(values.querySelectorAll("input")).some(
+      (input) => input.name === name && input.value === id,
+    );
+    if (exists) return;
+
+    const input = document.createElement("input");
+    input.type = "hidden";
+    input.name = name;
+    input.value = id;
+    input.setAttribute("data-pui-mention-token", token);
+    if (textarea.hasAttribute("form")) {
+      input.setAttribute("form", textarea.getAttribute("form"));
+    }
+    values.appendChild(input);
+  }
+
+  function escapeRegExp(s) {
+    return s.replace(/[.*+?^${}()|[\]\\]/g, "\\$&");
+  }
+
+  // Drops the IDs whose token was edited out of the text
+  function syncValues(textarea) {
+    const values = valuesOf(textarea);
+    if (!values) return;
+
+    values.querySelectorAll("input[data-pui-mention-token]").forEach((input) => {
+      const token = input.getAttribute("data-pui-mention-token");
+      const pattern = new RegExp(escapeRegExp(token) + "(?![\\w-])");
+      if (!pattern.test(textarea.value)) input.remove();
+    });
+  }
+
+  function select(textarea, option) {
+    const state = states.get(textarea);
+    const panel = panelOf(textarea);
+    if (!state || !panel) return;
+
+    const source = sourceOf(panel, state.char);
+    const id = option.getAttribute("data-pui-mention-id") || "";
+    const label = option.getAttribute("data-pui-mention-label") || "";
+    const token = option.getAttribute("data-pui-mention-insert") || state.char + label;
+
+    close(textarea);
+    addValue(textarea, source?.getAttribute("data-pui-mention-name"), id, token);
+
+    const end = state.start + 1 + state.query.length;
+    const after = textarea.value[end];
+    const spaced = /\s$/.test(token) || (after && /\s/.test(after));
+    replace(textarea, state.start, end, spaced ? token : token + " ");
+
+    textarea.dispatchEvent(
+      new CustomEvent("pui-mention:select", {
+        bubbles: true,
+        detail: { trigger: state.char, id, label, token },
+      }),
+    );
+  }
+
+  function activeIndex(panel) {
+    return options(panel).findIndex(
+      (item) => item.getAttribute("data-pui-mention-active") === "true",
+    );
+  }
+
+  document.addEventListener("input", (e) => {
+    const textarea = e.target.closest?.("[data-pui-mention]");
+    if (!textarea) return;
+
+    syncValues(textarea);
+    if (!e.isComposing) refresh(textarea);
+  });
+
+  document.addEventListener("keydown", (e) => {
+    const textarea = e.target.closest?.("[data-pui-mention]");
+    const panel = textarea && panelOf(textarea);
+    if (!panel || !isOpen(panel) || e.isComposing) return;
+
+    const items = options(panel);
+    const index = activeIndex(panel);
+
+    switch (e.key) {
+      case "ArrowDown":
+        e.preventDefault();
+        setActive(textarea, panel, (index + 1) % items.length);
+        break;
+      case "ArrowUp":
+        e.preventDefault();
+        setActive(textarea, panel, (index - 1 + items.length) % items.length);
+        break;
+      case "Enter":
+      case "Tab":
+        if (index < 0 || e.shiftKey) return;
+        e.preventDefault();
+        e.stopImmediatePropagation();
+        select(textarea, items[index]);
+        break;
+    }
+  }, true);
+
+  // Caret moves without input, e.g. by clicking or arrowing sideways
+  document.addEventListener("keyup", (e) => {
+    if (["ArrowLeft", "ArrowRight", "Home", "End"].includes(e.key)) {
+      const textarea = e.target.closest?.("[data-pui-mention]");
+      if (textarea) refresh(textarea);
+    }
+  });
+
+  document.addEventListener("click", (e) => {
+    const option = e.target.closest?.("[data-pui-mention-option]");
+    const panel = option?.closest("[data-pui-mention-panel]");
+    if (option && panel) {
+      const textarea = document.getElementById(panel.getAttribute("data-pui-mention-panel"));
+      if (textarea) select(textarea, option);
+      return;
+    }
+
+    document.querySelectorAll("[data-pui-mention]").forEach((textarea) => {
+      if (textarea === e.target) {
+        refresh(textarea);
+      } else if (!e.target.closest?.("[data-pui-mention-panel]")) {
+        close(textarea);
+      }
+    });
+  });
+
+  // Keep focus in the textarea while an option is pressed
+  document.addEventListener("mousedown", (e) => {
+    if (e.target.closest?.("[data-pui-mention-panel]")) e.preventDefault();
+  });
+
+  document.addEventListener("focusout", (e) => {
+    const textarea = e.target.closest?.("[data-pui-mention]");
+    if (!textarea) return;
+
+    const panel = panelOf(textarea);
+    if (!panel || !panel.contains(e.relatedTarget)) close(textarea);
+  });
+
+  document.addEventListener("pointerover", (e) => {
+    const option = e.target.closest?.("[data-pui-mention-option]");
+    const panel = option?.closest("[data-pui-mention-panel]");
+    if (!option || !panel) return;
+
+    const textarea = document.getElementById(panel.getAttribute("data-pui-mention-panel"));
+    if (textarea) setActive(textarea, panel, options(panel).indexOf(option));
+  });
+
+  // Expose public API
+  window.tui = window.tui || {};
+  window.tui.mention = {
+    close: (textarea) => close(textarea),
+    refresh: (textarea) => refresh(textarea),
+  };
+})();
@@ -1,13 +1,15 @@
 package textarea
 
 import (
-	"crypto/rand"
 	_ "embed"
-	"encoding/hex"
+	"errors"
 	"strconv"
 
 	"github.com/plainkit/html"
+	"github.com/plainkit/ui/internal/counter"
+	"github.com/plainkit/ui/internal/randomid"
 	"github.com/plainkit/ui/internal/styles"
+	"github.com/plainkit/ui/layer"
 )
 
 type Props struct {
@@ -20,17 +22,41 @@ type Props struct {
 	Placeholder string
 	Rows        int
 	AutoResize  bool
+	MinLength   int
+	MaxLength   int
+	ShowCount   bool      // Renders a live "120 / 500" counter below the field
+	CountWords  bool      // Adds the word count to the counter
+	SoftLimit   bool      // Lets the value exceed MaxLength and marks it invalid, as HasError does, instead
+	Markdown    bool      // Adds a formatting toolbar and keyboard shortcuts for markdown
+	PreviewURL  string    // Endpoint served by markdown.Handler; adds Write and Preview tabs to Markdown
+	Triggers    []Trigger // Characters such as "@" that open suggestions at the caret
 	Disabled    bool
 	Required    bool
 	Readonly    bool
 	HasError    bool
 }
 
+var (
+	ErrTooShort = errors.New("textarea: value is too short")
+	ErrTooLong  = errors.New("textarea: value is too long")
+)
+
+// CheckLength validates value against MinLength and MaxLength, counting characters the
+// way the browser does, so a submitted CRLF counts as one. Soft limits are checked too,
+// since the browser lets them pass.
+func CheckLength(p Props, value string) error {
+	return counter.CheckLength(value, p.MinLength, p.MaxLength, ErrTooShort, ErrTooLong)
+}
+
+func (p Props) hasCounter() bool {
+	return p.ShowCount || (p.SoftLimit && p.MaxLength > 0)
+}
+
 func textareaArgsFromProps(baseClass string, extra ...string) func(p Props) []html.TextareaArg {
 	return func(p Props) []html.TextareaArg {
 		id := p.ID
 		if id == "" {
-			id = randomID()
+			id = randomid.New("textarea")
 		}
 
 		autoResizeExtra := ""
@@ -113,6 +139,24 @@ func (p Props) ApplyTextarea(attrs *html.TextareaAttrs, children *[]html.Compone
 		args = append(args, html.AData("pui-textarea-auto-resize", "true"))
 	}
 
+	if p.MinLength > 0 {
+		args = append(args, html.AMinlength(strconv.Itoa(p.MinLength)))
+	}
+
+	if p.MaxLength > 0 && !p.SoftLimit {
+		args = append(args, html.AMaxlength(strconv.Itoa(p.MaxLength)))
+	}
+
+	if p.hasCounter() {
+		args = append(args, html.AAria("describedby", counter.ID(p.ID)))
+	}
+
+	if p.SoftLimit && counter.Over(p.Value, p.MaxLength) && !p.HasError {
+		args = append(args, html.AAria("invalid", "true"), html.AData("pui-counter-invalid", "true"))
+	}
+
+	args = append(args, mentionAttrs(p)...)
+
 	for _, a := range args {
 		a.ApplyTextarea(attrs, children)
 	}
@@ -136,7 +180,7 @@ func Textarea(args ...html.TextareaArg) html.Node {
 	}
 
 	if props.ID == "" {
-		props.ID = randomID()
+		props.ID = randomid.New("textarea")
 	}
 
 	// Add the value as text content if provided
@@ -144,21 +188,47 @@ func Textarea(args ...html.TextareaArg) html.Node {
 		rest = append(rest, html.Text(props.Value))
 	}
 
-	node := html.Textarea(append([]html.TextareaArg{props}, rest...)...)
-	if props.AutoResize {
-		node = node.WithAssets("", textareaResizeJS, "ui-textarea-autoresize")
+	var node html.Node
+	if props.Markdown {
+		node = markdownEditor(props, rest)
+	} else {
+		node = html.Textarea(append([]html.TextareaArg{props}, rest...)...)
+		if props.AutoResize {
+			node = node.WithAssets("", textareaResizeJS, "ui-textarea-autoresize")
+		}
 	}
 
-	return node
-}
+	var extras []html.DivArg
+
+	if len(props.Triggers) > 0 {
+		extras = append(extras, mentionNodes(props)...)
+	}
+
+	if props.hasCounter() {
+		extras = append(extras, counter.Counter(counter.Props{
+			For:   props.ID,
+			Value: props.Value,
+			Min:   props.MinLength,
+			Max:   props.MaxLength,
+			Words: props.CountWords,
+			Soft:  props.SoftLimit,
+		}))
+	}
+
+	if len(extras) == 0 {
+		return node
+	}
+
+	if len(props.Triggers) > 0 {
+		extras = append(extras, html.Child(layer.Assets()))
+	}
 
-func randomID() string {
-	buf := make([]byte, 4)
-	if _, err := rand.Read(buf); err != nil {
-		return "textarea-id"
+	wrapper := html.Div(append([]html.DivArg{html.AClass("w-full space-y-1.5"), node}, extras...)...)
+	if len(props.Triggers) > 0 {
+		wrapper = wrapper.WithAssets("", mentionJS, "ui-textarea-mention")
 	}
 
-	return "textarea-" + hex.EncodeToString(buf)
+	return wrapper
 }
 
 //go:embed textarea.js
@@ -10,6 +10,7 @@ import (
 	"github.com/plainkit/icons/lucide"
 	"github.com/plainkit/ui/button"
 	"github.com/plainkit/ui/internal/styles"
+	"github.com/plainkit/ui/layer"
 )
 
 type Variant string
@@ -196,6 +197,8 @@ func Toast(args ...html.DivArg) html.Node {
 		}
 	}
 
+	rest = append(rest, html.Child(layer.Assets()))
+
 	return html.Div(append([]html.DivArg{props}, rest...)...).WithAssets("", toastJS, "ui-toast")
 }
 
@@ -258,6 +261,8 @@ func Trigger(props TriggerProps, buttonProps button.Props, args ...html.ButtonAr
 		buttonProps.Class = html.ClassMerge(buttonProps.Class, props.Class)
 	}
 
+	args = append(args, html.Child(layer.Assets()))
+
 	return button.Button(append([]html.ButtonArg{buttonProps}, args...)...).WithAssets("", toastJS, "ui-toast")
 }
 
@@ -283,6 +288,7 @@ func Container(position Position) html.Node {
 			positionClasses[position],
 		)),
 		html.AData("pui-toast-container", string(position)),
+		html.Child(layer.Assets()),
 	).WithAssets("", toastJS, "ui-toast")
 }
 
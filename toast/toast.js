@@ -134,7 +134,25 @@
     setupToast(toast);
   }
 
+  // The shared layer manager from layer.js. Toasts are passive layers: they stack above
+  // overlays opened before them, but ESC and clicks outside still go to those overlays,
+  // and a modal hiding the toasts stays the top layer.
+  function layers() {
+    return window.tui && window.tui.layers;
+  }
+
+  function raise(toast) {
+    var l = layers();
+    if (!l) return;
+
+    // Toasts in a container stack with it, as their own z-index stays inside it
+    var parent = toast.parentElement;
+    var part = parent && parent.id.indexOf("toast-container-") === 0 ? parent : toast;
+    l.open(toast, { parts: [part], passive: true });
+  }
+
   function setupToast(toast) {
+    raise(toast);
     var duration = parseInt(toast.dataset.tuiToastDuration || "3000", 10);
     var progress = toast.querySelector(".toast-progress");
     var state = {
@@ -182,6 +200,8 @@
     });
   }
   function dismissToast(toast) {
+    var l = layers();
+    if (l) l.close(toast);
     toastTimers.delete(toast);
     toast.style.transition = "opacity 300ms, transform 300ms";
     toast.style.opacity = "0";
@@ -216,10 +236,18 @@
     }
   });
 
-  // Setup any existing toasts on page load
-  document.querySelectorAll("[data-pui-toast]").forEach(function (toast) {
-    if (!toast.hasAttribute("data-pui-toast-template")) {
-      setupToast(toast);
-    }
-  });
+  // Setup any existing toasts on page load, once layer.js has run too
+  function init() {
+    document.querySelectorAll("[data-pui-toast]").forEach(function (toast) {
+      if (!toast.hasAttribute("data-pui-toast-template")) {
+        setupToast(toast);
+      }
+    });
+  }
+
+  if (document.readyState === "loading") {
+    document.addEventListener("DOMContentLoaded", init);
+  } else {
+    init();
+  }
 })();
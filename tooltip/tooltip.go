@@ -52,6 +52,8 @@ type ContentProps struct {
 	Position      Position
 	HoverDelay    int
 	HoverOutDelay int
+	Native        bool   // Shows the tooltip in the top layer, see popover.ContentProps.Native
+	FallbackURL   string // See popover.ContentProps.FallbackURL
 }
 
 func divArgsFromProps(baseClass string, extra ...string) func(p Props) []html.DivArg {
@@ -191,6 +193,8 @@ func Content(args ...html.DivArg) html.Node {
 		ShowArrow:     props.ShowArrow,
 		HoverDelay:    props.HoverDelay,
 		HoverOutDelay: props.HoverOutDelay,
+		Native:        props.Native,
+		FallbackURL:   props.FallbackURL,
 	}
 
 	return popover.Content(append([]html.DivArg{contentProps}, rest...)...)
@@ -0,0 +1,88 @@
+// Package urlstate mirrors the state of components such as dialogs and tabs into the URL,
+// so links can point at an open dialog or a selected tab.
+package urlstate
+
+import (
+	_ "embed"
+	"net/http"
+
+	"github.com/plainkit/html"
+)
+
+// Mode selects the part of the URL holding the state.
+type Mode string
+
+const (
+	// ModeQuery keeps the state in a query parameter, such as ?tab=billing. The server
+	// sees it and can render the matching initial state without JavaScript.
+	ModeQuery Mode = "query"
+	// ModeHash keeps the state in the fragment, such as #tab=billing, which never reaches
+	// the server; the client applies it once loaded.
+	ModeHash Mode = "hash"
+)
+
+// Sync configures mirroring a component's state into the URL.
+type Sync struct {
+	Param string // Query parameter or fragment key, such as "dialog" or "tab"
+	Mode  Mode   // Default ModeQuery
+	Push  bool   // Adds a history entry per change, so Back reverts it; otherwise the entry is replaced
+}
+
+// Enabled reports whether the state is synced at all.
+func (s Sync) Enabled() bool {
+	return s.Param != ""
+}
+
+// Value returns the state in the query of r, or "" when it is absent or kept in the hash.
+func (s Sync) Value(r *http.Request) string {
+	if !s.Enabled() || s.Mode == ModeHash || r == nil {
+		return ""
+	}
+
+	return r.URL.Query().Get(s.Param)
+}
+
+// ValueOr returns Value, or fallback when it is empty.
+func (s Sync) ValueOr(r *http.Request, fallback string) string {
+	if v := s.Value(r); v != "" {
+		return v
+	}
+
+	return fallback
+}
+
+// Is reports whether the state in r is value, such as for dialog.ContentProps.Open.
+func (s Sync) Is(r *http.Request, value string) bool {
+	return value != "" && s.Value(r) == value
+}
+
+// Attrs returns the attributes describing the sync to the client.
+func (s Sync) Attrs() []html.Global {
+	if !s.Enabled() {
+		return nil
+	}
+
+	mode := s.Mode
+	if mode == "" {
+		mode = ModeQuery
+	}
+
+	history := "replace"
+	if s.Push {
+		history = "push"
+	}
+
+	return []html.Global{
+		html.AData("pui-url-param", s.Param),
+		html.AData("pui-url-mode", string(mode)),
+		html.AData("pui-url-history", history),
+	}
+}
+
+// Assets returns a component that loads the client runtime without rendering output.
+func Assets() html.Component {
+	return html.AssetHook("ui-urlstate", "", urlstateJS)
+}
+
+//go:embed urlstate.js
+var urlstateJS string
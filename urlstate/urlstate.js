@@ -0,0 +1,81 @@
+(function () {
+  "use strict";
+
+  // Mirrors Sync.Attrs in urlstate.go
+  function config(el) {
+    return {
+      param: el.getAttribute("data-pui-url-param"),
+      mode: el.getAttribute("data-pui-url-mode") || "query",
+      push: el.getAttribute("data-pui-url-history") === "push",
+    };
+  }
+
+  // The hash holds its state like a query string: #tab=billing&dialog=settings
+  function params(mode) {
+    if (mode === "hash") return new URLSearchParams(location.hash.slice(1));
+    return new URLSearchParams(location.search);
+  }
+
+  function get(el) {
+    const c = config(el);
+    if (!c.param) return "";
+    return params(c.mode).get(c.param) || "";
+  }
+
+  function href(c, value) {
+    const p = params(c.mode);
+    if (value) p.set(c.param, value);
+    else p.delete(c.param);
+
+    const s = p.toString();
+    if (c.mode === "hash") return location.pathname + location.search + (s ? "#" + s : "");
+    return location.pathname + (s ? "?" + s : "") + location.hash;
+  }
+
+  // Write value for el; an empty value removes the parameter. Reports whether a history
+  // entry was added, which the caller can later leave with history.go.
+  function set(el, value) {
+    const c = config(el);
+    if (!c.param || get(el) === (value || "")) return false;
+
+    const state = Object.assign({}, history.state);
+    if (c.push && value) {
+      // Marks the entry as added for this value, see pushed
+      state.puiUrl = { param: c.param, value: value };
+      history.pushState(state, "", href(c, value));
+      return true;
+    }
+
+    if (state.puiUrl && state.puiUrl.param === c.param) delete state.puiUrl;
+    history.replaceState(state, "", href(c, value));
+    return false;
+  }
+
+  // The param and value the current history entry was pushed for, if any
+  function pushed() {
+    return (history.state && history.state.puiUrl) || null;
+  }
+
+  // Components reconcile with the URL on this event: after Back and Forward, after a
+  // hash change and once at startup, for state only the client can see
+  function notify() {
+    document.dispatchEvent(new CustomEvent("pui-url:change"));
+  }
+
+  window.addEventListener("popstate", notify);
+  window.addEventListener("hashchange", notify);
+
+  if (document.readyState === "loading") {
+    document.addEventListener("DOMContentLoaded", notify);
+  } else {
+    setTimeout(notify);
+  }
+
+  // Expose public API
+  window.tui = window.tui || {};
+  window.tui.url = {
+    get: get,
+    set: set,
+    pushed: pushed,
+  };
+})();
@@ -0,0 +1,123 @@
+package virtual
+
+import (
+	_ "embed"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/plainkit/html"
+)
+
+// Query parameters sent by the client when requesting a chunk from Props.URL.
+const (
+	OffsetParam = "offset"
+	LimitParam  = "limit"
+)
+
+// TotalHeader is the response header carrying the total number of items behind Props.URL.
+const TotalHeader = "X-Total-Count"
+
+// MaxLimit caps the number of items a Handler renders per request.
+const MaxLimit = 500
+
+// Props configures windowed rendering of a long list of fixed-height items.
+type Props struct {
+	ItemHeight int    // Height of every item in pixels; virtual rendering is enabled when > 0
+	Overscan   int    // Items rendered above and below the visible window (default 6)
+	Selector   string // CSS selector picking the items out of the source; defaults to its direct children
+	URL        string // Endpoint serving rendered chunks of items on demand instead of embedding them
+	Count      int    // Total number of items behind URL; updated from TotalHeader on every chunk
+	ChunkSize  int    // Items requested per chunk from URL (default 100)
+}
+
+// Enabled reports whether the props turn on virtual rendering.
+func (p Props) Enabled() bool {
+	return p.ItemHeight > 0
+}
+
+// Attrs returns the attributes marking a scrollable container as virtualized.
+func Attrs(p Props) []html.Global {
+	overscan := p.Overscan
+	if overscan <= 0 {
+		overscan = 6
+	}
+
+	attrs := []html.Global{
+		html.AData("pui-virtual", ""),
+		html.AData("pui-virtual-item-height", strconv.Itoa(p.ItemHeight)),
+		html.AData("pui-virtual-overscan", strconv.Itoa(overscan)),
+	}
+
+	if p.Selector != "" {
+		attrs = append(attrs, html.AData("pui-virtual-selector", p.Selector))
+	}
+
+	if p.URL != "" {
+		chunkSize := p.ChunkSize
+		if chunkSize <= 0 {
+			chunkSize = 100
+		}
+
+		attrs = append(attrs,
+			html.AData("pui-virtual-url", p.URL),
+			html.AData("pui-virtual-count", strconv.Itoa(p.Count)),
+			html.AData("pui-virtual-chunk-size", strconv.Itoa(min(chunkSize, MaxLimit))),
+		)
+	}
+
+	return attrs
+}
+
+// Source wraps items in an inert template; the client only mounts the visible ones.
+func Source(items ...html.DivArg) html.Node {
+	// html.Template renders as a void element and drops global attributes, so retag a div instead
+	node := html.Div(append([]html.DivArg{html.AData("pui-virtual-source", "")}, items...)...)
+	node.Tag = "template"
+
+	return node
+}
+
+// Assets returns a component that loads the client runtime without rendering output.
+func Assets() html.Component {
+	return html.AssetHook("ui-virtual", "", virtualJS)
+}
+
+// ChunkFunc renders the items in [offset, offset+limit) and reports the total item count.
+type ChunkFunc func(r *http.Request, offset, limit int) (items []html.Node, total int, err error)
+
+// Handler serves the chunks requested by a container configured with Props.URL.
+// Other query parameters set on the client, such as a search term, are passed through in r.
+func Handler(fn ChunkFunc) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+
+		offset, _ := strconv.Atoi(query.Get(OffsetParam))
+		offset = max(offset, 0)
+
+		limit, err := strconv.Atoi(query.Get(LimitParam))
+		if err != nil || limit <= 0 {
+			limit = 100
+		}
+
+		items, total, err := fn(r, offset, min(limit, MaxLimit))
+		if err != nil {
+			http.Error(w, "could not load items", http.StatusInternalServerError)
+			return
+		}
+
+		var sb strings.Builder
+		for _, item := range items {
+			sb.WriteString(html.Render(item))
+		}
+
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Header().Set("Cache-Control", "no-store")
+		w.Header().Set(TotalHeader, strconv.Itoa(total))
+
+		_, _ = w.Write([]byte(sb.String()))
+	})
+}
+
+//go:embed virtual.js
+var virtualJS string
@@ -0,0 +1,508 @@
+(function () {
+  "use strict";
+
+  const states = new WeakMap();
+
+  function intAttr(el, name, fallback) {
+    const value = parseInt(el.getAttribute(name));
+    return isNaN(value) ? fallback : value;
+  }
+
+  // Closest scrolling ancestor, or the window when the page itself scrolls
+  function findViewport(el) {
+    const explicit = el.closest("[data-pui-virtual-viewport]");
+    if (explicit) return explicit;
+
+    for (let node = el; node && node !== document.body; node = node.parentElement) {
+      const overflow = getComputedStyle(node).overflowY;
+      if (overflow === "auto" || overflow === "scroll") return node;
+    }
+
+    return window;
+  }
+
+  function collect(state, root) {
+    const selector = state.el.getAttribute("data-pui-virtual-selector");
+    return Array.from(selector ? root.querySelectorAll(selector) : root.children);
+  }
+
+  function size(state) {
+    return state.order ? state.order.length : state.total;
+  }
+
+  function sourceIndex(state, pos) {
+    return state.order ? state.order[pos] : pos;
+  }
+
+  function spacer(state) {
+    const node = document.createElement(state.tag);
+    node.setAttribute("aria-hidden", "true");
+    node.setAttribute("data-pui-virtual-spacer", "");
+    node.style.height = "0px";
+
+    if (state.tag === "tr") {
+      const cell = document.createElement("td");
+      cell.colSpan = state.columns;
+      cell.style.padding = "0";
+      cell.style.border = "0";
+      node.append(cell);
+    }
+
+    return node;
+  }
+
+  function placeholder(state) {
+    const node = document.createElement(state.tag);
+    node.setAttribute("data-pui-virtual-placeholder", "");
+    node.setAttribute("aria-busy", "true");
+
+    const bar = document.createElement("div");
+    bar.className = "h-3 w-2/3 animate-pulse rounded bg-muted";
+
+    if (state.tag === "tr") {
+      const cell = document.createElement("td");
+      cell.colSpan = state.columns;
+      cell.className = "px-4 align-middle";
+      cell.append(bar);
+      node.append(cell);
+    } else {
+      node.className = "flex items-center px-3";
+      node.append(bar);
+    }
+
+    return node;
+  }
+
+  function setup(el) {
+    let state = states.get(el);
+    if (state) return state;
+
+    const table = el.tagName === "TBODY" ? el.closest("table") : null;
+    const head = table && table.tHead;
+    const template = el.querySelector(":scope > template[data-pui-virtual-source]");
+
+    state = {
+      el: el,
+      table: table,
+      tag: table ? "tr" : "div",
+      headerRows: head ? head.rows.length : 0,
+      columns: head && head.rows[0]
+        ? Array.from(head.rows[0].cells).reduce((n, cell) => n + cell.colSpan, 0)
+        : 1,
+      viewport: findViewport(el),
+      height: intAttr(el, "data-pui-virtual-item-height", 36),
+      overscan: intAttr(el, "data-pui-virtual-overscan", 6),
+      url: el.getAttribute("data-pui-virtual-url"),
+      chunkSize: intAttr(el, "data-pui-virtual-chunk-size", 100),
+      params: {},
+      items: [],
+      order: null,
+      total: 0,
+      known: false,
+      pending: new Set(),
+      failed: new Set(),
+      controller: new AbortController(),
+      generation: 0,
+      rendered: new Map(),
+      frame: 0,
+    };
+
+    if (state.url) {
+      state.total = intAttr(el, "data-pui-virtual-count", 0);
+      state.known = state.total > 0;
+      state.items = new Array(state.total);
+    } else {
+      state.items = template ? collect(state, template.content) : [];
+      state.total = state.items.length;
+    }
+
+    state.top = spacer(state);
+    state.bottom = spacer(state);
+    el.append(state.top, state.bottom);
+
+    if (table && !el.hasAttribute("tabindex")) el.tabIndex = 0;
+
+    const schedule = () => {
+      if (state.frame) return;
+      state.frame = requestAnimationFrame(() => {
+        state.frame = 0;
+        render(state, false);
+      });
+    };
+
+    if (state.viewport === window) {
+      window.addEventListener("scroll", schedule, { passive: true });
+      window.addEventListener("resize", schedule);
+    } else {
+      state.viewport.addEventListener("scroll", schedule, { passive: true });
+      if (window.ResizeObserver) {
+        new ResizeObserver(schedule).observe(state.viewport);
+      }
+    }
+
+    states.set(el, state);
+    render(state, true);
+
+    return state;
+  }
+
+  // Visible offset and extent of the container inside its viewport
+  function measure(state) {
+    const { el, viewport } = state;
+
+    if (viewport === window) {
+      return { offset: -el.getBoundingClientRect().top, extent: window.innerHeight };
+    }
+
+    if (viewport === el) {
+      return { offset: el.scrollTop, extent: el.clientHeight };
+    }
+
+    return {
+      offset: viewport.getBoundingClientRect().top - el.getBoundingClientRect().top,
+      extent: viewport.clientHeight,
+    };
+  }
+
+  function visibleRange(state) {
+    const { offset, extent } = measure(state);
+    const count = size(state);
+    const top = Math.max(0, offset);
+
+    const end = Math.min(count, Math.ceil((top + extent) / state.height) + state.overscan);
+    const start = Math.min(end, Math.max(0, Math.floor(top / state.height) - state.overscan));
+
+    return [start, end];
+  }
+
+  function createItem(state, pos) {
+    const source = state.items[sourceIndex(state, pos)];
+    const node = source ? source.cloneNode(true) : placeholder(state);
+
+    node.setAttribute("data-pui-virtual-index", String(pos));
+    node.style.height = state.height + "px";
+
+    if (state.tag === "tr") {
+      node.setAttribute("aria-rowindex", String(pos + 1 + state.headerRows));
+      if (!node.hasAttribute("tabindex")) node.tabIndex = -1;
+    } else {
+      node.setAttribute("aria-setsize", String(size(state)));
+      node.setAttribute("aria-posinset", String(pos + 1));
+    }
+
+    return node;
+  }
+
+  function render(state, reset) {
+    const [start, end] = visibleRange(state);
+    let changed = reset;
+
+    if (reset) {
+      state.rendered.forEach((node) => node.remove());
+      state.rendered.clear();
+    }
+
+    state.rendered.forEach((node, pos) => {
+      const stale =
+        node.hasAttribute("data-pui-virtual-placeholder") &&
+        state.items[sourceIndex(state, pos)];
+
+      if (pos < start || pos >= end || stale) {
+        node.remove();
+        state.rendered.delete(pos);
+        changed = true;
+      }
+    });
+
+    // Walk backwards so every new node is inserted before its successor
+    let anchor = state.bottom;
+    for (let pos = end - 1; pos >= start; pos--) {
+      let node = state.rendered.get(pos);
+      if (!node) {
+        node = createItem(state, pos);
+        state.el.insertBefore(node, anchor);
+        state.rendered.set(pos, node);
+        changed = true;
+      }
+      anchor = node;
+    }
+
+    state.top.style.height = start * state.height + "px";
+    state.bottom.style.height = (size(state) - end) * state.height + "px";
+
+    if (state.table) {
+      state.table.setAttribute("aria-rowcount", String(size(state) + state.headerRows));
+    }
+
+    if (state.url) load(state, start, end);
+
+    if (changed) {
+      state.el.dispatchEvent(
+        new CustomEvent("pui-virtual:render", {
+          bubbles: true,
+          detail: { start: start, end: end, total: size(state) },
+        }),
+      );
+    }
+  }
+
+  function chunkLoaded(state, chunk) {
+    const offset = chunk * state.chunkSize;
+    const last = Math.min(offset + state.chunkSize, state.total) - 1;
+
+    return !!state.items[offset] && !!state.items[last];
+  }
+
+  function load(state, start, end) {
+    const chunks = new Set();
+    if (!state.known) chunks.add(Math.floor(start / state.chunkSize));
+
+    for (let pos = start; pos < end; pos += state.chunkSize) {
+      chunks.add(Math.floor(pos / state.chunkSize));
+    }
+    if (end > start) chunks.add(Math.floor((end - 1) / state.chunkSize));
+
+    chunks.forEach((chunk) => {
+      if (state.pending.has(chunk) || state.failed.has(chunk)) return;
+      if (state.known && chunkLoaded(state, chunk)) return;
+
+      fetchChunk(state, chunk);
+    });
+  }
+
+  function fetchChunk(state, chunk) {
+    const generation = state.generation;
+    const offset = chunk * state.chunkSize;
+
+    const target = new URL(state.url, window.location.href);
+    Object.entries(state.params).forEach(([key, value]) => {
+      if (value !== undefined && value !== null && value !== "") {
+        target.searchParams.set(key, value);
+      }
+    });
+    target.searchParams.set("offset", String(offset));
+    target.searchParams.set("limit", String(state.chunkSize));
+
+    state.pending.add(chunk);
+
+    fetch(target, {
+      signal: state.controller.signal,
+      headers: { Accept: "text/html" },
+    })
+      .then((res) => {
+        if (!res.ok) throw new Error("HTTP " + res.status);
+
+        const total = parseInt(res.headers.get("X-Total-Count"));
+        return res.text().then((markup) => ({ markup: markup, total: total }));
+      })
+      .then(({ markup, total }) => {
+        if (generation !== state.generation) return;
+        state.pending.delete(chunk);
+
+        const parsed = document.createElement("template");
+        parsed.innerHTML = markup;
+        const items = collect(state, parsed.content);
+
+        let reset = false;
+        if (!isNaN(total) && (total !== state.total || !state.known)) {
+          reset = total !== state.total;
+          state.total = total;
+          state.items.length = total;
+        }
+        state.known = true;
+
+        items.forEach((item, i) => {
+          if (offset + i < state.total) state.items[offset + i] = item;
+        });
+
+        render(state, reset);
+      })
+      .catch((err) => {
+        if (err.name === "AbortError" || generation !== state.generation) return;
+
+        state.pending.delete(chunk);
+        state.failed.add(chunk);
+        state.el.dispatchEvent(
+          new CustomEvent("pui-virtual:error", {
+            bubbles: true,
+            detail: { offset: offset, error: err },
+          }),
+        );
+      });
+  }
+
+  function scrollTo(state, top) {
+    const target = state.viewport === window ? window : state.viewport;
+    target.scrollTo({ top: Math.max(0, top), behavior: "instant" });
+  }
+
+  function scrollToIndex(state, pos) {
+    const { el, viewport, height } = state;
+
+    if (viewport === window) {
+      const top = el.getBoundingClientRect().top + window.scrollY + pos * height;
+      if (top < window.scrollY) {
+        scrollTo(state, top);
+      } else if (top + height > window.scrollY + window.innerHeight) {
+        scrollTo(state, top + height - window.innerHeight);
+      }
+      return;
+    }
+
+    // Sticky table headers cover the first rows of the viewport
+    const inset =
+      viewport !== el && state.table && state.table.tHead
+        ? state.table.tHead.offsetHeight
+        : 0;
+    const base =
+      viewport === el
+        ? 0
+        : el.getBoundingClientRect().top -
+          viewport.getBoundingClientRect().top +
+          viewport.scrollTop;
+    const top = base + pos * height;
+
+    if (top - inset < viewport.scrollTop) {
+      scrollTo(state, top - inset);
+    } else if (top + height > viewport.scrollTop + viewport.clientHeight) {
+      scrollTo(state, top + height - viewport.clientHeight);
+    }
+  }
+
+  function currentIndex(state) {
+    const active = document.activeElement;
+    if (!active || !state.el.contains(active)) return -1;
+
+    const item = active.closest("[data-pui-virtual-index]");
+    return item ? parseInt(item.getAttribute("data-pui-virtual-index")) : -1;
+  }
+
+  function isDisabled(state, pos) {
+    const item = state.items[sourceIndex(state, pos)];
+    return !!item && item.getAttribute("aria-disabled") === "true";
+  }
+
+  function focusIndex(el, pos) {
+    const state = setup(el);
+    const count = size(state);
+    if (count === 0) return;
+
+    pos = Math.max(0, Math.min(count - 1, pos));
+    scrollToIndex(state, pos);
+    render(state, false);
+    state.rendered.get(pos)?.focus({ preventScroll: true });
+  }
+
+  // Move focus by delta items, skipping disabled ones
+  function move(el, delta) {
+    const state = setup(el);
+    const count = size(state);
+    if (count === 0) return;
+
+    const current = currentIndex(state);
+    const step = delta > 0 ? 1 : -1;
+    let pos = current < 0 ? (delta > 0 ? 0 : count - 1) : current + delta;
+    pos = Math.max(0, Math.min(count - 1, pos));
+
+    while (pos >= 0 && pos < count && isDisabled(state, pos)) pos += step;
+    if (pos < 0 || pos >= count) return;
+
+    focusIndex(el, pos);
+  }
+
+  // Keep only the items accepted by predicate, or all of them when it is null
+  function filter(el, predicate) {
+    const state = setup(el);
+    if (state.url) return;
+
+    state.order = predicate
+      ? state.items.reduce((order, item, i) => {
+          if (predicate(item)) order.push(i);
+          return order;
+        }, [])
+      : null;
+
+    if (state.viewport === el) el.scrollTop = 0;
+    render(state, true);
+  }
+
+  // Replace the extra query parameters sent with every chunk and reload
+  function setParams(el, params) {
+    const state = setup(el);
+    if (!state.url) return;
+
+    state.controller.abort();
+    state.controller = new AbortController();
+    state.generation++;
+    state.params = Object.assign({}, params);
+    state.items = new Array(state.total);
+    state.known = false;
+    state.pending.clear();
+    state.failed.clear();
+
+    if (state.viewport === el) el.scrollTop = 0;
+    render(state, true);
+  }
+
+  function isEditable(target) {
+    return (
+      target.isContentEditable ||
+      /^(INPUT|TEXTAREA|SELECT)$/.test(target.tagName || "")
+    );
+  }
+
+  document.addEventListener("keydown", (e) => {
+    const el = e.target.closest?.("[data-pui-virtual]");
+    if (!el || isEditable(e.target)) return;
+
+    const state = setup(el);
+    const page = Math.max(1, Math.floor(measure(state).extent / state.height) - 1);
+
+    switch (e.key) {
+      case "ArrowDown":
+        move(el, 1);
+        break;
+      case "ArrowUp":
+        move(el, -1);
+        break;
+      case "PageDown":
+        move(el, page);
+        break;
+      case "PageUp":
+        move(el, -page);
+        break;
+      case "Home":
+        focusIndex(el, 0);
+        break;
+      case "End":
+        focusIndex(el, size(state) - 1);
+        break;
+      default:
+        return;
+    }
+
+    e.preventDefault();
+  });
+
+  function init() {
+    document.querySelectorAll("[data-pui-virtual]").forEach(setup);
+  }
+
+  if (document.readyState === "loading") {
+    document.addEventListener("DOMContentLoaded", init);
+  } else {
+    init();
+  }
+
+  // Expose public API
+  window.tui = window.tui || {};
+  window.tui.virtual = {
+    init: init,
+    refresh: (el) => render(setup(el), true),
+    items: (el) => setup(el).items.filter(Boolean),
+    filter: filter,
+    setParams: setParams,
+    focusIndex: focusIndex,
+    move: move,
+  };
+})();